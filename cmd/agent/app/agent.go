@@ -70,7 +70,10 @@ func Run(ctx context.Context, opts *options.VolcanoAgentOptions) error {
 	if sfsFsPath == "" {
 		sfsFsPath = utils.DefaultSysFsPath
 	}
-	cgroupManager := cgroup.NewCgroupManager("cgroupfs", path.Join(sfsFsPath, "cgroup"), conf.GenericConfiguration.KubeCgroupRoot)
+	cgroupRoot := path.Join(sfsFsPath, "cgroup")
+	cgroupVersion := cgroup.DetectCgroupVersion(cgroupRoot)
+	klog.InfoS("Detected cgroup version", "version", cgroupVersion, "cgroupRoot", cgroupRoot)
+	cgroupManager := cgroup.NewCgroupManagerWithVersion(cgroupVersion, "cgroupfs", cgroupRoot, conf.GenericConfiguration.KubeCgroupRoot)
 	metricCollectorManager, err := metriccollect.NewMetricCollectorManager(conf, cgroupManager)
 	if err != nil {
 		return fmt.Errorf("failed to create metric collector manager: %v", err)
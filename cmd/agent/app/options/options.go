@@ -60,6 +60,9 @@ type VolcanoAgentOptions struct {
 	// OverSubscriptionRatio is the over subscription ratio of idle resources, default to 60, which means 60%.
 	OverSubscriptionRatio int
 
+	// EvictionRankPolicy defines the policy ranking the offline pods to be evicted.
+	EvictionRankPolicy string
+
 	// IncludeSystemUsage determines whether considering system usage when calculate overSubscription resource and evict.
 	IncludeSystemUsage bool
 }
@@ -78,6 +81,7 @@ func (options *VolcanoAgentOptions) AddFlags(c *cobra.Command) {
 	c.Flags().StringVar(&options.KubePodName, "kube-pod-name", os.Getenv("KUBE_POD_NAME"), "the name of the pod")
 	c.Flags().StringVar(&options.KubePodNamespace, "kube-pod-namespace", os.Getenv("KUBE_POD_NAMESPACE"), "the namespace of the pod")
 	c.Flags().StringVar(&options.OverSubscriptionPolicy, "oversubscription-policy", "extend", "The oversubscription policy determines where oversubscription resources to report and how to use, default to extend means report to extend resources")
+	c.Flags().StringVar(&options.EvictionRankPolicy, "eviction-rank-policy", "default", "The eviction rank policy determines which offline pods are evicted first when node has pressure, default to rank by the recently evicted penalty, priority, resource usage and runtime")
 	// TODO: put in configMap.
 	c.Flags().IntVar(&options.OverSubscriptionRatio, "oversubscription-ratio", defaultOverSubscriptionRatio, "The oversubscription ratio determines how many idle resources can be oversold")
 	c.Flags().BoolVar(&options.IncludeSystemUsage, "include-system-usage", false, "It determines whether considering system usage when calculate overSubscription resource and evict.")
@@ -100,6 +104,7 @@ func (options *VolcanoAgentOptions) ApplyTo(cfg *config.Configuration) error {
 	cfg.GenericConfiguration.KubePodNamespace = options.KubePodNamespace
 	cfg.GenericConfiguration.OverSubscriptionPolicy = options.OverSubscriptionPolicy
 	cfg.GenericConfiguration.OverSubscriptionRatio = options.OverSubscriptionRatio
+	cfg.GenericConfiguration.EvictionRankPolicy = options.EvictionRankPolicy
 	cfg.GenericConfiguration.IncludeSystemUsage = options.IncludeSystemUsage
 	return nil
 }
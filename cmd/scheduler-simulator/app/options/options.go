@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	scheduleroptions "volcano.sh/volcano/cmd/scheduler/app/options"
+)
+
+const (
+	defaultCycles       = 1
+	defaultOutputFormat = "text"
+)
+
+// SimulatorOption is the options of the scheduler simulator. It accepts all the vc-scheduler
+// flags, so that the scheduler command line can be replayed against a snapshot as it is.
+type SimulatorOption struct {
+	*scheduleroptions.ServerOption
+
+	// SnapshotFile is the cache snapshot dumped by vc-scheduler
+	SnapshotFile string
+	// Cycles is the number of scheduling cycles to simulate
+	Cycles int
+	// Output is the file the report is written to, stdout if empty
+	Output string
+	// OutputFormat is the format of the report, text or json
+	OutputFormat string
+}
+
+// NewSimulatorOption creates a new SimulatorOption.
+func NewSimulatorOption() *SimulatorOption {
+	return &SimulatorOption{
+		ServerOption: scheduleroptions.NewServerOption(),
+	}
+}
+
+// AddFlags adds flags for the simulator to the specified FlagSet.
+func (s *SimulatorOption) AddFlags(fs *pflag.FlagSet) {
+	s.ServerOption.AddFlags(fs)
+
+	fs.StringVar(&s.SnapshotFile, "snapshot", "", "The cache snapshot file dumped by vc-scheduler")
+	fs.IntVar(&s.Cycles, "cycles", defaultCycles, "The number of scheduling cycles to simulate")
+	fs.StringVar(&s.Output, "output", "", "The file the report is written to; it is stdout by default")
+	fs.StringVar(&s.OutputFormat, "output-format", defaultOutputFormat, "The format of the report, text or json")
+}
+
+// CheckOptionOrDie checks the simulator options.
+func (s *SimulatorOption) CheckOptionOrDie() error {
+	if s.SnapshotFile == "" {
+		return fmt.Errorf("--snapshot is required")
+	}
+	if s.Cycles <= 0 {
+		return fmt.Errorf("--cycles must be positive, got %d", s.Cycles)
+	}
+	if s.OutputFormat != "text" && s.OutputFormat != "json" {
+		return fmt.Errorf("unsupported --output-format %q, must be text or json", s.OutputFormat)
+	}
+	if len(s.SchedulerNames) == 0 {
+		return fmt.Errorf("--scheduler-name is required")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/cmd/scheduler-simulator/app/options"
+	"volcano.sh/volcano/pkg/scheduler"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/simulator"
+)
+
+// Run replays the snapshot with the scheduler configuration and writes the report.
+func Run(opt *options.SimulatorOption) error {
+	if opt.PluginsDir != "" {
+		if err := framework.LoadCustomPlugins(opt.PluginsDir); err != nil {
+			return fmt.Errorf("failed to load custom plugins: %v", err)
+		}
+	}
+
+	schedulerConf := scheduler.DefaultSchedulerConf
+	if opt.SchedulerConf != "" {
+		confData, err := os.ReadFile(opt.SchedulerConf)
+		if err != nil {
+			return fmt.Errorf("failed to read scheduler config %s: %v", opt.SchedulerConf, err)
+		}
+		schedulerConf = strings.TrimSpace(string(confData))
+	}
+	actions, tiers, configurations, _, err := scheduler.UnmarshalSchedulerConf(schedulerConf)
+	if err != nil {
+		return fmt.Errorf("invalid scheduler config: %v", err)
+	}
+
+	snapshot, err := schedcache.LoadSnapshotFile(opt.SnapshotFile)
+	if err != nil {
+		return err
+	}
+	klog.V(2).Infof("Loaded snapshot %s with %d nodes, %d pods, %d podgroups and %d queues",
+		opt.SnapshotFile, len(snapshot.Nodes), len(snapshot.Pods), len(snapshot.PodGroups), len(snapshot.Queues))
+
+	sim := simulator.New(snapshot, opt.SchedulerNames[0], actions, tiers, configurations)
+	report := sim.Run(opt.Cycles)
+
+	var out io.Writer = os.Stdout
+	if opt.Output != "" {
+		file, err := os.Create(opt.Output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if opt.OutputFormat == "json" {
+		return report.WriteJSON(out)
+	}
+	return report.WriteText(out)
+}
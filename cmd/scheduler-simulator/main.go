@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/cmd/scheduler-simulator/app"
+	"volcano.sh/volcano/cmd/scheduler-simulator/app/options"
+	"volcano.sh/volcano/pkg/version"
+
+	// Import default actions/plugins.
+	_ "volcano.sh/volcano/pkg/scheduler/actions"
+	_ "volcano.sh/volcano/pkg/scheduler/plugins"
+
+	// init assert
+	_ "volcano.sh/volcano/pkg/scheduler/util/assert"
+)
+
+func main() {
+	klog.InitFlags(nil)
+
+	fs := pflag.CommandLine
+	s := options.NewSimulatorOption()
+
+	s.AddFlags(fs)
+	utilfeature.DefaultMutableFeatureGate.AddFlag(fs)
+	s.RegisterOptions()
+
+	cliflag.InitFlags()
+
+	if s.PrintVersion {
+		version.PrintVersionAndExit()
+		return
+	}
+
+	if err := s.CheckOptionOrDie(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	defer klog.Flush()
+
+	if err := app.Run(s); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
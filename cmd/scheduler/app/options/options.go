@@ -85,7 +85,13 @@ type ServerOption struct {
 	NodeSelector      []string
 	CacheDumpFileDir  string
 	EnableCacheDumper bool
-	NodeWorkerThreads uint32
+	// CacheDumpTokenFile is the file containing the bearer token required by the /debug/snapshot,
+	// /debug/traces and /debug/dryrun endpoints, the endpoints are disabled when it is empty
+	CacheDumpTokenFile string
+	NodeWorkerThreads  uint32
+	// TraceSessions is the number of recent sessions whose scheduling decisions are traced,
+	// tracing is disabled when it is 0
+	TraceSessions int
 
 	// IgnoredCSIProvisioners contains a list of provisioners, and pod request pvc with these provisioners will
 	// not be counted in pod pvc resource request and node.Allocatable, because the spec.drivers of csinode resource
@@ -146,6 +152,8 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	fs.StringSliceVar(&s.NodeSelector, "node-selector", nil, "volcano only work with the labeled node, like: --node-selector=volcano.sh/role:train --node-selector=volcano.sh/role:serving")
 	fs.BoolVar(&s.EnableCacheDumper, "cache-dumper", true, "Enable the cache dumper, it's true by default")
 	fs.StringVar(&s.CacheDumpFileDir, "cache-dump-dir", "/tmp", "The target dir where the json file put at when dump cache info to json file")
+	fs.StringVar(&s.CacheDumpTokenFile, "cache-dump-token-file", "", "The file containing the bearer token required to get the cache snapshot from the /debug/snapshot endpoint, the traces from the /debug/traces endpoint and the dry-run victims from the /debug/dryrun endpoint on --listen-address; the endpoints are disabled if it is empty")
+	fs.IntVar(&s.TraceSessions, "trace-sessions", 0, "The number of recent scheduling sessions whose per-task decisions are traced and served on the /debug/traces endpoint on --listen-address, which requires --cache-dump-token-file; tracing is disabled if it is 0")
 	fs.Uint32Var(&s.NodeWorkerThreads, "node-worker-threads", defaultNodeWorkers, "The number of threads syncing node operations.")
 	fs.StringSliceVar(&s.IgnoredCSIProvisioners, "ignored-provisioners", nil, "The provisioners that will be ignored during pod pvc request computation and preemption.")
 }
@@ -18,10 +18,12 @@ package app
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"strings"
 
 	"volcano.sh/apis/pkg/apis/helpers"
 	"volcano.sh/volcano/cmd/scheduler/app/options"
@@ -69,8 +71,47 @@ func Run(opt *options.ServerOption) error {
 		panic(err)
 	}
 
-	if opt.EnableMetrics || opt.EnablePprof {
-		go startMetricsServer(opt)
+	// the debug endpoints expose the state of the cluster, they are only served to the
+	// requests carrying the bearer token
+	debugHandlers := map[string]http.Handler{}
+	debugToken := ""
+	if opt.CacheDumpTokenFile != "" {
+		token, err := os.ReadFile(opt.CacheDumpTokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read cache dump token file %s: %v", opt.CacheDumpTokenFile, err)
+		}
+		debugToken = strings.TrimSpace(string(token))
+	}
+	if opt.EnableCacheDumper && debugToken != "" {
+		snapshotHandler, err := withBearerToken(debugToken, sched.CacheDumper())
+		if err != nil {
+			return err
+		}
+		debugHandlers["/debug/snapshot"] = snapshotHandler
+	}
+	if tracer := sched.Tracer(); tracer != nil {
+		if debugToken == "" {
+			klog.Warningf("The /debug/traces endpoint is disabled because --cache-dump-token-file is not set")
+		} else {
+			tracesHandler, err := withBearerToken(debugToken, tracer)
+			if err != nil {
+				return err
+			}
+			debugHandlers["/debug/traces"] = tracesHandler
+		}
+	}
+
+	if debugToken != "" {
+		// the victims reported in dry-run mode are served along with the other debug endpoints
+		dryRunHandler, err := withBearerToken(debugToken, sched.DryRunRecorder())
+		if err != nil {
+			return err
+		}
+		debugHandlers["/debug/dryrun"] = dryRunHandler
+	}
+
+	if opt.EnableMetrics || opt.EnablePprof || len(debugHandlers) != 0 {
+		go startMetricsServer(opt, debugHandlers)
 	}
 
 	if opt.EnableHealthz {
@@ -140,7 +181,23 @@ func Run(opt *options.ServerOption) error {
 	return fmt.Errorf("lost lease")
 }
 
-func startMetricsServer(opt *options.ServerOption) {
+// withBearerToken only passes the requests which carry the given bearer token to the handler.
+func withBearerToken(token string, handler http.Handler) (http.Handler, error) {
+	if token == "" {
+		return nil, fmt.Errorf("the bearer token must not be empty")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}), nil
+}
+
+func startMetricsServer(opt *options.ServerOption, debugHandlers map[string]http.Handler) {
 	mux := http.NewServeMux()
 
 	if opt.EnableMetrics {
@@ -155,6 +212,10 @@ func startMetricsServer(opt *options.ServerOption) {
 		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 	}
 
+	for pattern, handler := range debugHandlers {
+		mux.Handle(pattern, handler)
+	}
+
 	server := &http.Server{
 		Addr:              opt.ListenAddress,
 		Handler:           mux,
@@ -21,9 +21,11 @@ import (
 	"io"
 	"net/http"
 
+	"google.golang.org/protobuf/encoding/protojson"
 	"k8s.io/klog/v2"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/plugins/extender"
+	"volcano.sh/volcano/pkg/scheduler/plugins/extender/extenderpb"
 )
 
 var snapshot *api.ClusterInfo
@@ -66,14 +68,14 @@ func predicate(w http.ResponseWriter, r *http.Request) {
 
 	r.Body.Close()
 
-	req := &extender.PredicateRequest{}
-	if err := json.Unmarshal(content, req); err != nil || req.Task == nil || req.Node == nil {
+	req := &extenderpb.PredicateRequest{}
+	if err := protojson.Unmarshal(content, req); err != nil || req.Task == nil || req.Node == nil {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	resp := &extender.PredicateResponse{}
-	if req.Task.BestEffort && len(req.Node.Tasks) > 10 {
+	if isBestEffort(req.Task) && len(req.Node.TaskUids) > 10 {
 		resp.ErrorMessage = "Too many tasks on the node"
 		resp.Code = api.Unschedulable
 	}
@@ -97,15 +99,15 @@ func prioritize(w http.ResponseWriter, r *http.Request) {
 
 	r.Body.Close()
 
-	req := &extender.PrioritizeRequest{}
-	if err := json.Unmarshal(content, req); err != nil || req.Task == nil || len(req.Nodes) == 0 {
+	req := &extenderpb.PrioritizeRequest{}
+	if err := protojson.Unmarshal(content, req); err != nil || req.Task == nil || len(req.Nodes) == 0 {
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	resp := &extender.PrioritizeResponse{NodeScore: map[string]float64{}}
 	for i := range req.Nodes {
-		if req.Task.BestEffort && len(req.Nodes[i].Tasks) > 5 {
+		if isBestEffort(req.Task) && len(req.Nodes[i].TaskUids) > 5 {
 			resp.NodeScore[req.Nodes[i].Name] = 0
 		} else {
 			resp.NodeScore[req.Nodes[i].Name] = 1
@@ -123,6 +125,11 @@ func prioritize(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// isBestEffort tells whether the task requests neither cpu nor memory.
+func isBestEffort(task *extenderpb.Task) bool {
+	return task.GetResreq().GetMilliCpu() == 0 && task.GetResreq().GetMemory() == 0
+}
+
 func main() {
 	http.HandleFunc("/onSessionOpen", onSessionOpen)
 	http.HandleFunc("/onSessionClose", onSessionClose)
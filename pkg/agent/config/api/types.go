@@ -59,6 +59,12 @@ type ColocationConfig struct {
 	// network qos related config.
 	NetworkQosConfig *NetworkQos `json:"networkQosConfig,omitempty" configKey:"NetworkQoS"`
 
+	// last level cache and memory bandwidth isolation related config.
+	ResctrlConfig *Resctrl `json:"resctrlConfig,omitempty" configKey:"Resctrl"`
+
+	// disk io qos related config.
+	IOQosConfig *IOQos `json:"ioQosConfig,omitempty" configKey:"IOQoS"`
+
 	// overSubscription related config.
 	OverSubscriptionConfig *OverSubscription `json:"overSubscriptionConfig,omitempty" configKey:"OverSubscription"`
 
@@ -94,6 +100,38 @@ type NetworkQos struct {
 	QoSCheckInterval *int `json:"qosCheckInterval,omitempty"`
 }
 
+type Resctrl struct {
+	// Enable Resctrl or not.
+	Enable *bool `json:"enable,omitempty"`
+	// OfflineL3CachePercent presents the percent of the L3 cache ways the offline pods can use.
+	OfflineL3CachePercent *int `json:"offlineL3CachePercent,omitempty"`
+	// OfflineMemoryBandwidthPercent presents the percent of the memory bandwidth the offline pods can use.
+	OfflineMemoryBandwidthPercent *int `json:"offlineMemoryBandwidthPercent,omitempty"`
+}
+
+type IOQos struct {
+	// Enable IOQoS or not.
+	Enable *bool `json:"enable,omitempty"`
+	// OfflineIOWeight presents the io weight of the offline pods in the range of [1, 10000], the default io weight of a
+	// cgroup is 100.
+	OfflineIOWeight *int `json:"offlineIOWeight,omitempty"`
+	// OfflineDeviceLimits presents the throughput and iops limits of every offline pod on the block devices.
+	OfflineDeviceLimits []IODeviceLimit `json:"offlineDeviceLimits,omitempty"`
+}
+
+type IODeviceLimit struct {
+	// Device presents the major and minor number of the block device, e.g. 8:0.
+	Device string `json:"device"`
+	// ReadBps presents the read bytes per second limit, zero or unset means unlimited.
+	ReadBps *int64 `json:"readBps,omitempty"`
+	// WriteBps presents the write bytes per second limit, zero or unset means unlimited.
+	WriteBps *int64 `json:"writeBps,omitempty"`
+	// ReadIOPS presents the read io operations per second limit, zero or unset means unlimited.
+	ReadIOPS *int64 `json:"readIOPS,omitempty"`
+	// WriteIOPS presents the write io operations per second limit, zero or unset means unlimited.
+	WriteIOPS *int64 `json:"writeIOPS,omitempty"`
+}
+
 type OverSubscription struct {
 	// Enable OverSubscription or not.
 	Enable *bool `json:"enable,omitempty"`
@@ -110,4 +148,19 @@ type Evicting struct {
 	EvictingCPULowWatermark *int `json:"evictingCPULowWatermark,omitempty"`
 	// EvictingMemoryLowWatermark defines the low watermark percent of memory usage when the node could recover schedule pods.
 	EvictingMemoryLowWatermark *int `json:"evictingMemoryLowWatermark,omitempty"`
+	// EvictingCPUPressureThreshold defines the percent of time some tasks stalled on cpu in the last 10 seconds,
+	// read from the pressure stall information, above which offline pods are evicted. Zero or unset disables it.
+	EvictingCPUPressureThreshold *int `json:"evictingCPUPressureThreshold,omitempty"`
+	// EvictingMemoryPressureThreshold defines the percent of time some tasks stalled on memory in the last 10 seconds,
+	// read from the pressure stall information, above which offline pods are evicted. Zero or unset disables it.
+	EvictingMemoryPressureThreshold *int `json:"evictingMemoryPressureThreshold,omitempty"`
+	// EvictingIOPressureThreshold defines the percent of time some tasks stalled on io in the last 10 seconds,
+	// read from the pressure stall information, above which the node stops scheduling offline pods. Zero or unset disables it.
+	EvictingIOPressureThreshold *int `json:"evictingIOPressureThreshold,omitempty"`
+	// EvictingRateLimit defines the max number of offline pods evicted per minute on the node when it has pressure.
+	// Zero or unset means unlimited.
+	EvictingRateLimit *int `json:"evictingRateLimit,omitempty"`
+	// EvictingDryRun defines whether to only emit events of the offline pods which would be evicted, without evicting
+	// them or disabling schedule.
+	EvictingDryRun *bool `json:"evictingDryRun,omitempty"`
 }
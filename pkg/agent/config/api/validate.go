@@ -19,6 +19,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -34,6 +35,16 @@ var (
 	IllegalEvictingMemoryLowWatermark                            = "evictingMemoryLowWatermark must be a positive number"
 	EvictingCPULowWatermarkHigherThanHighWatermark               = "cpu evicting low watermark is higher than high watermark"
 	EvictingMemoryLowWatermarkHigherThanHighWatermark            = "memory evicting low watermark is higher than high watermark"
+	IllegalEvictingCPUPressureThreshold                          = "evictingCPUPressureThreshold must be in the range of [0, 100]"
+	IllegalEvictingMemoryPressureThreshold                       = "evictingMemoryPressureThreshold must be in the range of [0, 100]"
+	IllegalEvictingIOPressureThreshold                           = "evictingIOPressureThreshold must be in the range of [0, 100]"
+	IllegalEvictingRateLimit                                     = "evictingRateLimit must not be negative"
+	IllegalOfflineL3CachePercent                                 = "offlineL3CachePercent must be a positive number between 1 and 100"
+	IllegalOfflineMemoryBandwidthPercent                         = "offlineMemoryBandwidthPercent must be a positive number between 1 and 100"
+	IllegalOfflineIOWeight                                       = "offlineIOWeight must be a positive number between 1 and 10000"
+	IllegalIODevice                                              = "device(%s) must be in the format of major:minor"
+	DuplicatedIODevice                                           = "device(%s) is duplicated in offlineDeviceLimits"
+	IllegalIODeviceLimit                                         = "limits of device(%s) must not be negative"
 	IllegalOverSubscriptionTypes                                 = "overSubscriptionType(%s) is not supported, only supports cpu/memory"
 )
 
@@ -84,6 +95,52 @@ func (n *NetworkQos) Validate() []error {
 	return errs
 }
 
+func (r *Resctrl) Validate() []error {
+	if r == nil {
+		return nil
+	}
+
+	var errs []error
+	if r.OfflineL3CachePercent != nil && (*r.OfflineL3CachePercent <= 0 || *r.OfflineL3CachePercent > 100) {
+		errs = append(errs, errors.New(IllegalOfflineL3CachePercent))
+	}
+	if r.OfflineMemoryBandwidthPercent != nil && (*r.OfflineMemoryBandwidthPercent <= 0 || *r.OfflineMemoryBandwidthPercent > 100) {
+		errs = append(errs, errors.New(IllegalOfflineMemoryBandwidthPercent))
+	}
+	return errs
+}
+
+var ioDeviceRegexp = regexp.MustCompile(`^[0-9]+:[0-9]+$`)
+
+func (i *IOQos) Validate() []error {
+	if i == nil {
+		return nil
+	}
+
+	var errs []error
+	if i.OfflineIOWeight != nil && (*i.OfflineIOWeight <= 0 || *i.OfflineIOWeight > 10000) {
+		errs = append(errs, errors.New(IllegalOfflineIOWeight))
+	}
+	devices := make(map[string]bool)
+	for _, limit := range i.OfflineDeviceLimits {
+		if !ioDeviceRegexp.MatchString(limit.Device) {
+			errs = append(errs, fmt.Errorf(IllegalIODevice, limit.Device))
+			continue
+		}
+		if devices[limit.Device] {
+			errs = append(errs, fmt.Errorf(DuplicatedIODevice, limit.Device))
+		}
+		devices[limit.Device] = true
+		for _, value := range []*int64{limit.ReadBps, limit.WriteBps, limit.ReadIOPS, limit.WriteIOPS} {
+			if value != nil && *value < 0 {
+				errs = append(errs, fmt.Errorf(IllegalIODeviceLimit, limit.Device))
+				break
+			}
+		}
+	}
+	return errs
+}
+
 func (o *OverSubscription) Validate() []error {
 	if o == nil {
 		return nil
@@ -126,6 +183,18 @@ func (e *Evicting) Validate() []error {
 	if e.EvictingMemoryLowWatermark != nil && e.EvictingMemoryHighWatermark != nil && (*e.EvictingMemoryLowWatermark > *e.EvictingMemoryHighWatermark) {
 		errs = append(errs, errors.New(EvictingMemoryLowWatermarkHigherThanHighWatermark))
 	}
+	if e.EvictingCPUPressureThreshold != nil && (*e.EvictingCPUPressureThreshold < 0 || *e.EvictingCPUPressureThreshold > 100) {
+		errs = append(errs, errors.New(IllegalEvictingCPUPressureThreshold))
+	}
+	if e.EvictingMemoryPressureThreshold != nil && (*e.EvictingMemoryPressureThreshold < 0 || *e.EvictingMemoryPressureThreshold > 100) {
+		errs = append(errs, errors.New(IllegalEvictingMemoryPressureThreshold))
+	}
+	if e.EvictingIOPressureThreshold != nil && (*e.EvictingIOPressureThreshold < 0 || *e.EvictingIOPressureThreshold > 100) {
+		errs = append(errs, errors.New(IllegalEvictingIOPressureThreshold))
+	}
+	if e.EvictingRateLimit != nil && *e.EvictingRateLimit < 0 {
+		errs = append(errs, errors.New(IllegalEvictingRateLimit))
+	}
 	return errs
 }
 
@@ -140,6 +209,8 @@ func (c *ColocationConfig) Validate() []error {
 	errs = append(errs, c.CPUBurstConfig.Validate()...)
 	errs = append(errs, c.MemoryQosConfig.Validate()...)
 	errs = append(errs, c.NetworkQosConfig.Validate()...)
+	errs = append(errs, c.ResctrlConfig.Validate()...)
+	errs = append(errs, c.IOQosConfig.Validate()...)
 	errs = append(errs, c.OverSubscriptionConfig.Validate()...)
 	errs = append(errs, c.EvictingConfig.Validate()...)
 	return errs
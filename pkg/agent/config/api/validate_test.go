@@ -118,6 +118,35 @@ func TestColocationConfigValidate(t *testing.T) {
 				errors.New(IllegalEvictingCPULowWatermark), errors.New(IllegalEvictingMemoryLowWatermark)},
 		},
 
+		{
+			name: "illegal ResctrlConfig",
+			colocationCfg: &ColocationConfig{
+				ResctrlConfig: &Resctrl{
+					Enable:                        utilpointer.Bool(true),
+					OfflineL3CachePercent:         utilpointer.Int(0),
+					OfflineMemoryBandwidthPercent: utilpointer.Int(101),
+				},
+			},
+			expectedErr: []error{errors.New(IllegalOfflineL3CachePercent), errors.New(IllegalOfflineMemoryBandwidthPercent)},
+		},
+
+		{
+			name: "illegal IOQosConfig",
+			colocationCfg: &ColocationConfig{
+				IOQosConfig: &IOQos{
+					Enable:          utilpointer.Bool(true),
+					OfflineIOWeight: utilpointer.Int(10001),
+					OfflineDeviceLimits: []IODeviceLimit{
+						{Device: "sda", ReadBps: utilpointer.Int64(1048576)},
+						{Device: "8:0", WriteIOPS: utilpointer.Int64(-1)},
+						{Device: "8:0", ReadBps: utilpointer.Int64(1048576)},
+					},
+				},
+			},
+			expectedErr: []error{errors.New(IllegalOfflineIOWeight), fmt.Errorf(IllegalIODevice, "sda"),
+				fmt.Errorf(IllegalIODeviceLimit, "8:0"), fmt.Errorf(DuplicatedIODevice, "8:0")},
+		},
+
 		{
 			name: "illegal OverSubscriptionConfig",
 			colocationCfg: &ColocationConfig{
@@ -140,6 +169,28 @@ func TestColocationConfigValidate(t *testing.T) {
 			},
 			expectedErr: []error{errors.New(EvictingCPULowWatermarkHigherThanHighWatermark), errors.New(EvictingMemoryLowWatermarkHigherThanHighWatermark)},
 		},
+		{
+			name: "illegal evicting pressure thresholds",
+			colocationCfg: &ColocationConfig{
+				EvictingConfig: &Evicting{
+					EvictingCPUPressureThreshold:    utilpointer.Int(-1),
+					EvictingMemoryPressureThreshold: utilpointer.Int(101),
+					EvictingIOPressureThreshold:     utilpointer.Int(200),
+				},
+			},
+			expectedErr: []error{errors.New(IllegalEvictingCPUPressureThreshold), errors.New(IllegalEvictingMemoryPressureThreshold),
+				errors.New(IllegalEvictingIOPressureThreshold)},
+		},
+		{
+			name: "illegal evicting rate limit",
+			colocationCfg: &ColocationConfig{
+				EvictingConfig: &Evicting{
+					EvictingRateLimit: utilpointer.Int(-1),
+					EvictingDryRun:    utilpointer.Bool(true),
+				},
+			},
+			expectedErr: []error{errors.New(IllegalEvictingRateLimit)},
+		},
 	}
 
 	for _, tc := range testCases {
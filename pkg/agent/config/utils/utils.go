@@ -44,6 +44,13 @@ const (
 	DefaultOfflineHighBandwidthPercent     = 40
 	DefaultNetworkQoSInterval              = 10000000 // 1000000 纳秒 = 10 毫秒
 
+	// Resctrl config
+	DefaultOfflineL3CachePercent         = 30
+	DefaultOfflineMemoryBandwidthPercent = 30
+
+	// IOQoS config
+	DefaultOfflineIOWeight = 10
+
 	// OverSubscription config
 	DefaultOverSubscriptionTypes = "cpu,memory"
 
@@ -100,6 +107,15 @@ func DefaultColocationConfig() *api.ColocationConfig {
 			OfflineHighBandwidthPercent:     utilpointer.Int(DefaultOfflineHighBandwidthPercent),
 			QoSCheckInterval:                utilpointer.Int(DefaultNetworkQoSInterval),
 		},
+		ResctrlConfig: &api.Resctrl{
+			Enable:                        utilpointer.Bool(false),
+			OfflineL3CachePercent:         utilpointer.Int(DefaultOfflineL3CachePercent),
+			OfflineMemoryBandwidthPercent: utilpointer.Int(DefaultOfflineMemoryBandwidthPercent),
+		},
+		IOQosConfig: &api.IOQos{
+			Enable:          utilpointer.Bool(false),
+			OfflineIOWeight: utilpointer.Int(DefaultOfflineIOWeight),
+		},
 		OverSubscriptionConfig: &api.OverSubscription{
 			Enable:                utilpointer.Bool(true),
 			OverSubscriptionTypes: utilpointer.String(DefaultOverSubscriptionTypes),
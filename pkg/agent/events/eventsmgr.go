@@ -32,9 +32,11 @@ import (
 	_ "volcano.sh/volcano/pkg/agent/events/handlers/cpuburst"
 	_ "volcano.sh/volcano/pkg/agent/events/handlers/cpuqos"
 	_ "volcano.sh/volcano/pkg/agent/events/handlers/eviction"
+	_ "volcano.sh/volcano/pkg/agent/events/handlers/ioqos"
 	_ "volcano.sh/volcano/pkg/agent/events/handlers/memoryqos"
 	_ "volcano.sh/volcano/pkg/agent/events/handlers/networkqos"
 	_ "volcano.sh/volcano/pkg/agent/events/handlers/oversubscription"
+	_ "volcano.sh/volcano/pkg/agent/events/handlers/resctrl"
 	_ "volcano.sh/volcano/pkg/agent/events/handlers/resources"
 	_ "volcano.sh/volcano/pkg/agent/events/probes/nodemonitor"
 	_ "volcano.sh/volcano/pkg/agent/events/probes/noderesources"
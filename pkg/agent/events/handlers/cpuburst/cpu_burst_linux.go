@@ -88,21 +88,23 @@ func (c *CPUBurstHandle) Handle(event interface{}) error {
 
 	quotaBurstTime := getCPUBurstTime(pod)
 	podBurstTime := int64(0)
-	err = filepath.WalkDir(cgroupPath, walkFunc(cgroupPath, quotaBurstTime, &podBurstTime))
+	version := c.cgroupMgr.GetCgroupVersion()
+	err = filepath.WalkDir(cgroupPath, walkFunc(cgroupPath, version, quotaBurstTime, &podBurstTime))
 	if err != nil {
 		return fmt.Errorf("failed to set container cpu quota burst time, err: %v", err)
 	}
 
 	// last set pod cgroup cpu quota burst.
-	podQuotaTotalFile := filepath.Join(cgroupPath, cgroup.CPUQuotaTotalFile)
-	value, err := file.ReadIntFromFile(podQuotaTotalFile)
+	quotaTotalFile, quotaBurstFile := cpuQuotaFiles(version)
+	podQuotaTotalFile := filepath.Join(cgroupPath, quotaTotalFile)
+	value, err := readCPUQuota(podQuotaTotalFile)
 	if err != nil {
 		return fmt.Errorf("failed to get pod cpu total quota time, err: %v,path: %s", err, podQuotaTotalFile)
 	}
 	if value == fixedQuotaValue {
 		return nil
 	}
-	podQuotaBurstFile := filepath.Join(cgroupPath, cgroup.CPUQuotaBurstFile)
+	podQuotaBurstFile := filepath.Join(cgroupPath, quotaBurstFile)
 	err = utils.UpdateFile(podQuotaBurstFile, []byte(strconv.FormatInt(podBurstTime, 10)))
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
@@ -116,7 +118,8 @@ func (c *CPUBurstHandle) Handle(event interface{}) error {
 	return nil
 }
 
-func walkFunc(cgroupPath string, quotaBurstTime int64, podBurstTime *int64) fs.WalkDirFunc {
+func walkFunc(cgroupPath string, version cgroup.CgroupVersion, quotaBurstTime int64, podBurstTime *int64) fs.WalkDirFunc {
+	quotaTotalFileName, quotaBurstFileName := cpuQuotaFiles(version)
 	return func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -128,8 +131,8 @@ func walkFunc(cgroupPath string, quotaBurstTime int64, podBurstTime *int64) fs.W
 		if d == nil || !d.IsDir() {
 			return nil
 		}
-		quotaTotalFile := filepath.Join(path, cgroup.CPUQuotaTotalFile)
-		quotaTotal, err := file.ReadIntFromFile(quotaTotalFile)
+		quotaTotalFile := filepath.Join(path, quotaTotalFileName)
+		quotaTotal, err := readCPUQuota(quotaTotalFile)
 		if err != nil {
 			return fmt.Errorf("failed to get container cpu total quota time, err: %v, path: %s", err, quotaTotalFile)
 		}
@@ -146,7 +149,7 @@ func walkFunc(cgroupPath string, quotaBurstTime int64, podBurstTime *int64) fs.W
 			actualBurst = quotaTotal
 		}
 		*podBurstTime += actualBurst
-		quotaBurstFile := filepath.Join(path, cgroup.CPUQuotaBurstFile)
+		quotaBurstFile := filepath.Join(path, quotaBurstFileName)
 		err = utils.UpdateFile(quotaBurstFile, []byte(strconv.FormatInt(actualBurst, 10)))
 		if err != nil {
 			if errors.Is(err, os.ErrNotExist) {
@@ -175,3 +178,23 @@ func getCPUBurstTime(pod *corev1.Pod) int64 {
 	quotaBurstTime = int64(value)
 	return quotaBurstTime
 }
+
+// cpuQuotaFiles returns the files of the cpu quota and the cpu quota burst of the cgroup version.
+func cpuQuotaFiles(version cgroup.CgroupVersion) (string, string) {
+	if version == cgroup.CgroupV2 {
+		return cgroup.CPUMaxFile, cgroup.CPUMaxBurstFile
+	}
+	return cgroup.CPUQuotaTotalFile, cgroup.CPUQuotaBurstFile
+}
+
+// readCPUQuota reads the cpu quota from cpu.cfs_quota_us or cpu.max, the unlimited quota is -1.
+func readCPUQuota(quotaFile string) (int64, error) {
+	if filepath.Base(quotaFile) != cgroup.CPUMaxFile {
+		return file.ReadIntFromFile(quotaFile)
+	}
+	content, err := file.ReadByteFromFile(quotaFile)
+	if err != nil {
+		return 0, err
+	}
+	return cgroup.ParseCPUMax(string(content))
+}
@@ -229,6 +229,37 @@ func TestCPUBurstHandle_Handle(t *testing.T) {
 	}
 }
 
+func TestCPUBurstHandle_HandleCgroupV2(t *testing.T) {
+	tmpDir := t.TempDir()
+	podDir := path.Join(tmpDir, "kubepods", "podfake-id1")
+	for dir, cpuMax := range map[string]string{
+		podDir:                          "300000 100000",
+		path.Join(podDir, "container1"): "100000 100000",
+		path.Join(podDir, "container2"): "max 100000",
+	} {
+		assert.NoError(t, os.MkdirAll(dir, 0755))
+		assert.NoError(t, os.WriteFile(path.Join(dir, cgroup.CPUMaxFile), []byte(cpuMax+"\n"), 0644))
+		assert.NoError(t, os.WriteFile(path.Join(dir, cgroup.CPUMaxBurstFile), []byte("0\n"), 0644))
+	}
+
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	c := &CPUBurstHandle{
+		cgroupMgr:   cgroup.NewCgroupV2Manager("cgroupfs", tmpDir, ""),
+		podInformer: informerFactory.Core().V1().Pods(),
+	}
+	err := c.Handle(framework.PodEvent{UID: "fake-id1", Pod: getPod("50000", "true")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		path.Join(podDir, "cpu.max.burst"):            "50000",
+		path.Join(podDir, "container1/cpu.max.burst"): "50000",
+		path.Join(podDir, "container2/cpu.max.burst"): "0\n",
+	}, file.ReadBatchFromFile([]string{
+		path.Join(podDir, "cpu.max.burst"),
+		path.Join(podDir, "container1/cpu.max.burst"),
+		path.Join(podDir, "container2/cpu.max.burst"),
+	}))
+}
+
 func getPod(cpuQuotaBurst string, enableBurst string) *corev1.Pod {
 	return &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -65,6 +65,11 @@ func (h *CPUQoSHandle) Handle(event interface{}) error {
 	}
 	qosLevelFile := path.Join(cgroupPath, cgroup.CPUQoSLevelFile)
 	qosLevel := []byte(fmt.Sprintf("%d", podEvent.QoSLevel))
+	if h.cgroupMgr.GetCgroupVersion() == cgroup.CgroupV2 {
+		// the offline pods are scheduled with SCHED_IDLE, which the online pods always preempt.
+		qosLevelFile = path.Join(cgroupPath, cgroup.CPUIdleFile)
+		qosLevel = []byte(cpuIdle(podEvent.QoSLevel))
+	}
 
 	err = utils.UpdatePodCgroup(qosLevelFile, qosLevel)
 	if err != nil {
@@ -78,3 +83,11 @@ func (h *CPUQoSHandle) Handle(event interface{}) error {
 	klog.InfoS("Successfully set cpu qos level to cgroup file", "qosLevel", podEvent.QoSLevel, "cgroupFile", qosLevelFile)
 	return nil
 }
+
+// cpuIdle maps the qos level onto cpu.idle of cgroup v2.
+func cpuIdle(qosLevel int64) string {
+	if qosLevel < 0 {
+		return "1"
+	}
+	return "0"
+}
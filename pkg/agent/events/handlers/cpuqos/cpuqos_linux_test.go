@@ -85,3 +85,32 @@ func TestCPUQoSHandle_Handle(t *testing.T) {
 		})
 	}
 }
+
+func TestCPUQoSHandle_HandleCgroupV2(t *testing.T) {
+	tmpDir := t.TempDir()
+	podDir := path.Join(tmpDir, "kubepods", "besteffort", "podfake-id1")
+	containerDir := path.Join(podDir, "container1")
+	assert.NoError(t, os.MkdirAll(containerDir, 0755))
+	for _, dir := range []string{podDir, containerDir} {
+		assert.NoError(t, os.WriteFile(path.Join(dir, "cpu.idle"), []byte("0"), 0644))
+	}
+
+	h := &CPUQoSHandle{
+		cgroupMgr: cgroup.NewCgroupV2Manager("cgroupfs", tmpDir, ""),
+	}
+	for _, tt := range []struct {
+		qosLevel int64
+		want     string
+	}{
+		{qosLevel: -1, want: "1"},
+		{qosLevel: 2, want: "0"},
+	} {
+		err := h.Handle(framework.PodEvent{UID: "fake-id1", QoSLevel: tt.qosLevel, QoSClass: "BestEffort"})
+		assert.NoError(t, err)
+		for _, dir := range []string{podDir, containerDir} {
+			value, err := os.ReadFile(path.Join(dir, "cpu.idle"))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(value), "qos level %d", tt.qosLevel)
+		}
+	}
+}
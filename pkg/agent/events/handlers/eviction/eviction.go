@@ -20,7 +20,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
+	"time"
 
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/agent/apis"
@@ -46,8 +50,14 @@ type manager struct {
 	cfg *config.Configuration
 	eviction.Eviction
 	policy.Interface
+	rankPolicy  eviction.RankPolicy
 	getNodeFunc utilnode.ActiveNode
 	getPodsFunc utilpod.ActivePods
+
+	lock sync.Mutex
+	// limiter limits the evictions of the node, it is unlimited by default.
+	limiter *rate.Limiter
+	dryRun  bool
 }
 
 func NewManager(config *config.Configuration, mgr *metriccollect.MetricCollectorManager, cgroupMgr cgroup.CgroupManager) framework.Handle {
@@ -56,8 +66,10 @@ func NewManager(config *config.Configuration, mgr *metriccollect.MetricCollector
 		cfg:         config,
 		Eviction:    evictor,
 		Interface:   policy.GetPolicyFunc(config.GenericConfiguration.OverSubscriptionPolicy)(config, mgr, evictor, queue.NewSqQueue(), ""),
+		rankPolicy:  eviction.GetRankPolicyFunc(config.GenericConfiguration.EvictionRankPolicy)(config, mgr),
 		getNodeFunc: config.GetNode,
 		getPodsFunc: config.GetActivePods,
+		limiter:     rate.NewLimiter(rate.Inf, 0),
 	}
 	return m
 }
@@ -87,14 +99,34 @@ func (m *manager) Handle(event interface{}) error {
 			return err
 		}
 
-		for _, pod := range preemptablePods {
+		if len(preemptablePods) == 0 {
+			continue
+		}
+
+		limiter, dryRun := m.evictingCfg()
+		if !evictionAllowed(limiter) {
+			klog.InfoS("Eviction is rate limited, skipped evicting offline pods", "resource", res)
+			continue
+		}
+		evictMsg := fmt.Sprintf("Evict offline pod due to %s resource pressure", res)
+		for _, pod := range m.rankPolicy.Rank(preemptablePods, res) {
+			if dryRun {
+				klog.InfoS("Dry run, skipped evicting pod", "pod", klog.KObj(pod))
+				m.cfg.GenericConfiguration.Recorder.Eventf(pod, corev1.EventTypeWarning, eviction.DryRunReason, "Dry run: %s", evictMsg)
+				limiter.Allow()
+				break
+			}
+
 			if err = m.DisableSchedule(); err != nil {
 				klog.ErrorS(err, "Failed to add eviction annotation")
 			}
 			klog.InfoS("Successfully disable schedule")
 
 			klog.InfoS("Try to evict pod", "pod", klog.KObj(pod))
-			if m.Evict(context.TODO(), pod, m.cfg.GenericConfiguration.Recorder, 0, fmt.Sprintf("Evict offline pod due to %s resource pressure", res)) {
+			if m.Evict(context.TODO(), pod, m.cfg.GenericConfiguration.Recorder, 0, evictMsg) {
+				// the token is only taken by a successful eviction, the failed candidates don't count in the rate limit
+				limiter.Allow()
+				m.rankPolicy.Evicted(pod)
 				break
 			}
 		}
@@ -102,7 +134,33 @@ func (m *manager) Handle(event interface{}) error {
 	return nil
 }
 
+// evictionAllowed returns whether the limiter has a token left for an eviction, without taking it.
+func evictionAllowed(limiter *rate.Limiter) bool {
+	return limiter.Limit() == rate.Inf || limiter.Tokens() >= 1
+}
+
+func (m *manager) evictingCfg() (*rate.Limiter, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.limiter, m.dryRun
+}
+
 func (m *manager) RefreshCfg(cfg *api.ColocationConfig) error {
+	if cfg == nil || cfg.EvictingConfig == nil {
+		return nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	limit, burst := rate.Inf, 0
+	if rateLimit := cfg.EvictingConfig.EvictingRateLimit; rateLimit != nil && *rateLimit > 0 {
+		limit, burst = rate.Every(time.Minute/time.Duration(*rateLimit)), *rateLimit
+	}
+	if m.limiter.Limit() != limit || m.limiter.Burst() != burst {
+		klog.InfoS("Eviction rate limit changes", "evictionsPerMinute", burst)
+		m.limiter = rate.NewLimiter(limit, burst)
+	}
+	m.dryRun = cfg.EvictingConfig.EvictingDryRun != nil && *cfg.EvictingConfig.EvictingDryRun
 	return nil
 }
 
@@ -24,11 +24,15 @@ import (
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
 
 	"volcano.sh/volcano/pkg/agent/apis"
+	"volcano.sh/volcano/pkg/agent/config/api"
 	"volcano.sh/volcano/pkg/agent/events/framework"
 	"volcano.sh/volcano/pkg/agent/oversubscription/policy"
 	"volcano.sh/volcano/pkg/agent/oversubscription/policy/extend"
@@ -114,8 +118,10 @@ func Test_manager_Handle(t *testing.T) {
 				cfg:         cfg,
 				Interface:   tt.policy(cfg, tt.getPodsFunc, nil),
 				Eviction:    tt.Eviction,
+				rankPolicy:  eviction.NewDefaultRankPolicy(cfg, nil),
 				getNodeFunc: tt.getNodeFunc,
 				getPodsFunc: tt.getPodsFunc,
+				limiter:     rate.NewLimiter(rate.Inf, 0),
 			}
 			tt.wantErr(t, m.Handle(tt.event), fmt.Sprintf("Handle(%v)", tt.event))
 			evictedPods := pp.GetEvictedPods()
@@ -133,3 +139,103 @@ func Test_manager_Handle(t *testing.T) {
 		})
 	}
 }
+
+// failingEvictor fails the first evictions of the pod provider.
+type failingEvictor struct {
+	*utiltesting.PodProvider
+	failures int
+}
+
+func (e *failingEvictor) Evict(ctx context.Context, pod *v1.Pod, eventRecorder record.EventRecorder, gracePeriodSeconds int64, evictMsg string) bool {
+	if e.failures > 0 {
+		e.failures--
+		return false
+	}
+	return e.PodProvider.Evict(ctx, pod, eventRecorder, gracePeriodSeconds, evictMsg)
+}
+
+func Test_manager_HandleRateLimitAndDryRun(t *testing.T) {
+	event := framework.NodeMonitorEvent{TimeStamp: time.Now(), Resource: v1.ResourceCPU}
+	tests := []struct {
+		name            string
+		evictingCfg     *api.Evicting
+		events          int
+		failures        int
+		expectedEvicted []string
+		expectedEvents  int
+		expectedTaint   bool
+	}{
+		{
+			name:            "evict one pod per event without rate limit",
+			evictingCfg:     &api.Evicting{},
+			events:          2,
+			expectedEvicted: []string{"offline-pod-2", "offline-pod-1"},
+			expectedTaint:   true,
+		},
+		{
+			name:            "evictions beyond the rate limit are skipped",
+			evictingCfg:     &api.Evicting{EvictingRateLimit: utilpointer.Int(1)},
+			events:          2,
+			expectedEvicted: []string{"offline-pod-2"},
+			expectedTaint:   true,
+		},
+		{
+			name:            "failed evictions don't take the tokens of the rate limit",
+			evictingCfg:     &api.Evicting{EvictingRateLimit: utilpointer.Int(1)},
+			events:          2,
+			failures:        2,
+			expectedEvicted: []string{"offline-pod-2"},
+			expectedTaint:   true,
+		},
+		{
+			name:           "dry run only emits events",
+			evictingCfg:    &api.Evicting{EvictingDryRun: utilpointer.Bool(true)},
+			events:         2,
+			expectedEvents: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pp := utiltesting.NewPodProvider(
+				utiltesting.MakePod("offline-pod-1", 30, 30, "BE"),
+				utiltesting.MakePod("offline-pod-2", 40, 30, "BE"),
+				utiltesting.MakePod("online-pod", 10, 10, ""),
+			)
+			fakeNode, err := makeNode()
+			assert.NoError(t, err)
+			fakeClient := fakeclientset.NewSimpleClientset(fakeNode)
+			recorder := record.NewFakeRecorder(10)
+			cfg := &config.Configuration{GenericConfiguration: &config.VolcanoAgentConfiguration{
+				KubeClient:   fakeClient,
+				KubeNodeName: "test-node",
+				Recorder:     recorder,
+				NodeHasSynced: func() bool {
+					return false
+				},
+			}}
+			m := &manager{
+				cfg:         cfg,
+				Interface:   extend.NewExtendResource(cfg, nil, nil, nil, ""),
+				Eviction:    &failingEvictor{PodProvider: pp, failures: tt.failures},
+				rankPolicy:  eviction.NewDefaultRankPolicy(cfg, nil),
+				getNodeFunc: makeNode,
+				getPodsFunc: pp.GetPodsFunc,
+				limiter:     rate.NewLimiter(rate.Inf, 0),
+			}
+			assert.NoError(t, m.RefreshCfg(&api.ColocationConfig{EvictingConfig: tt.evictingCfg}))
+			for i := 0; i < tt.events; i++ {
+				assert.NoError(t, m.Handle(event))
+			}
+
+			var evicted []string
+			for _, pod := range pp.GetEvictedPods() {
+				evicted = append(evicted, pod.Name)
+			}
+			assert.Equal(t, tt.expectedEvicted, evicted)
+			assert.Equal(t, tt.expectedEvents, len(recorder.Events))
+			node, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), "test-node", metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTaint, len(node.Spec.Taints) > 0)
+		})
+	}
+}
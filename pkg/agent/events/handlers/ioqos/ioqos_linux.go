@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ioqos
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/agent/apis/extension"
+	"volcano.sh/volcano/pkg/agent/config/api"
+	"volcano.sh/volcano/pkg/agent/events/framework"
+	"volcano.sh/volcano/pkg/agent/events/handlers"
+	"volcano.sh/volcano/pkg/agent/events/handlers/base"
+	"volcano.sh/volcano/pkg/agent/features"
+	"volcano.sh/volcano/pkg/agent/utils"
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+	"volcano.sh/volcano/pkg/agent/utils/file"
+	"volcano.sh/volcano/pkg/config"
+	"volcano.sh/volcano/pkg/metriccollect"
+)
+
+func init() {
+	handlers.RegisterEventHandleFunc(string(framework.PodEventName), NewIOQoSHandle)
+}
+
+// IOQoSHandle sets the io weight and the block device limits of the offline pods.
+type IOQoSHandle struct {
+	*base.BaseHandle
+	cgroupMgr cgroup.CgroupManager
+	weight    int64
+	limits    []api.IODeviceLimit
+}
+
+type ioSetting struct {
+	file  string
+	value string
+}
+
+func NewIOQoSHandle(config *config.Configuration, mgr *metriccollect.MetricCollectorManager, cgroupMgr cgroup.CgroupManager) framework.Handle {
+	return &IOQoSHandle{
+		BaseHandle: &base.BaseHandle{
+			Name:   string(features.IOQoSFeature),
+			Config: config,
+		},
+		cgroupMgr: cgroupMgr,
+	}
+}
+
+func (h *IOQoSHandle) Handle(event interface{}) error {
+	podEvent, ok := event.(framework.PodEvent)
+	if !ok {
+		return fmt.Errorf("illegal pod event")
+	}
+	// the online pods keep the default io weight and are not limited.
+	if extension.NormalizeQosLevel(podEvent.QoSLevel) >= 0 {
+		return nil
+	}
+
+	h.Lock.RLock()
+	weight, limits := h.weight, h.limits
+	h.Lock.RUnlock()
+
+	cgroupPath, err := h.cgroupMgr.GetPodCgroupPath(podEvent.QoSClass, cgroup.CgroupBlkioSubsystem, podEvent.UID)
+	if err != nil {
+		return fmt.Errorf("failed to get pod cgroup file(%s), error: %v", podEvent.UID, err)
+	}
+	settings := ioSettingsV1(cgroupPath, weight, limits)
+	if h.cgroupMgr.GetCgroupVersion() == cgroup.CgroupV2 {
+		settings = ioSettingsV2(cgroupPath, weight, limits)
+	}
+
+	for _, setting := range settings {
+		cgroupFile := path.Join(cgroupPath, setting.file)
+		if err = utils.UpdateFile(cgroupFile, []byte(setting.value)); err != nil {
+			// blkio.weight and io.weight only exist with an io scheduler supporting them, e.g. bfq.
+			if errors.Is(err, os.ErrNotExist) {
+				klog.InfoS("Cgroup file not existed", "cgroupFile", cgroupFile)
+				continue
+			}
+			return err
+		}
+	}
+
+	klog.InfoS("Successfully set io qos to cgroup", "qosLevel", podEvent.QoSLevel, "weight", weight, "devices", len(limits), "cgroupPath", cgroupPath)
+	return nil
+}
+
+func (h *IOQoSHandle) RefreshCfg(cfg *api.ColocationConfig) error {
+	if err := h.BaseHandle.RefreshCfg(cfg); err != nil {
+		return err
+	}
+
+	h.Lock.Lock()
+	defer h.Lock.Unlock()
+	if cfg.IOQosConfig == nil {
+		return nil
+	}
+	if cfg.IOQosConfig.OfflineIOWeight != nil {
+		h.weight = int64(*cfg.IOQosConfig.OfflineIOWeight)
+	}
+	h.limits = cfg.IOQosConfig.OfflineDeviceLimits
+	return nil
+}
+
+// ioSettingsV1 returns the blkio settings, one line is written per device, and the devices no longer configured are
+// reset by a zero limit.
+func ioSettingsV1(cgroupPath string, weight int64, limits []api.IODeviceLimit) []ioSetting {
+	var settings []ioSetting
+	if weight > 0 {
+		settings = append(settings, ioSetting{file: cgroup.BlkioWeightFile, value: strconv.FormatInt(cgroup.IOWeightToBlkioWeight(weight), 10)})
+	}
+	for _, throttle := range []struct {
+		file  string
+		value func(limit api.IODeviceLimit) *int64
+	}{
+		{file: cgroup.BlkioThrottleReadBpsFile, value: func(limit api.IODeviceLimit) *int64 { return limit.ReadBps }},
+		{file: cgroup.BlkioThrottleWriteBpsFile, value: func(limit api.IODeviceLimit) *int64 { return limit.WriteBps }},
+		{file: cgroup.BlkioThrottleReadIOPSFile, value: func(limit api.IODeviceLimit) *int64 { return limit.ReadIOPS }},
+		{file: cgroup.BlkioThrottleWriteIOPSFile, value: func(limit api.IODeviceLimit) *int64 { return limit.WriteIOPS }},
+	} {
+		configured := make(map[string]bool)
+		for _, limit := range limits {
+			configured[limit.Device] = true
+			value := int64(0)
+			if v := throttle.value(limit); v != nil {
+				value = *v
+			}
+			settings = append(settings, ioSetting{file: throttle.file, value: fmt.Sprintf("%s %d", limit.Device, value)})
+		}
+		for _, device := range limitedDevices(path.Join(cgroupPath, throttle.file)) {
+			if !configured[device] {
+				settings = append(settings, ioSetting{file: throttle.file, value: device + " 0"})
+			}
+		}
+	}
+	return settings
+}
+
+// ioSettingsV2 returns the io.weight and io.max settings, the devices no longer configured are reset to max.
+func ioSettingsV2(cgroupPath string, weight int64, limits []api.IODeviceLimit) []ioSetting {
+	var settings []ioSetting
+	if weight > 0 {
+		settings = append(settings, ioSetting{file: cgroup.IOWeightFile, value: fmt.Sprintf("default %d", weight)})
+	}
+	configured := make(map[string]bool)
+	for _, limit := range limits {
+		configured[limit.Device] = true
+		settings = append(settings, ioSetting{file: cgroup.IOMaxFile, value: fmt.Sprintf("%s rbps=%s wbps=%s riops=%s wiops=%s",
+			limit.Device, maxValue(limit.ReadBps), maxValue(limit.WriteBps), maxValue(limit.ReadIOPS), maxValue(limit.WriteIOPS))})
+	}
+	for _, device := range limitedDevices(path.Join(cgroupPath, cgroup.IOMaxFile)) {
+		if !configured[device] {
+			settings = append(settings, ioSetting{file: cgroup.IOMaxFile, value: device + " rbps=max wbps=max riops=max wiops=max"})
+		}
+	}
+	return settings
+}
+
+// limitedDevices returns the devices in a limit file, whose lines start with the major:minor of the device.
+func limitedDevices(limitFile string) []string {
+	content, err := file.ReadByteFromFile(limitFile)
+	if err != nil {
+		return nil
+	}
+	var devices []string
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			devices = append(devices, fields[0])
+		}
+	}
+	return devices
+}
+
+func maxValue(value *int64) string {
+	if value == nil || *value == 0 {
+		return cgroup.CgroupMaxValue
+	}
+	return strconv.FormatInt(*value, 10)
+}
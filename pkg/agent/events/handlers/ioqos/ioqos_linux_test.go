@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ioqos
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	utilpointer "k8s.io/utils/pointer"
+
+	"volcano.sh/volcano/pkg/agent/config/api"
+	"volcano.sh/volcano/pkg/agent/events/framework"
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+	"volcano.sh/volcano/pkg/config"
+)
+
+func makeConfig() *api.ColocationConfig {
+	return &api.ColocationConfig{
+		NodeLabelConfig: &api.NodeLabelConfig{
+			NodeColocationEnable:       utilpointer.Bool(true),
+			NodeOverSubscriptionEnable: utilpointer.Bool(false),
+		},
+		IOQosConfig: &api.IOQos{
+			Enable:          utilpointer.Bool(true),
+			OfflineIOWeight: utilpointer.Int(10),
+			OfflineDeviceLimits: []api.IODeviceLimit{
+				{Device: "8:0", ReadBps: utilpointer.Int64(104857600), WriteIOPS: utilpointer.Int64(1000)},
+			},
+		},
+	}
+}
+
+func newHandle(cgroupMgr cgroup.CgroupManager) framework.Handle {
+	cfg := &config.Configuration{GenericConfiguration: &config.VolcanoAgentConfiguration{SupportedFeatures: []string{"*"}}}
+	return NewIOQoSHandle(cfg, nil, cgroupMgr)
+}
+
+func TestIOQoSHandle_Handle(t *testing.T) {
+	tmpDir := t.TempDir()
+	podPath := path.Join(tmpDir, "blkio", "kubepods", "besteffort", "pod00000000-1111-2222-3333-000000000001")
+	assert.NoError(t, os.MkdirAll(podPath, 0755))
+	files := map[string]string{
+		"blkio.weight":                     "500",
+		"blkio.throttle.read_bps_device":   "",
+		"blkio.throttle.write_bps_device":  "",
+		"blkio.throttle.read_iops_device":  "",
+		"blkio.throttle.write_iops_device": "8:16 200\n",
+	}
+	for file, value := range files {
+		assert.NoError(t, os.WriteFile(path.Join(podPath, file), []byte(value), 0644))
+	}
+
+	h := newHandle(cgroup.NewCgroupManager("cgroupfs", tmpDir, ""))
+	assert.NoError(t, h.RefreshCfg(makeConfig()))
+
+	// the online pod is not limited.
+	assert.NoError(t, h.Handle(framework.PodEvent{UID: "00000000-1111-2222-3333-000000000001", QoSLevel: 0, QoSClass: "BestEffort"}))
+	weight, err := os.ReadFile(path.Join(podPath, "blkio.weight"))
+	assert.NoError(t, err)
+	assert.Equal(t, "500", string(weight))
+
+	assert.NoError(t, h.Handle(framework.PodEvent{UID: "00000000-1111-2222-3333-000000000001", QoSLevel: -1, QoSClass: "BestEffort"}))
+	// the device no longer configured is reset, the fake file only keeps the last line written.
+	expected := map[string]string{
+		"blkio.weight":                     "10",
+		"blkio.throttle.read_bps_device":   "8:0 104857600",
+		"blkio.throttle.write_bps_device":  "8:0 0",
+		"blkio.throttle.read_iops_device":  "8:0 0",
+		"blkio.throttle.write_iops_device": "8:16 0",
+	}
+	for file, value := range expected {
+		actual, err := os.ReadFile(path.Join(podPath, file))
+		assert.NoError(t, err, file)
+		assert.Equal(t, value, string(actual), file)
+	}
+}
+
+func TestIOQoSHandle_HandleCgroupV2(t *testing.T) {
+	tmpDir := t.TempDir()
+	podPath := path.Join(tmpDir, "kubepods", "besteffort", "pod00000000-1111-2222-3333-000000000001")
+	assert.NoError(t, os.MkdirAll(podPath, 0755))
+	assert.NoError(t, os.WriteFile(path.Join(podPath, "io.max"), []byte(""), 0644))
+
+	h := newHandle(cgroup.NewCgroupV2Manager("cgroupfs", tmpDir, ""))
+	assert.NoError(t, h.RefreshCfg(makeConfig()))
+	// io.weight does not exist without an io scheduler supporting it.
+	assert.NoError(t, h.Handle(framework.PodEvent{UID: "00000000-1111-2222-3333-000000000001", QoSLevel: -1, QoSClass: "BestEffort"}))
+
+	ioMax, err := os.ReadFile(path.Join(podPath, "io.max"))
+	assert.NoError(t, err)
+	assert.Equal(t, "8:0 rbps=104857600 wbps=max riops=max wiops=1000", string(ioMax))
+}
@@ -21,6 +21,10 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 
 	"k8s.io/klog/v2"
 
@@ -35,6 +39,9 @@ import (
 	"volcano.sh/volcano/pkg/metriccollect"
 )
 
+// offlineMemoryHighPercent is the percent of memory.max the offline pods are throttled at on cgroup v2.
+const offlineMemoryHighPercent = 90
+
 func init() {
 	handlers.RegisterEventHandleFunc(string(framework.PodEventName), NewMemoryQoSHandle)
 }
@@ -64,6 +71,10 @@ func (h *MemoryQoSHandle) Handle(event interface{}) error {
 	if err != nil {
 		return fmt.Errorf("failed to get pod cgroup file(%s), error: %v", podEvent.UID, err)
 	}
+	if h.cgroupMgr.GetCgroupVersion() == cgroup.CgroupV2 {
+		return setMemoryQoSV2(cgroupPath, podEvent)
+	}
+
 	qosLevelFile := path.Join(cgroupPath, cgroup.MemoryQoSLevelFile)
 	qosLevel := []byte(fmt.Sprintf("%d", extension.NormalizeQosLevel(podEvent.QoSLevel)))
 
@@ -79,3 +90,55 @@ func (h *MemoryQoSHandle) Handle(event interface{}) error {
 	klog.InfoS("Successfully set memory qos level to cgroup file", "qosLevel", qosLevel, "cgroupFile", qosLevelFile)
 	return nil
 }
+
+// setMemoryQoSV2 maps the qos level onto cgroup v2, which has no memory qos level: the memory requests of the online
+// pods are protected from reclaim by memory.low, and the offline pods are throttled and reclaimed by memory.high
+// before they reach their memory.max.
+func setMemoryQoSV2(cgroupPath string, podEvent framework.PodEvent) error {
+	low, high := "0", cgroup.CgroupMaxValue
+	if extension.NormalizeQosLevel(podEvent.QoSLevel) < 0 {
+		limit, err := os.ReadFile(path.Join(cgroupPath, cgroup.MemoryMaxFile))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				klog.InfoS("Cgroup file not existed", "cgroupFile", path.Join(cgroupPath, cgroup.MemoryMaxFile))
+				return nil
+			}
+			return err
+		}
+		if value, err := strconv.ParseInt(strings.TrimSpace(string(limit)), 10, 64); err == nil {
+			high = strconv.FormatInt(value*offlineMemoryHighPercent/100, 10)
+		}
+	} else {
+		low = strconv.FormatInt(memoryRequests(podEvent.Pod), 10)
+	}
+
+	for _, setting := range []struct{ file, value string }{
+		{file: cgroup.MemoryLowFile, value: low},
+		{file: cgroup.MemoryHighFile, value: high},
+	} {
+		cgroupFile := path.Join(cgroupPath, setting.file)
+		if err := utils.UpdateFile(cgroupFile, []byte(setting.value)); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				klog.InfoS("Cgroup file not existed", "cgroupFile", cgroupFile)
+				continue
+			}
+			return err
+		}
+	}
+
+	klog.InfoS("Successfully set memory qos to cgroup v2", "qosLevel", podEvent.QoSLevel, "memoryLow", low, "memoryHigh", high, "cgroupPath", cgroupPath)
+	return nil
+}
+
+func memoryRequests(pod *corev1.Pod) int64 {
+	if pod == nil {
+		return 0
+	}
+	requests := int64(0)
+	for _, c := range pod.Spec.Containers {
+		if request, ok := c.Resources.Requests[corev1.ResourceMemory]; ok {
+			requests += request.Value()
+		}
+	}
+	return requests
+}
@@ -17,11 +17,15 @@ limitations under the License.
 package memoryqos
 
 import (
+	"fmt"
 	"os"
 	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
 
 	"volcano.sh/volcano/pkg/agent/events/framework"
 	"volcano.sh/volcano/pkg/agent/utils/cgroup"
@@ -129,3 +133,61 @@ func TestMemroyQoSHandle_Handle(t *testing.T) {
 		assert.Equal(t, tc.expectedQoSLevel, string(actualLevel), tc.name)
 	}
 }
+
+func TestMemoryQoSHandle_HandleCgroupV2(t *testing.T) {
+	tmpDir := t.TempDir()
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}}},
+		{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}}},
+	}}}
+
+	testCases := []struct {
+		name         string
+		qosLevel     int64
+		memoryMax    string
+		expectedLow  string
+		expectedHigh string
+	}{
+		{
+			name:         "online pod is protected by its memory requests",
+			qosLevel:     2,
+			memoryMax:    "2147483648",
+			expectedLow:  "1610612736",
+			expectedHigh: "max",
+		},
+		{
+			name:         "offline pod is throttled below its memory limit",
+			qosLevel:     -1,
+			memoryMax:    "2147483648",
+			expectedLow:  "0",
+			expectedHigh: "1932735283",
+		},
+		{
+			name:         "offline pod without memory limit is not throttled",
+			qosLevel:     -1,
+			memoryMax:    "max",
+			expectedLow:  "0",
+			expectedHigh: "max",
+		},
+	}
+
+	for i, tc := range testCases {
+		uid := fmt.Sprintf("00000000-1111-2222-3333-00000000000%d", i)
+		podDir := path.Join(tmpDir, "kubepods", "burstable", "pod"+uid)
+		assert.NoError(t, os.MkdirAll(podDir, 0755), tc.name)
+		for file, value := range map[string]string{"memory.max": tc.memoryMax, "memory.low": "0", "memory.high": "max"} {
+			assert.NoError(t, os.WriteFile(path.Join(podDir, file), []byte(value), 0644), tc.name)
+		}
+
+		h := NewMemoryQoSHandle(nil, nil, cgroup.NewCgroupV2Manager("cgroupfs", tmpDir, ""))
+		err := h.Handle(framework.PodEvent{UID: types.UID(uid), QoSLevel: tc.qosLevel, QoSClass: "Burstable", Pod: pod})
+		assert.NoError(t, err, tc.name)
+
+		low, err := os.ReadFile(path.Join(podDir, "memory.low"))
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedLow, string(low), tc.name)
+		high, err := os.ReadFile(path.Join(podDir, "memory.high"))
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedHigh, string(high), tc.name)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resctrl
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/agent/apis/extension"
+	"volcano.sh/volcano/pkg/agent/config/api"
+	"volcano.sh/volcano/pkg/agent/events/framework"
+	"volcano.sh/volcano/pkg/agent/events/handlers"
+	"volcano.sh/volcano/pkg/agent/events/handlers/base"
+	"volcano.sh/volcano/pkg/agent/features"
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+	"volcano.sh/volcano/pkg/agent/utils/file"
+	"volcano.sh/volcano/pkg/agent/utils/resctrl"
+	"volcano.sh/volcano/pkg/config"
+	"volcano.sh/volcano/pkg/metriccollect"
+)
+
+func init() {
+	handlers.RegisterEventHandleFunc(string(framework.PodEventName), NewResctrlHandle)
+}
+
+// ResctrlHandle assigns the offline pods to a CLOS group limiting the L3 cache ways and the memory bandwidth they can
+// use, it does nothing on the nodes without resctrl.
+type ResctrlHandle struct {
+	*base.BaseHandle
+	cgroupMgr cgroup.CgroupManager
+	root      string
+	// groupReady is true when the group of the offline pods is created with the schemata of the latest config.
+	groupReady bool
+}
+
+func NewResctrlHandle(config *config.Configuration, mgr *metriccollect.MetricCollectorManager, cgroupMgr cgroup.CgroupManager) framework.Handle {
+	return &ResctrlHandle{
+		BaseHandle: &base.BaseHandle{
+			Name:   string(features.ResctrlFeature),
+			Config: config,
+		},
+		cgroupMgr: cgroupMgr,
+		root:      resctrl.Root(),
+	}
+}
+
+func (h *ResctrlHandle) Handle(event interface{}) error {
+	podEvent, ok := event.(framework.PodEvent)
+	if !ok {
+		return fmt.Errorf("illegal pod event")
+	}
+	// the online pods stay in the root group which can use the whole cache and memory bandwidth.
+	if extension.NormalizeQosLevel(podEvent.QoSLevel) >= 0 {
+		return nil
+	}
+
+	h.Lock.RLock()
+	groupReady := h.groupReady
+	h.Lock.RUnlock()
+	if !groupReady {
+		klog.V(4).InfoS("Resctrl group is not ready, skipped assigning offline pod", "pod", klog.KObj(podEvent.Pod))
+		return nil
+	}
+
+	cgroupPath, err := h.cgroupMgr.GetPodCgroupPath(podEvent.QoSClass, cgroup.CgroupCpuSubsystem, podEvent.UID)
+	if err != nil {
+		return fmt.Errorf("failed to get pod cgroup file(%s), error: %v", podEvent.UID, err)
+	}
+	threadsFile := cgroup.CgroupTasksFile
+	if h.cgroupMgr.GetCgroupVersion() == cgroup.CgroupV2 {
+		threadsFile = cgroup.CgroupThreadsFile
+	}
+
+	tids, err := podThreads(cgroupPath, threadsFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			klog.InfoS("Cgroup file not existed", "cgroupPath", cgroupPath)
+			return nil
+		}
+		return err
+	}
+	if err = resctrl.AddTasks(h.root, resctrl.OfflineGroup, tids); err != nil {
+		return err
+	}
+
+	klog.InfoS("Successfully assigned offline pod to resctrl group", "pod", klog.KObj(podEvent.Pod), "group", resctrl.OfflineGroup, "threads", len(tids))
+	return nil
+}
+
+func (h *ResctrlHandle) RefreshCfg(cfg *api.ColocationConfig) error {
+	if err := h.BaseHandle.RefreshCfg(cfg); err != nil {
+		return err
+	}
+
+	h.Lock.Lock()
+	defer h.Lock.Unlock()
+	h.groupReady = false
+	if !resctrl.IsSupported(h.root) {
+		if h.Active {
+			klog.V(4).InfoS("Resctrl is not mounted, skipped isolating L3 cache and memory bandwidth of offline pods", "path", h.root)
+		}
+		return nil
+	}
+
+	if !h.Active {
+		return resctrl.RemoveGroup(h.root, resctrl.OfflineGroup)
+	}
+	schemata, err := resctrl.BuildSchemata(h.root, *cfg.ResctrlConfig.OfflineL3CachePercent, *cfg.ResctrlConfig.OfflineMemoryBandwidthPercent)
+	if err != nil {
+		klog.ErrorS(err, "Failed to build resctrl schemata")
+		return err
+	}
+	if err = resctrl.EnsureGroup(h.root, resctrl.OfflineGroup, schemata); err != nil {
+		klog.ErrorS(err, "Failed to create resctrl group", "group", resctrl.OfflineGroup)
+		return err
+	}
+	h.groupReady = true
+	return nil
+}
+
+// podThreads returns the thread ids of the pod cgroup and the container cgroups under it.
+func podThreads(cgroupPath, threadsFile string) ([]string, error) {
+	var tids []string
+	err := filepath.WalkDir(cgroupPath, func(p string, d os.DirEntry, iErr error) error {
+		if iErr != nil {
+			return iErr
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		content, err := file.ReadByteFromFile(path.Join(p, threadsFile))
+		if err != nil {
+			return err
+		}
+		tids = append(tids, strings.Fields(string(content))...)
+		return nil
+	})
+	return tids, err
+}
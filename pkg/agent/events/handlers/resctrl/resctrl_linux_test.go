@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resctrl
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	utilpointer "k8s.io/utils/pointer"
+
+	"volcano.sh/volcano/pkg/agent/config/api"
+	"volcano.sh/volcano/pkg/agent/events/framework"
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+	"volcano.sh/volcano/pkg/config"
+)
+
+func writeFile(t *testing.T, file, content string) {
+	assert.NoError(t, os.MkdirAll(path.Dir(file), 0755))
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0644))
+}
+
+func makeConfig(enable bool) *api.ColocationConfig {
+	return &api.ColocationConfig{
+		NodeLabelConfig: &api.NodeLabelConfig{
+			NodeColocationEnable:       utilpointer.Bool(true),
+			NodeOverSubscriptionEnable: utilpointer.Bool(false),
+		},
+		ResctrlConfig: &api.Resctrl{
+			Enable:                        utilpointer.Bool(enable),
+			OfflineL3CachePercent:         utilpointer.Int(50),
+			OfflineMemoryBandwidthPercent: utilpointer.Int(30),
+		},
+	}
+}
+
+func newHandle(cgroupMgr cgroup.CgroupManager) *ResctrlHandle {
+	cfg := &config.Configuration{GenericConfiguration: &config.VolcanoAgentConfiguration{SupportedFeatures: []string{"*"}}}
+	return NewResctrlHandle(cfg, nil, cgroupMgr).(*ResctrlHandle)
+}
+
+func TestResctrlHandle(t *testing.T) {
+	sysFsPath := t.TempDir()
+	t.Setenv("SYS_FS_PATH", sysFsPath)
+	root := path.Join(sysFsPath, "resctrl")
+	writeFile(t, path.Join(root, "schemata"), "L3:0=ff\nMB:0=100\n")
+	writeFile(t, path.Join(root, "info", "L3", "cbm_mask"), "ff\n")
+	writeFile(t, path.Join(root, "info", "MB", "min_bandwidth"), "10\n")
+	writeFile(t, path.Join(root, "info", "MB", "bandwidth_gran"), "10\n")
+
+	cgroupRoot := t.TempDir()
+	podPath := path.Join(cgroupRoot, "kubepods", "besteffort", "pod00000000-1111-2222-3333-000000000001")
+	writeFile(t, path.Join(podPath, "cgroup.threads"), "")
+	writeFile(t, path.Join(podPath, "container1", "cgroup.threads"), "200\n201\n")
+
+	h := newHandle(cgroup.NewCgroupV2Manager("cgroupfs", cgroupRoot, ""))
+	assert.NoError(t, h.RefreshCfg(makeConfig(true)))
+	content, err := os.ReadFile(path.Join(root, "volcano-offline", "schemata"))
+	assert.NoError(t, err)
+	assert.Equal(t, "L3:0=f\nMB:0=30", string(content))
+	// the kernel creates the tasks file of the group.
+	writeFile(t, path.Join(root, "volcano-offline", "tasks"), "")
+
+	// the online pod is not assigned.
+	assert.NoError(t, h.Handle(framework.PodEvent{UID: "00000000-1111-2222-3333-000000000001", QoSLevel: 0, QoSClass: "BestEffort"}))
+	content, err = os.ReadFile(path.Join(root, "volcano-offline", "tasks"))
+	assert.NoError(t, err)
+	assert.Equal(t, "", string(content))
+
+	assert.NoError(t, h.Handle(framework.PodEvent{UID: "00000000-1111-2222-3333-000000000001", QoSLevel: -1, QoSClass: "BestEffort"}))
+	content, err = os.ReadFile(path.Join(root, "volcano-offline", "tasks"))
+	assert.NoError(t, err)
+	assert.Equal(t, "200\n201\n", string(content))
+
+	// the pod whose cgroup has gone is skipped.
+	assert.NoError(t, h.Handle(framework.PodEvent{UID: "00000000-1111-2222-3333-000000000002", QoSLevel: -1, QoSClass: "BestEffort"}))
+}
+
+func TestResctrlHandle_Unsupported(t *testing.T) {
+	t.Setenv("SYS_FS_PATH", t.TempDir())
+
+	cgroupRoot := t.TempDir()
+	podPath := path.Join(cgroupRoot, "cpu", "kubepods", "besteffort", "pod00000000-1111-2222-3333-000000000001")
+	writeFile(t, path.Join(podPath, "tasks"), "100\n")
+
+	h := newHandle(cgroup.NewCgroupManager("cgroupfs", cgroupRoot, ""))
+	assert.NoError(t, h.RefreshCfg(makeConfig(true)))
+	assert.True(t, h.IsActive())
+	assert.NoError(t, h.Handle(framework.PodEvent{UID: "00000000-1111-2222-3333-000000000001", QoSLevel: -1, QoSClass: "BestEffort"}))
+	assert.NoError(t, h.RefreshCfg(makeConfig(false)))
+}
@@ -76,8 +76,12 @@ func (r *ResourcesHandle) Handle(event interface{}) error {
 			errs = append(errs, err)
 		}
 
-		filePath := path.Join(cgroupPath, cr.ContainerID, cr.SubPath)
-		err = utils.UpdateFile(filePath, []byte(strconv.FormatInt(cr.Value, 10)))
+		subPath, content := cr.SubPath, strconv.FormatInt(cr.Value, 10)
+		if r.cgroupMgr.GetCgroupVersion() == cgroup.CgroupV2 {
+			subPath, content = cgroup.ConvertToV2(cr.SubPath, cr.Value)
+		}
+		filePath := path.Join(cgroupPath, cr.ContainerID, subPath)
+		err = utils.UpdateFile(filePath, []byte(content))
 		if os.IsNotExist(err) {
 			klog.InfoS("Cgroup file not existed", "filePath", filePath)
 			continue
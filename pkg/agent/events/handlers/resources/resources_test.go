@@ -89,6 +89,41 @@ func TestResourcesHandle_Handle(t *testing.T) {
 				path.Join(tmpDir, "cpu/kubepods/burstable/poduid1/cpu.shares"): "1536",
 			},
 		},
+		{
+			name:      "set correctly on cgroup v2",
+			cgroupMgr: cgroup.NewCgroupV2Manager("cgroupfs", path.Join(tmpDir, "v2"), ""),
+			event: framework.PodEvent{
+				UID:      "uid2",
+				QoSLevel: -1,
+				QoSClass: "Burstable",
+				Pod:      buildPodWithContainerID("p2", "uid2", containerID1, containerID2),
+			},
+			prepare: func() {
+				prepareV2(t, path.Join(tmpDir, "v2"), "uid2", containerID1, containerID2)
+			},
+			post: func() map[string]string {
+				return file.ReadBatchFromFile([]string{
+					path.Join(tmpDir, "v2/kubepods/burstable/poduid2/65a6099d/cpu.weight"),
+					path.Join(tmpDir, "v2/kubepods/burstable/poduid2/65a6099d/cpu.max"),
+					path.Join(tmpDir, "v2/kubepods/burstable/poduid2/13b017b7/cpu.weight"),
+					path.Join(tmpDir, "v2/kubepods/burstable/poduid2/13b017b7/memory.max"),
+					path.Join(tmpDir, "v2/kubepods/burstable/poduid2/cpu.weight"),
+				})
+			},
+			wantErr: false,
+			wantVal: map[string]string{
+				// container1
+				path.Join(tmpDir, "v2/kubepods/burstable/poduid2/65a6099d/cpu.weight"): "20",
+				path.Join(tmpDir, "v2/kubepods/burstable/poduid2/65a6099d/cpu.max"):    "200000",
+
+				// container2
+				path.Join(tmpDir, "v2/kubepods/burstable/poduid2/13b017b7/cpu.weight"): "39",
+				path.Join(tmpDir, "v2/kubepods/burstable/poduid2/13b017b7/memory.max"): "10737418240",
+
+				// pod
+				path.Join(tmpDir, "v2/kubepods/burstable/poduid2/cpu.weight"): "59",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -182,3 +217,18 @@ func prepare(t *testing.T, tmpDir, podUID, containerID1, containerID2 string) {
 		}
 	}
 }
+
+func prepareV2(t *testing.T, cgroupRoot, podUID, containerID1, containerID2 string) {
+	podDir := path.Join(cgroupRoot, "kubepods", "burstable", "pod"+podUID)
+	for _, c := range []string{containerID1, containerID2} {
+		containerDir := path.Join(podDir, c)
+		err := os.MkdirAll(containerDir, 0755)
+		assert.NoError(t, err)
+		for _, dir := range []string{podDir, containerDir} {
+			for _, cgroupFile := range []string{"cpu.weight", "cpu.max", "memory.max"} {
+				err = os.WriteFile(path.Join(dir, cgroupFile), []byte("max"), 0644)
+				assert.NoError(t, err)
+			}
+		}
+	}
+}
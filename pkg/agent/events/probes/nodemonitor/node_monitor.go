@@ -32,6 +32,7 @@ import (
 	"volcano.sh/volcano/pkg/agent/oversubscription/policy"
 	"volcano.sh/volcano/pkg/agent/oversubscription/queue"
 	"volcano.sh/volcano/pkg/agent/utils"
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
 	"volcano.sh/volcano/pkg/agent/utils/eviction"
 	utilnode "volcano.sh/volcano/pkg/agent/utils/node"
 	utilpod "volcano.sh/volcano/pkg/agent/utils/pod"
@@ -57,15 +58,24 @@ type monitor struct {
 	queue         workqueue.RateLimitingInterface
 	lowWatermark  apis.Watermark
 	highWatermark apis.Watermark
-	// highUsageCountByResName is used to record whether resources usage are high.
+	// pressureThreshold is the pressure of the resources above which they are under pressure, zero disables it.
+	pressureThreshold map[v1.ResourceName]int
+	// highUsageCountByResName is used to record whether resources usage or pressure are high.
 	highUsageCountByResName map[v1.ResourceName]int
-	getNodeFunc             utilnode.ActiveNode
-	getPodsFunc             utilpod.ActivePods
-	usageGetter             resourceusage.Getter
+	// pressures is the latest pressure of the resources.
+	pressures    map[v1.ResourceName]float64
+	getNodeFunc  utilnode.ActiveNode
+	getPodsFunc  utilpod.ActivePods
+	usageGetter  resourceusage.Getter
+	getPressures PressureGetter
 }
 
 func NewMonitor(config *config.Configuration, mgr *metriccollect.MetricCollectorManager, workQueue workqueue.RateLimitingInterface) framework.Probe {
 	evictor := eviction.NewEviction(config.GenericConfiguration.KubeClient, config.GenericConfiguration.KubeNodeName)
+	var cgroupMgr cgroup.CgroupManager
+	if mgr != nil {
+		cgroupMgr = mgr.CgroupManager()
+	}
 	return &monitor{
 		Interface:               policy.GetPolicyFunc(config.GenericConfiguration.OverSubscriptionPolicy)(config, mgr, evictor, queue.NewSqQueue(), local.CollectorName),
 		queue:                   workQueue,
@@ -73,8 +83,10 @@ func NewMonitor(config *config.Configuration, mgr *metriccollect.MetricCollector
 		getPodsFunc:             config.GetActivePods,
 		lowWatermark:            make(apis.Watermark),
 		highWatermark:           make(apis.Watermark),
+		pressureThreshold:       make(map[v1.ResourceName]int),
 		highUsageCountByResName: make(map[v1.ResourceName]int),
 		usageGetter:             resourceusage.NewUsageGetter(mgr, local.CollectorName),
+		getPressures:            NewPressureGetter(cgroupMgr),
 	}
 }
 
@@ -91,6 +103,7 @@ func (m *monitor) Run(stop <-chan struct{}) {
 func (m *monitor) RefreshCfg(cfg *api.ColocationConfig) error {
 	m.cfgLock.Lock()
 	utils.SetEvictionWatermark(cfg, m.lowWatermark, m.highWatermark)
+	setPressureThreshold(cfg, m.pressureThreshold)
 	m.cfgLock.Unlock()
 
 	m.Lock()
@@ -113,15 +126,24 @@ func (m *monitor) utilizationMonitoring() {
 	}
 	nodeCopy := node.DeepCopy()
 
-	// check if resource usage is high
+	if m.getPressures != nil {
+		m.pressures = m.getPressures()
+	}
+
+	// check if resource usage or pressure is high
 	usage := m.usageGetter.UsagesByPercentage(nodeCopy)
 	for _, res := range apis.OverSubscriptionResourceTypes {
-		if m.isHighResourceUsageOnce(nodeCopy, apis.Resource(usage), res) {
+		if m.isHighResourceUsageOnce(nodeCopy, apis.Resource(usage), res) || m.isHighPressureOnce(m.pressures, res) {
 			m.highUsageCountByResName[res]++
 		} else {
 			m.highUsageCountByResName[res] = 0
 		}
 	}
+	if m.isHighPressureOnce(m.pressures, resourceIO) {
+		m.highUsageCountByResName[resourceIO]++
+	} else {
+		m.highUsageCountByResName[resourceIO] = 0
+	}
 }
 
 func (m *monitor) detect() {
@@ -131,6 +153,7 @@ func (m *monitor) detect() {
 		return
 	}
 	nodeCopy := node.DeepCopy()
+	pressures := m.latestPressures()
 
 	allResourcesAreLowUsage := true
 	for _, res := range apis.OverSubscriptionResourceTypes {
@@ -151,11 +174,23 @@ func (m *monitor) detect() {
 		}
 
 		usage := m.usageGetter.UsagesByPercentage(nodeCopy)
-		if !m.isLowResourceUsageOnce(nodeCopy, apis.Resource(usage), res) {
+		if !m.isLowResourceUsageOnce(nodeCopy, apis.Resource(usage), res) || m.isHighPressureOnce(pressures, res) {
 			allResourcesAreLowUsage = false
 		}
 	}
 
+	// io is not overSubscribed, the sustained io pressure stops scheduling offline pods instead of evicting them.
+	if m.SupportOverSubscription(nodeCopy) && m.nodeHasPressure(resourceIO) {
+		klog.InfoS("Node pressure detected, disable schedule", "resource", resourceIO)
+		if err := m.DisableSchedule(); err != nil {
+			klog.ErrorS(err, "Failed to disable schedule")
+		}
+		return
+	}
+	if m.isHighPressureOnce(pressures, resourceIO) {
+		allResourcesAreLowUsage = false
+	}
+
 	// Only remove eviction annotation when all resources are low usage.
 	if !allResourcesAreLowUsage {
 		return
@@ -194,6 +229,19 @@ func (m *monitor) isLowResourceUsageOnce(node *v1.Node, usage apis.Resource, res
 	return usage[resName] <= lowWatermark[resName]
 }
 
+func (m *monitor) isHighPressureOnce(pressures map[v1.ResourceName]float64, resName v1.ResourceName) bool {
+	m.cfgLock.RLock()
+	defer m.cfgLock.RUnlock()
+	threshold := m.pressureThreshold[resName]
+	return threshold > 0 && pressures[resName] > float64(threshold)
+}
+
+func (m *monitor) latestPressures() map[v1.ResourceName]float64 {
+	m.Lock()
+	defer m.Unlock()
+	return m.pressures
+}
+
 func (m *monitor) nodeHasPressure(resName v1.ResourceName) bool {
 	m.Lock()
 	defer m.Unlock()
@@ -61,6 +61,8 @@ func Test_monitor_detect(t *testing.T) {
 		lowWatermark            apis.Watermark
 		highWatermark           apis.Watermark
 		highUsageCountByResName map[v1.ResourceName]int
+		pressureThreshold       map[v1.ResourceName]int
+		pressures               map[v1.ResourceName]float64
 		getNodeFunc             utilnode.ActiveNode
 		getPodsFunc             utilpod.ActivePods
 		usageGetter             resourceusage.Getter
@@ -102,10 +104,54 @@ func Test_monitor_detect(t *testing.T) {
 			},
 			expectedLen: 0,
 		},
+		{
+			name:                    "keep taint when cpu stalls with low usage",
+			highUsageCountByResName: map[v1.ResourceName]int{v1.ResourceCPU: 5},
+			pressureThreshold:       map[v1.ResourceName]int{v1.ResourceCPU: 20},
+			pressures:               map[v1.ResourceName]float64{v1.ResourceCPU: 35.5},
+			getNodeFunc:             makeNode,
+			getPodsFunc: func() ([]*v1.Pod, error) {
+				return []*v1.Pod{}, nil
+			},
+			policy: func(cfg *config.Configuration, pods utilpod.ActivePods, evictor eviction.Eviction) policy.Interface {
+				return extend.NewExtendResource(cfg, nil, evictor, nil, "")
+			},
+			usageGetter: resourceusage.NewFakeResourceGetter(0, 0, 20, 20),
+			expectedNode: func() *v1.Node {
+				node, err := makeNode()
+				assert.NoError(t, err)
+				return node
+			},
+			expectedLen: 0,
+		},
+		{
+			name:                    "disable schedule without eviction when io stalls",
+			highUsageCountByResName: map[v1.ResourceName]int{resourceIO: 6},
+			pressureThreshold:       map[v1.ResourceName]int{resourceIO: 20},
+			pressures:               map[v1.ResourceName]float64{resourceIO: 50},
+			getNodeFunc: func() (*v1.Node, error) {
+				node, err := makeNode()
+				node.Spec.Taints = nil
+				return node, err
+			},
+			getPodsFunc: func() ([]*v1.Pod, error) {
+				return []*v1.Pod{}, nil
+			},
+			policy: func(cfg *config.Configuration, pods utilpod.ActivePods, evictor eviction.Eviction) policy.Interface {
+				return extend.NewExtendResource(cfg, nil, evictor, nil, "")
+			},
+			usageGetter: resourceusage.NewFakeResourceGetter(0, 0, 20, 20),
+			expectedNode: func() *v1.Node {
+				node, err := makeNode()
+				assert.NoError(t, err)
+				return node
+			},
+			expectedLen: 0,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fakeNode, err := makeNode()
+			fakeNode, err := tt.getNodeFunc()
 			assert.NoError(t, err)
 			fakeClient := fakeclientset.NewSimpleClientset(fakeNode)
 			cfg := &config.Configuration{GenericConfiguration: &config.VolcanoAgentConfiguration{
@@ -121,6 +167,8 @@ func Test_monitor_detect(t *testing.T) {
 				Configuration:           cfg,
 				Interface:               tt.policy(cfg, nil, nil),
 				highUsageCountByResName: tt.highUsageCountByResName,
+				pressureThreshold:       tt.pressureThreshold,
+				pressures:               tt.pressures,
 				lowWatermark:            map[v1.ResourceName]int{v1.ResourceCPU: 30, v1.ResourceMemory: 30},
 				getNodeFunc:             tt.getNodeFunc,
 				getPodsFunc:             tt.getPodsFunc,
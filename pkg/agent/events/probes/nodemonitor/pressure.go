@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemonitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/agent/config/api"
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+)
+
+const (
+	defaultPSIPath = "/host/proc/pressure"
+	psiPathEnv     = "PSI_PATH"
+
+	// resourceIO is the name of the io pressure, io is not overSubscribed, so its pressure only stops scheduling
+	// offline pods.
+	resourceIO v1.ResourceName = "io"
+)
+
+// pressureResources are the resources whose pressure stall information is read.
+var pressureResources = []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, resourceIO}
+
+type pressureFile struct {
+	// node is the file of the node in the psi path.
+	node string
+	// cgroup is the file of the cgroup in the subsystem.
+	cgroup    string
+	subsystem cgroup.CgroupSubsystem
+}
+
+var pressureFiles = map[v1.ResourceName]pressureFile{
+	v1.ResourceCPU:    {node: "cpu", cgroup: cgroup.CPUPressureFile, subsystem: cgroup.CgroupCpuSubsystem},
+	v1.ResourceMemory: {node: "memory", cgroup: cgroup.MemoryPressureFile, subsystem: cgroup.CgroupMemorySubsystem},
+	resourceIO:        {node: "io", cgroup: cgroup.IOPressureFile, subsystem: cgroup.CgroupBlkioSubsystem},
+}
+
+// PressureGetter returns the percent of time some tasks stalled on the resources in the last 10 seconds.
+type PressureGetter func() map[v1.ResourceName]float64
+
+// NewPressureGetter returns the getter of the pressure of the node and of the pods: the highest of the pressure of the
+// node and of the kubepods cgroup is taken, so the stalls are detected whichever of them the kernel exposes.
+func NewPressureGetter(cgroupMgr cgroup.CgroupManager) PressureGetter {
+	psiPath := strings.TrimSpace(os.Getenv(psiPathEnv))
+	if psiPath == "" {
+		psiPath = defaultPSIPath
+	}
+	// unreadable is logged once, the pressure stall information is missing until the kernel or the mounts change
+	var unreadable sync.Once
+	return func() map[v1.ResourceName]float64 {
+		pressures := make(map[v1.ResourceName]float64)
+		for _, res := range pressureResources {
+			files := []string{filepath.Join(psiPath, pressureFiles[res].node)}
+			if cgroupMgr != nil {
+				if cgroupPath, err := cgroupMgr.GetRootCgroupPath(pressureFiles[res].subsystem); err == nil {
+					files = append(files, filepath.Join(cgroupPath, pressureFiles[res].cgroup))
+				}
+			}
+			readable := false
+			for _, file := range files {
+				pressure, err := readSomeAvg10(file)
+				if err != nil {
+					klog.V(5).InfoS("Failed to read pressure stall information", "file", file, "err", err)
+					continue
+				}
+				readable = true
+				if pressure > pressures[res] {
+					pressures[res] = pressure
+				}
+			}
+			if !readable {
+				unreadable.Do(func() {
+					klog.InfoS("No pressure stall information is readable, the pressure of the resource is not checked", "resource", res, "files", files)
+				})
+			}
+		}
+		return pressures
+	}
+}
+
+// readSomeAvg10 reads the avg10 of the "some" line of a pressure file, e.g.
+// "some avg10=1.53 avg60=0.87 avg300=0.41 total=1234567".
+func readSomeAvg10(file string) (float64, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "some" {
+			continue
+		}
+		for _, field := range fields[1:] {
+			value, found := strings.CutPrefix(field, "avg10=")
+			if !found {
+				continue
+			}
+			return strconv.ParseFloat(value, 64)
+		}
+	}
+	return 0, fmt.Errorf("no some avg10 in %s", file)
+}
+
+// setPressureThreshold sets the pressure thresholds of the resources, zero disables the pressure of the resource.
+func setPressureThreshold(cfg *api.ColocationConfig, threshold map[v1.ResourceName]int) {
+	for res, value := range map[v1.ResourceName]*int{
+		v1.ResourceCPU:    cfg.EvictingConfig.EvictingCPUPressureThreshold,
+		v1.ResourceMemory: cfg.EvictingConfig.EvictingMemoryPressureThreshold,
+		resourceIO:        cfg.EvictingConfig.EvictingIOPressureThreshold,
+	} {
+		threshold[res] = 0
+		if value != nil {
+			threshold[res] = *value
+		}
+	}
+	klog.InfoS("Successfully set pressure threshold", "cpu", threshold[v1.ResourceCPU],
+		"memory", threshold[v1.ResourceMemory], "io", threshold[resourceIO])
+}
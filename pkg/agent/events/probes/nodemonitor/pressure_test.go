@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemonitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+	"volcano.sh/volcano/pkg/resourceusage"
+)
+
+func writePressure(t *testing.T, file, someAvg10 string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	content := "some avg10=" + someAvg10 + " avg60=1.00 avg300=0.50 total=123456\n" +
+		"full avg10=0.00 avg60=0.00 avg300=0.00 total=0\n"
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0644))
+}
+
+func TestPressureGetter(t *testing.T) {
+	psiPath := t.TempDir()
+	cgroupRoot := t.TempDir()
+	t.Setenv(psiPathEnv, psiPath)
+
+	writePressure(t, filepath.Join(psiPath, "cpu"), "12.50")
+	writePressure(t, filepath.Join(psiPath, "memory"), "3.00")
+	// the pods stall more on memory than the node on average.
+	writePressure(t, filepath.Join(cgroupRoot, "kubepods", "memory.pressure"), "8.25")
+	assert.NoError(t, os.WriteFile(filepath.Join(psiPath, "io"), []byte("invalid"), 0644))
+
+	pressures := NewPressureGetter(cgroup.NewCgroupV2Manager("cgroupfs", cgroupRoot, ""))()
+	assert.Equal(t, map[v1.ResourceName]float64{
+		v1.ResourceCPU:    12.5,
+		v1.ResourceMemory: 8.25,
+	}, pressures)
+}
+
+func Test_monitor_utilizationMonitoring(t *testing.T) {
+	m := &monitor{
+		lowWatermark:            map[v1.ResourceName]int{v1.ResourceCPU: 30, v1.ResourceMemory: 30},
+		highWatermark:           map[v1.ResourceName]int{v1.ResourceCPU: 80, v1.ResourceMemory: 60},
+		pressureThreshold:       map[v1.ResourceName]int{v1.ResourceCPU: 20, v1.ResourceMemory: 0, resourceIO: 10},
+		highUsageCountByResName: map[v1.ResourceName]int{v1.ResourceMemory: 3},
+		getNodeFunc:             makeNode,
+		usageGetter:             resourceusage.NewFakeResourceGetter(0, 0, 20, 20),
+		getPressures: func() map[v1.ResourceName]float64 {
+			return map[v1.ResourceName]float64{v1.ResourceCPU: 25, v1.ResourceMemory: 90, resourceIO: 15}
+		},
+	}
+
+	for i := 0; i < highUsageCountLimit; i++ {
+		m.utilizationMonitoring()
+	}
+	assert.True(t, m.nodeHasPressure(v1.ResourceCPU))
+	// the memory pressure is disabled by the zero threshold.
+	assert.False(t, m.nodeHasPressure(v1.ResourceMemory))
+	assert.True(t, m.nodeHasPressure(resourceIO))
+}
@@ -23,6 +23,8 @@ const (
 	CPUBurstFeature         Feature = "CPUBurst"
 	MemoryQoSFeature        Feature = "MemoryQoS"
 	NetworkQoSFeature       Feature = "NetworkQoS"
+	ResctrlFeature          Feature = "Resctrl"
+	IOQoSFeature            Feature = "IOQoS"
 	OverSubscriptionFeature Feature = "OverSubscription"
 	EvictionFeature         Feature = "Eviction"
 	ResourcesFeature        Feature = "Resources"
@@ -77,6 +77,18 @@ func (f *featureGate) Enabled(key Feature, c *api.ColocationConfig) (bool, error
 		}
 		return (nodeColocationEnabled || nodeOverSubscriptionEnabled) && *c.NetworkQosConfig.Enable, nil
 
+	case ResctrlFeature:
+		if c.ResctrlConfig == nil || c.ResctrlConfig.Enable == nil {
+			return false, fmt.Errorf("nil resctrl config")
+		}
+		return (nodeColocationEnabled || nodeOverSubscriptionEnabled) && *c.ResctrlConfig.Enable, nil
+
+	case IOQoSFeature:
+		if c.IOQosConfig == nil || c.IOQosConfig.Enable == nil {
+			return false, fmt.Errorf("nil io qos config")
+		}
+		return (nodeColocationEnabled || nodeOverSubscriptionEnabled) && *c.IOQosConfig.Enable, nil
+
 	case OverSubscriptionFeature:
 		if c.OverSubscriptionConfig == nil || c.OverSubscriptionConfig.Enable == nil {
 			return false, fmt.Errorf("nil overSubscription config")
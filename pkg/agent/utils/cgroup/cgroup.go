@@ -33,6 +33,7 @@ const (
 	CgroupMemorySubsystem CgroupSubsystem = "memory"
 	CgroupCpuSubsystem    CgroupSubsystem = "cpu"
 	CgroupNetCLSSubsystem CgroupSubsystem = "net_cls"
+	CgroupBlkioSubsystem  CgroupSubsystem = "blkio"
 
 	CgroupKubeRoot string = "kubepods"
 
@@ -52,12 +53,35 @@ const (
 	NetCLSFileName string = "net_cls.classid"
 
 	CPUShareFileName string = "cpu.shares"
+
+	BlkioWeightFile               string = "blkio.weight"
+	BlkioThrottleReadBpsFile      string = "blkio.throttle.read_bps_device"
+	BlkioThrottleWriteBpsFile     string = "blkio.throttle.write_bps_device"
+	BlkioThrottleReadIOPSFile     string = "blkio.throttle.read_iops_device"
+	BlkioThrottleWriteIOPSFile    string = "blkio.throttle.write_iops_device"
+	BlkioThrottleServiceBytesFile string = "blkio.throttle.io_service_bytes_recursive"
+	BlkioThrottleServicedFile     string = "blkio.throttle.io_serviced_recursive"
+
+	// CgroupTasksFile lists the thread ids of the cgroup.
+	CgroupTasksFile string = "tasks"
+)
+
+// CgroupVersion is the version of the cgroup hierarchy mounted on the host.
+type CgroupVersion string
+
+const (
+	// CgroupV1 is the legacy hierarchy, with a mount per subsystem.
+	CgroupV1 CgroupVersion = "v1"
+	// CgroupV2 is the unified hierarchy, with all controllers in a single mount.
+	CgroupV2 CgroupVersion = "v2"
 )
 
 type CgroupManager interface {
 	GetRootCgroupPath(cgroupSubsystem CgroupSubsystem) (string, error)
 	GetQoSCgroupPath(qos corev1.PodQOSClass, cgroupSubsystem CgroupSubsystem) (string, error)
 	GetPodCgroupPath(qos corev1.PodQOSClass, cgroupSubsystem CgroupSubsystem, podUID types.UID) (string, error)
+	// GetCgroupVersion returns the version of the hierarchy the paths are in, which decides the files to use.
+	GetCgroupVersion() CgroupVersion
 }
 
 type CgroupManagerImpl struct {
@@ -80,12 +104,7 @@ func NewCgroupManager(cgroupDriver, cgroupRoot, kubeCgroupRoot string) CgroupMan
 }
 
 func (c *CgroupManagerImpl) GetRootCgroupPath(cgroupSubsystem CgroupSubsystem) (string, error) {
-	cgroupName := []string{CgroupKubeRoot}
-	if c.kubeCgroupRoot != "" {
-		cgroupName = append([]string{c.kubeCgroupRoot}, cgroupName...)
-	}
-
-	cgroupPath, err := c.CgroupNameToCgroupPath(cgroupName)
+	cgroupPath, err := c.CgroupNameToCgroupPath(c.rootCgroupName())
 	if err != nil {
 		return "", err
 	}
@@ -93,42 +112,46 @@ func (c *CgroupManagerImpl) GetRootCgroupPath(cgroupSubsystem CgroupSubsystem) (
 }
 
 func (c *CgroupManagerImpl) GetQoSCgroupPath(qos corev1.PodQOSClass, cgroupSubsystem CgroupSubsystem) (string, error) {
-	cgroupName := []string{CgroupKubeRoot}
-	if c.kubeCgroupRoot != "" {
-		cgroupName = append([]string{c.kubeCgroupRoot}, cgroupName...)
-	}
-	switch qos {
-	case corev1.PodQOSBurstable:
-		cgroupName = append(cgroupName, "burstable")
-	case corev1.PodQOSBestEffort:
-		cgroupName = append(cgroupName, "besteffort")
+	cgroupPath, err := c.CgroupNameToCgroupPath(c.qosCgroupName(qos))
+	if err != nil {
+		return "", err
 	}
+	return filepath.Join(c.cgroupRoot, string(cgroupSubsystem), cgroupPath), err
+}
 
-	cgroupPath, err := c.CgroupNameToCgroupPath(cgroupName)
+func (c *CgroupManagerImpl) GetPodCgroupPath(qos corev1.PodQOSClass, cgroupSubsystem CgroupSubsystem, podUID types.UID) (string, error) {
+	cgroupPath, err := c.CgroupNameToCgroupPath(c.podCgroupName(qos, podUID))
 	if err != nil {
 		return "", err
 	}
 	return filepath.Join(c.cgroupRoot, string(cgroupSubsystem), cgroupPath), err
 }
 
-func (c *CgroupManagerImpl) GetPodCgroupPath(qos corev1.PodQOSClass, cgroupSubsystem CgroupSubsystem, podUID types.UID) (string, error) {
+func (c *CgroupManagerImpl) GetCgroupVersion() CgroupVersion {
+	return CgroupV1
+}
+
+func (c *CgroupManagerImpl) rootCgroupName() []string {
 	cgroupName := []string{CgroupKubeRoot}
 	if c.kubeCgroupRoot != "" {
 		cgroupName = append([]string{c.kubeCgroupRoot}, cgroupName...)
 	}
+	return cgroupName
+}
+
+func (c *CgroupManagerImpl) qosCgroupName(qos corev1.PodQOSClass) []string {
+	cgroupName := c.rootCgroupName()
 	switch qos {
 	case corev1.PodQOSBurstable:
 		cgroupName = append(cgroupName, "burstable")
 	case corev1.PodQOSBestEffort:
 		cgroupName = append(cgroupName, "besteffort")
 	}
-	cgroupName = append(cgroupName, getPodCgroupNameSuffix(podUID))
+	return cgroupName
+}
 
-	cgroupPath, err := c.CgroupNameToCgroupPath(cgroupName)
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(c.cgroupRoot, string(cgroupSubsystem), cgroupPath), err
+func (c *CgroupManagerImpl) podCgroupName(qos corev1.PodQOSClass, podUID types.UID) []string {
+	return append(c.qosCgroupName(qos), getPodCgroupNameSuffix(podUID))
 }
 
 func (c *CgroupManagerImpl) CgroupNameToCgroupPath(cgroupName []string) (string, error) {
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDetectCgroupVersion(t *testing.T) {
+	v1Root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(v1Root, "cpu"), 0755))
+	// the hybrid mode mounts the unified hierarchy aside the v1 ones.
+	assert.NoError(t, os.MkdirAll(filepath.Join(v1Root, "unified"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(v1Root, "unified", CgroupControllersFile), []byte("cpu memory"), 0644))
+
+	v2Root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(v2Root, CgroupControllersFile), []byte("cpuset cpu io memory pids"), 0644))
+
+	assert.Equal(t, CgroupV1, DetectCgroupVersion(v1Root))
+	assert.Equal(t, CgroupV2, DetectCgroupVersion(v2Root))
+	assert.Equal(t, CgroupV1, NewCgroupManagerWithVersion(DetectCgroupVersion(v1Root), "cgroupfs", v1Root, "").GetCgroupVersion())
+	assert.Equal(t, CgroupV2, NewCgroupManagerWithVersion(DetectCgroupVersion(v2Root), "cgroupfs", v2Root, "").GetCgroupVersion())
+}
+
+func TestCgroupV2ManagerPaths(t *testing.T) {
+	tests := []struct {
+		name         string
+		cgroupDriver string
+		wantRoot     string
+		wantQoS      string
+		wantPod      string
+	}{
+		{
+			name:         "cgroupfs driver",
+			cgroupDriver: "cgroupfs",
+			wantRoot:     "/sys/fs/cgroup/kubepods",
+			wantQoS:      "/sys/fs/cgroup/kubepods/burstable",
+			wantPod:      "/sys/fs/cgroup/kubepods/burstable/pod1234-5678",
+		},
+		{
+			name:         "systemd driver",
+			cgroupDriver: "systemd",
+			wantRoot:     "/sys/fs/cgroup/kubepods.slice",
+			wantQoS:      "/sys/fs/cgroup/kubepods.slice/kubepods-burstable.slice",
+			wantPod:      "/sys/fs/cgroup/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1234_5678.slice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr := NewCgroupV2Manager(tt.cgroupDriver, "/sys/fs/cgroup", "")
+			for _, subsystem := range []CgroupSubsystem{CgroupCpuSubsystem, CgroupMemorySubsystem} {
+				root, err := mgr.GetRootCgroupPath(subsystem)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRoot, root)
+
+				qos, err := mgr.GetQoSCgroupPath(corev1.PodQOSBurstable, subsystem)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantQoS, qos)
+
+				pod, err := mgr.GetPodCgroupPath(corev1.PodQOSBurstable, subsystem, "1234-5678")
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPod, pod)
+			}
+		})
+	}
+}
+
+func TestConvertToV2(t *testing.T) {
+	tests := []struct {
+		v1File      string
+		value       int64
+		wantFile    string
+		wantContent string
+	}{
+		{v1File: CPUShareFileName, value: 2, wantFile: CPUWeightFile, wantContent: "1"},
+		{v1File: CPUShareFileName, value: 1024, wantFile: CPUWeightFile, wantContent: "39"},
+		{v1File: CPUShareFileName, value: 262144, wantFile: CPUWeightFile, wantContent: "10000"},
+		{v1File: CPUQuotaTotalFile, value: 200000, wantFile: CPUMaxFile, wantContent: "200000"},
+		{v1File: CPUQuotaTotalFile, value: -1, wantFile: CPUMaxFile, wantContent: "max"},
+		{v1File: CPUQuotaBurstFile, value: 50000, wantFile: CPUMaxBurstFile, wantContent: "50000"},
+		{v1File: MemoryLimitFile, value: 1073741824, wantFile: MemoryMaxFile, wantContent: "1073741824"},
+		{v1File: NetCLSFileName, value: 1, wantFile: NetCLSFileName, wantContent: "1"},
+	}
+
+	for _, tt := range tests {
+		file, content := ConvertToV2(tt.v1File, tt.value)
+		assert.Equal(t, tt.wantFile, file, tt.v1File)
+		assert.Equal(t, tt.wantContent, content, tt.v1File)
+	}
+}
+
+func TestIOWeightToBlkioWeight(t *testing.T) {
+	assert.Equal(t, int64(10), IOWeightToBlkioWeight(1))
+	assert.Equal(t, int64(10), IOWeightToBlkioWeight(10))
+	assert.Equal(t, int64(19), IOWeightToBlkioWeight(100))
+	assert.Equal(t, int64(1000), IOWeightToBlkioWeight(10000))
+}
+
+func TestParseCPUMax(t *testing.T) {
+	quota, err := ParseCPUMax("max 100000\n")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), quota)
+
+	quota, err = ParseCPUMax("150000 100000\n")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(150000), quota)
+
+	_, err = ParseCPUMax("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cgroup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// CgroupControllersFile only exists in the root of the unified hierarchy.
+	CgroupControllersFile string = "cgroup.controllers"
+	// CgroupThreadsFile lists the thread ids of the cgroup like tasks in v1.
+	CgroupThreadsFile string = "cgroup.threads"
+
+	CPUWeightFile   string = "cpu.weight"
+	CPUIdleFile     string = "cpu.idle"
+	CPUMaxFile      string = "cpu.max"
+	CPUMaxBurstFile string = "cpu.max.burst"
+	CPUStatFile     string = "cpu.stat"
+
+	MemoryLowFile  string = "memory.low"
+	MemoryHighFile string = "memory.high"
+	MemoryMaxFile  string = "memory.max"
+
+	IOWeightFile string = "io.weight"
+	IOMaxFile    string = "io.max"
+	IOStatFile   string = "io.stat"
+
+	// the pressure stall information of the cgroup, also in v1 when the kernel enables psi for it.
+	CPUPressureFile    string = "cpu.pressure"
+	MemoryPressureFile string = "memory.pressure"
+	IOPressureFile     string = "io.pressure"
+
+	// CgroupMaxValue is the value of the unlimited cpu.max quota and memory.high and memory.max.
+	CgroupMaxValue string = "max"
+
+	// CPUUsageUsecKey is the key of the cpu usage in microseconds in cpu.stat.
+	CPUUsageUsecKey string = "usage_usec"
+)
+
+type CgroupV2ManagerImpl struct {
+	*CgroupManagerImpl
+}
+
+// NewCgroupV2Manager returns the manager of the unified hierarchy, the paths of all subsystems are the same.
+func NewCgroupV2Manager(cgroupDriver, cgroupRoot, kubeCgroupRoot string) CgroupManager {
+	return &CgroupV2ManagerImpl{
+		CgroupManagerImpl: &CgroupManagerImpl{
+			cgroupDriver:   cgroupDriver,
+			cgroupRoot:     cgroupRoot,
+			kubeCgroupRoot: kubeCgroupRoot,
+		},
+	}
+}
+
+// NewCgroupManagerWithVersion returns the manager of the hierarchy of the version.
+func NewCgroupManagerWithVersion(version CgroupVersion, cgroupDriver, cgroupRoot, kubeCgroupRoot string) CgroupManager {
+	if version == CgroupV2 {
+		return NewCgroupV2Manager(cgroupDriver, cgroupRoot, kubeCgroupRoot)
+	}
+	return NewCgroupManager(cgroupDriver, cgroupRoot, kubeCgroupRoot)
+}
+
+// DetectCgroupVersion returns the version of the hierarchy mounted on cgroupRoot, the hybrid mode is v1 as the
+// controllers are still in the v1 mounts.
+func DetectCgroupVersion(cgroupRoot string) CgroupVersion {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, CgroupControllersFile)); err == nil {
+		return CgroupV2
+	}
+	return CgroupV1
+}
+
+func (c *CgroupV2ManagerImpl) GetRootCgroupPath(cgroupSubsystem CgroupSubsystem) (string, error) {
+	cgroupPath, err := c.CgroupNameToCgroupPath(c.rootCgroupName())
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(c.cgroupRoot, cgroupPath), nil
+}
+
+func (c *CgroupV2ManagerImpl) GetQoSCgroupPath(qos corev1.PodQOSClass, cgroupSubsystem CgroupSubsystem) (string, error) {
+	cgroupPath, err := c.CgroupNameToCgroupPath(c.qosCgroupName(qos))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(c.cgroupRoot, cgroupPath), nil
+}
+
+func (c *CgroupV2ManagerImpl) GetPodCgroupPath(qos corev1.PodQOSClass, cgroupSubsystem CgroupSubsystem, podUID types.UID) (string, error) {
+	cgroupPath, err := c.CgroupNameToCgroupPath(c.podCgroupName(qos, podUID))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(c.cgroupRoot, cgroupPath), nil
+}
+
+func (c *CgroupV2ManagerImpl) GetCgroupVersion() CgroupVersion {
+	return CgroupV2
+}
+
+// CPUSharesToWeight converts cpu.shares in [2, 262144] to cpu.weight in [1, 10000], the same way as runc.
+func CPUSharesToWeight(shares int64) int64 {
+	if shares < 2 {
+		shares = 2
+	}
+	if shares > 262144 {
+		shares = 262144
+	}
+	return 1 + ((shares-2)*9999)/262142
+}
+
+// IOWeightToBlkioWeight converts io.weight in [1, 10000] to blkio.weight in [10, 1000], the inverse of the conversion
+// of runc.
+func IOWeightToBlkioWeight(weight int64) int64 {
+	if weight < 1 {
+		weight = 1
+	}
+	if weight > 10000 {
+		weight = 10000
+	}
+	return 10 + ((weight-1)*990)/9999
+}
+
+// ParseCPUMax returns the quota of cpu.max, "$MAX $PERIOD", -1 is returned for "max" like cpu.cfs_quota_us.
+func ParseCPUMax(content string) (int64, error) {
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid cpu.max content: %q", content)
+	}
+	if fields[0] == CgroupMaxValue {
+		return -1, nil
+	}
+	return strconv.ParseInt(fields[0], 10, 64)
+}
+
+// ConvertToV2 converts the value of a v1 file to the v2 file and content of the same policy, the files without
+// a v2 counterpart are returned as they are.
+func ConvertToV2(v1File string, value int64) (string, string) {
+	switch v1File {
+	case CPUShareFileName:
+		return CPUWeightFile, strconv.FormatInt(CPUSharesToWeight(value), 10)
+	case CPUQuotaTotalFile:
+		// the period is kept when only the quota is written.
+		if value < 0 {
+			return CPUMaxFile, CgroupMaxValue
+		}
+		return CPUMaxFile, strconv.FormatInt(value, 10)
+	case CPUQuotaBurstFile:
+		return CPUMaxBurstFile, strconv.FormatInt(value, 10)
+	case MemoryLimitFile:
+		if value < 0 {
+			return MemoryMaxFile, CgroupMaxValue
+		}
+		return MemoryMaxFile, strconv.FormatInt(value, 10)
+	default:
+		klog.V(4).InfoS("Cgroup file has no v2 counterpart", "file", v1File)
+		return v1File, strconv.FormatInt(value, 10)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eviction
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/config"
+	"volcano.sh/volcano/pkg/metriccollect"
+	"volcano.sh/volcano/pkg/metriccollect/local"
+)
+
+const (
+	DefaultRankPolicyName = "default"
+
+	// recentlyEvictedWindow is how long the workload of an evicted pod is penalized, so that the pressure is not
+	// always relieved at the cost of the same workload.
+	recentlyEvictedWindow = 10 * time.Minute
+)
+
+func init() {
+	RegistryRankPolicy(DefaultRankPolicyName, NewDefaultRankPolicy)
+}
+
+// defaultRankPolicy evicts first the pods not of a recently evicted workload, then the pods of lower priority, then
+// the pods using more of the resource under pressure, then the pods which started later and lose less work. The
+// ties keep the order of the candidates, which are sorted by requests.
+type defaultRankPolicy struct {
+	mgr *metriccollect.MetricCollectorManager
+	now func() time.Time
+
+	lock sync.Mutex
+	// evictedAt is the last time a pod of the workload was evicted.
+	evictedAt map[string]time.Time
+}
+
+func NewDefaultRankPolicy(config *config.Configuration, mgr *metriccollect.MetricCollectorManager) RankPolicy {
+	return &defaultRankPolicy{
+		mgr:       mgr,
+		now:       time.Now,
+		evictedAt: make(map[string]time.Time),
+	}
+}
+
+func (p *defaultRankPolicy) Name() string {
+	return DefaultRankPolicyName
+}
+
+func (p *defaultRankPolicy) Rank(pods []*corev1.Pod, resName corev1.ResourceName) []*corev1.Pod {
+	usages := p.podUsages(pods, resName)
+	penalized := p.recentlyEvicted(pods)
+
+	ranked := make([]*corev1.Pod, len(pods))
+	copy(ranked, pods)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if penalized[a] != penalized[b] {
+			return !penalized[a]
+		}
+		if podPriority(a) != podPriority(b) {
+			return podPriority(a) < podPriority(b)
+		}
+		if usages[a.UID] != usages[b.UID] {
+			return usages[a.UID] > usages[b.UID]
+		}
+		return podStartTime(a).After(podStartTime(b))
+	})
+	return ranked
+}
+
+func (p *defaultRankPolicy) Evicted(pod *corev1.Pod) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.evictedAt[workloadKey(pod)] = p.now()
+}
+
+// recentlyEvicted returns the pods whose workload had a pod evicted in the window, and forgets the evictions before.
+func (p *defaultRankPolicy) recentlyEvicted(pods []*corev1.Pod) map[*corev1.Pod]bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := p.now()
+	for key, evictedAt := range p.evictedAt {
+		if now.Sub(evictedAt) > recentlyEvictedWindow {
+			delete(p.evictedAt, key)
+		}
+	}
+	penalized := make(map[*corev1.Pod]bool)
+	for _, pod := range pods {
+		if _, ok := p.evictedAt[workloadKey(pod)]; ok {
+			penalized[pod] = true
+		}
+	}
+	return penalized
+}
+
+// podUsages returns the usage of the resource of the pods, it is empty when the usage can not be collected.
+func (p *defaultRankPolicy) podUsages(pods []*corev1.Pod, resName corev1.ResourceName) map[types.UID]float64 {
+	usages := make(map[types.UID]float64)
+	if p.mgr == nil || len(pods) == 0 {
+		return usages
+	}
+	collector, err := p.mgr.GetPluginByName(local.CollectorName)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get metric collector")
+		return usages
+	}
+	series, err := collector.CollectMetrics(&local.LocalMetricInfo{ResourceType: string(resName), Pods: pods}, time.Now(), metav1.Duration{})
+	if err != nil {
+		klog.ErrorS(err, "Failed to collect pods usage", "resource", resName)
+		return usages
+	}
+	for _, s := range series {
+		if len(s.Samples) == 0 {
+			continue
+		}
+		for _, label := range s.Labels {
+			if label.Name == local.PodUIDLabel {
+				usages[types.UID(label.Value)] = s.Samples[len(s.Samples)-1].Value
+			}
+		}
+	}
+	return usages
+}
+
+// workloadKey returns the controller of the pod, or the pod itself if it has no controller.
+func workloadKey(pod *corev1.Pod) string {
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		return string(owner.UID)
+	}
+	return pod.Namespace + "/" + pod.Name
+}
+
+func podPriority(pod *corev1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
+
+func podStartTime(pod *corev1.Pod) time.Time {
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time
+	}
+	return pod.CreationTimestamp.Time
+}
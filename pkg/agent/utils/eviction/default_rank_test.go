@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eviction
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilpointer "k8s.io/utils/pointer"
+
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+	"volcano.sh/volcano/pkg/metriccollect"
+)
+
+func makeRankPod(name string, priority int32, startedAgo time.Duration, owner types.UID) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+		Spec:       corev1.PodSpec{Priority: utilpointer.Int32(priority)},
+		Status: corev1.PodStatus{
+			QOSClass:  corev1.PodQOSBestEffort,
+			StartTime: &metav1.Time{Time: time.Now().Add(-startedAgo)},
+		},
+	}
+	if owner != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{{UID: owner, Controller: utilpointer.Bool(true)}}
+	}
+	return pod
+}
+
+func TestDefaultRankPolicy(t *testing.T) {
+	cgroupRoot := t.TempDir()
+	for name, usage := range map[string]string{"low-usage": "100", "high-usage": "300"} {
+		podPath := filepath.Join(cgroupRoot, "kubepods", "besteffort", "pod"+name)
+		assert.NoError(t, os.MkdirAll(podPath, 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(podPath, cgroup.MemoryUsageFile), []byte("anon "+usage+"\nfile 0\n"), 0644))
+	}
+	mgr, err := metriccollect.NewMetricCollectorManager(nil, cgroup.NewCgroupV2Manager("cgroupfs", cgroupRoot, ""))
+	assert.NoError(t, err)
+
+	pods := []*corev1.Pod{
+		makeRankPod("high-priority", 10, time.Hour, ""),
+		makeRankPod("low-usage", 0, time.Hour, ""),
+		makeRankPod("high-usage", 0, time.Hour, ""),
+		// the usage of the pods without cgroup is unknown.
+		makeRankPod("long-running", 0, 2*time.Hour, "job-1"),
+		makeRankPod("short-running", 0, time.Minute, "job-2"),
+	}
+	p := NewDefaultRankPolicy(nil, mgr)
+	rankedNames := func() []string {
+		var names []string
+		for _, pod := range p.Rank(pods, corev1.ResourceMemory) {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"high-usage", "low-usage", "short-running", "long-running", "high-priority"}, rankedNames())
+
+	// the pods of the workload recently evicted are penalized.
+	p.Evicted(makeRankPod("short-running-evicted", 0, time.Minute, "job-2"))
+	p.Evicted(makeRankPod("high-usage", 0, time.Hour, ""))
+	assert.Equal(t, []string{"low-usage", "long-running", "high-priority", "high-usage", "short-running"}, rankedNames())
+
+	// the penalty expires.
+	p.(*defaultRankPolicy).now = func() time.Time { return time.Now().Add(recentlyEvictedWindow + time.Minute) }
+	assert.Equal(t, []string{"high-usage", "low-usage", "short-running", "long-running", "high-priority"}, rankedNames())
+}
@@ -42,6 +42,8 @@ const (
 
 	// Reason is the reason reported back in status.
 	Reason = "Evicted"
+	// DryRunReason is the reason of the event of a pod which would be evicted in dry run mode.
+	DryRunReason = "EvictionDryRun"
 )
 
 func evictPod(ctx context.Context, client clientset.Interface, gracePeriodSeconds *int64, pod *corev1.Pod, evictionVersion string) error {
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eviction
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/config"
+	"volcano.sh/volcano/pkg/metriccollect"
+)
+
+var (
+	rankLock      sync.Mutex
+	rankPolicyMap = make(map[string]RankPolicyFunc)
+)
+
+type RankPolicyFunc func(config *config.Configuration, mgr *metriccollect.MetricCollectorManager) RankPolicy
+
+// RankPolicy ranks the offline pods to be evicted when the node has pressure, you can register your own policy and
+// select it by the flag --eviction-rank-policy.
+type RankPolicy interface {
+	// Name is the policy name.
+	Name() string
+	// Rank returns the pods in the order they should be evicted to relieve the pressure of resName.
+	Rank(pods []*corev1.Pod, resName corev1.ResourceName) []*corev1.Pod
+	// Evicted is called after a pod is evicted.
+	Evicted(pod *corev1.Pod)
+}
+
+func RegistryRankPolicy(name string, policyFunc RankPolicyFunc) {
+	rankLock.Lock()
+	defer rankLock.Unlock()
+
+	if _, exist := rankPolicyMap[name]; exist {
+		klog.ErrorS(nil, "Eviction rank policy has already been registered", "name", name)
+		return
+	}
+	rankPolicyMap[name] = policyFunc
+}
+
+func GetRankPolicyFunc(name string) RankPolicyFunc {
+	rankLock.Lock()
+	defer rankLock.Unlock()
+
+	fn, exist := rankPolicyMap[name]
+	if !exist {
+		klog.Fatalf("Eviction rank policy %s not registered", name)
+	}
+	return fn
+}
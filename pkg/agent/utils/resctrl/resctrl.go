@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resctrl
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/agent/utils"
+	"volcano.sh/volcano/pkg/agent/utils/file"
+)
+
+const (
+	resctrlDir = "resctrl"
+	infoDir    = "info"
+
+	SchemataFile = "schemata"
+	TasksFile    = "tasks"
+
+	l3CbmMaskFile       = "info/L3/cbm_mask"
+	l3MinCbmBitsFile    = "info/L3/min_cbm_bits"
+	mbMinBandwidthFile  = "info/MB/min_bandwidth"
+	mbBandwidthGranFile = "info/MB/bandwidth_gran"
+
+	l3Resource = "L3"
+	mbResource = "MB"
+
+	// OfflineGroup is the CLOS group the offline pods are assigned to.
+	OfflineGroup = "volcano-offline"
+)
+
+// Root returns the mount point of resctrl under the sys fs path.
+func Root() string {
+	sysFsPath := strings.TrimSpace(os.Getenv(utils.SysFsPathEnv))
+	if sysFsPath == "" {
+		sysFsPath = utils.DefaultSysFsPath
+	}
+	return filepath.Join(sysFsPath, resctrlDir)
+}
+
+// IsSupported returns true if resctrl is mounted on root, which requires the cpu and the kernel to support RDT.
+func IsSupported(root string) bool {
+	for _, f := range []string{infoDir, SchemataFile} {
+		if _, err := os.Stat(filepath.Join(root, f)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildSchemata returns the schemata limiting a group to l3Percent of the L3 cache ways and mbPercent of the memory
+// bandwidth of every cache domain, a resource not enabled in the root schemata is left out.
+func BuildSchemata(root string, l3Percent, mbPercent int) (string, error) {
+	domains, err := readDomains(filepath.Join(root, SchemataFile))
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	if ids, ok := domains[l3Resource]; ok {
+		mask, err := l3Mask(root, l3Percent)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, schemataLine(l3Resource, ids, mask))
+	}
+	if ids, ok := domains[mbResource]; ok {
+		bandwidth, err := mbBandwidth(root, mbPercent)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, schemataLine(mbResource, ids, bandwidth))
+	}
+	if len(lines) == 0 {
+		return "", fmt.Errorf("neither %s nor %s is enabled in resctrl", l3Resource, mbResource)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// EnsureGroup creates the group and writes the schemata to it if it has changed.
+func EnsureGroup(root, group, schemata string) error {
+	groupPath := filepath.Join(root, group)
+	if err := os.Mkdir(groupPath, 0755); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("failed to create resctrl group(%s): %w", groupPath, err)
+	}
+
+	schemataFile := filepath.Join(groupPath, SchemataFile)
+	current, err := file.ReadByteFromFile(schemataFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if schemataApplied(string(current), schemata) {
+		return nil
+	}
+	if err = file.WriteByteToFile(schemataFile, []byte(schemata)); err != nil {
+		return fmt.Errorf("failed to write schemata(%s) to file(%s): %w", schemata, schemataFile, err)
+	}
+	klog.InfoS("Successfully set resctrl schemata", "group", group, "schemata", schemata)
+	return nil
+}
+
+// RemoveGroup removes the group, the kernel moves its tasks back to the root group.
+func RemoveGroup(root, group string) error {
+	groupPath := filepath.Join(root, group)
+	if err := os.Remove(groupPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove resctrl group(%s): %w", groupPath, err)
+	}
+	return nil
+}
+
+// AddTasks moves the threads into the group, the threads exited meanwhile are skipped. The threads created later
+// inherit the group of their parent.
+func AddTasks(root, group string, tids []string) error {
+	tasksFile := filepath.Join(root, group, TasksFile)
+	content, err := file.ReadByteFromFile(tasksFile)
+	if err != nil {
+		return err
+	}
+	existed := make(map[string]bool)
+	for _, tid := range strings.Fields(string(content)) {
+		existed[tid] = true
+	}
+
+	f, err := os.OpenFile(tasksFile, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// the kernel only accepts one thread id per write.
+	for _, tid := range tids {
+		if existed[tid] {
+			continue
+		}
+		if _, err = f.WriteString(tid + "\n"); err != nil {
+			if errors.Is(err, syscall.ESRCH) {
+				continue
+			}
+			return fmt.Errorf("failed to add task(%s) to resctrl group(%s): %w", tid, group, err)
+		}
+	}
+	return nil
+}
+
+// readDomains returns the cache ids of each resource in the schemata, e.g. "L3:0=fff;1=fff".
+func readDomains(schemataFile string) (map[string][]string, error) {
+	content, err := file.ReadByteFromFile(schemataFile)
+	if err != nil {
+		return nil, err
+	}
+	domains := make(map[string][]string)
+	for _, line := range strings.Split(string(content), "\n") {
+		resource, values, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		for _, value := range strings.Split(values, ";") {
+			id, _, found := strings.Cut(value, "=")
+			if found {
+				domains[resource] = append(domains[resource], strings.TrimSpace(id))
+			}
+		}
+	}
+	return domains, nil
+}
+
+// l3Mask returns the mask of the lowest ways making up percent of the cache, the ways of a mask must be contiguous.
+func l3Mask(root string, percent int) (string, error) {
+	content, err := file.ReadByteFromFile(filepath.Join(root, l3CbmMaskFile))
+	if err != nil {
+		return "", err
+	}
+	fullMask, err := strconv.ParseUint(strings.TrimSpace(string(content)), 16, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid L3 cbm mask: %w", err)
+	}
+	minWays := int64(1)
+	if value, err := file.ReadIntFromFile(filepath.Join(root, l3MinCbmBitsFile)); err == nil && value > minWays {
+		minWays = value
+	}
+
+	totalWays := int64(bits.OnesCount64(fullMask))
+	ways := (totalWays*int64(percent) + 99) / 100
+	if ways < minWays {
+		ways = minWays
+	}
+	if ways > totalWays {
+		ways = totalWays
+	}
+	return strconv.FormatUint(uint64(1)<<ways-1, 16), nil
+}
+
+// mbBandwidth returns percent rounded up to the granularity of the memory bandwidth allocation.
+func mbBandwidth(root string, percent int) (string, error) {
+	minBandwidth, err := file.ReadIntFromFile(filepath.Join(root, mbMinBandwidthFile))
+	if err != nil {
+		return "", err
+	}
+	granularity, err := file.ReadIntFromFile(filepath.Join(root, mbBandwidthGranFile))
+	if err != nil || granularity <= 0 {
+		granularity = 1
+	}
+
+	bandwidth := (int64(percent) + granularity - 1) / granularity * granularity
+	if bandwidth < minBandwidth {
+		bandwidth = minBandwidth
+	}
+	if bandwidth > 100 {
+		bandwidth = 100
+	}
+	return strconv.FormatInt(bandwidth, 10), nil
+}
+
+func schemataLine(resource string, ids []string, value string) string {
+	sort.Strings(ids)
+	domains := make([]string, 0, len(ids))
+	for _, id := range ids {
+		domains = append(domains, id+"="+value)
+	}
+	return resource + ":" + strings.Join(domains, ";")
+}
+
+// schemataApplied returns true if every domain of expected has the same value in content, the kernel pads the lines
+// and the masks it prints.
+func schemataApplied(content, expected string) bool {
+	current := parseSchemata(content)
+	for key, value := range parseSchemata(expected) {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSchemata returns the values of the schemata keyed by resource and cache id, the values are parsed as hex
+// which keeps the decimal bandwidth comparable as well.
+func parseSchemata(content string) map[string]uint64 {
+	values := make(map[string]uint64)
+	for _, line := range strings.Split(content, "\n") {
+		resource, domains, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found {
+			continue
+		}
+		for _, domain := range strings.Split(domains, ";") {
+			id, value, found := strings.Cut(domain, "=")
+			if !found {
+				continue
+			}
+			if parsed, err := strconv.ParseUint(strings.TrimSpace(value), 16, 64); err == nil {
+				values[resource+":"+strings.TrimSpace(id)] = parsed
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resctrl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, file, content string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0644))
+}
+
+// makeResctrl makes a fake resctrl of two cache domains with 12 L3 ways and the memory bandwidth allocation.
+func makeResctrl(t *testing.T, withMB bool) string {
+	root := t.TempDir()
+	schemata := "    L3:0=fff;1=fff\n"
+	if withMB {
+		schemata += "    MB:0=100;1=100\n"
+		writeFile(t, filepath.Join(root, mbMinBandwidthFile), "10\n")
+		writeFile(t, filepath.Join(root, mbBandwidthGranFile), "10\n")
+	}
+	writeFile(t, filepath.Join(root, SchemataFile), schemata)
+	writeFile(t, filepath.Join(root, l3CbmMaskFile), "fff\n")
+	writeFile(t, filepath.Join(root, l3MinCbmBitsFile), "2\n")
+	return root
+}
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported(makeResctrl(t, true)))
+	// resctrl is not mounted.
+	assert.False(t, IsSupported(t.TempDir()))
+
+	t.Setenv("SYS_FS_PATH", "/host/sys/fs")
+	assert.Equal(t, "/host/sys/fs/resctrl", Root())
+}
+
+func TestBuildSchemata(t *testing.T) {
+	tests := []struct {
+		name      string
+		withMB    bool
+		l3Percent int
+		mbPercent int
+		want      string
+	}{
+		{
+			name:      "l3 and mb",
+			withMB:    true,
+			l3Percent: 30,
+			mbPercent: 25,
+			want:      "L3:0=f;1=f\nMB:0=30;1=30",
+		},
+		{
+			name:      "at least min cbm bits and min bandwidth",
+			withMB:    true,
+			l3Percent: 1,
+			mbPercent: 1,
+			want:      "L3:0=3;1=3\nMB:0=10;1=10",
+		},
+		{
+			name:      "full cache",
+			withMB:    true,
+			l3Percent: 100,
+			mbPercent: 100,
+			want:      "L3:0=fff;1=fff\nMB:0=100;1=100",
+		},
+		{
+			name:      "no memory bandwidth allocation",
+			l3Percent: 50,
+			mbPercent: 50,
+			want:      "L3:0=3f;1=3f",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemata, err := BuildSchemata(makeResctrl(t, tt.withMB), tt.l3Percent, tt.mbPercent)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, schemata)
+		})
+	}
+}
+
+func TestGroup(t *testing.T) {
+	root := makeResctrl(t, true)
+	schemata := "L3:0=f;1=f\nMB:0=30;1=30"
+	assert.NoError(t, EnsureGroup(root, OfflineGroup, schemata))
+	content, err := os.ReadFile(filepath.Join(root, OfflineGroup, SchemataFile))
+	assert.NoError(t, err)
+	assert.Equal(t, schemata, string(content))
+
+	// the padded schemata printed by the kernel is not written again.
+	padded := "    L3:0=00f;1=00f\n    MB:0=30;1=30\n"
+	writeFile(t, filepath.Join(root, OfflineGroup, SchemataFile), padded)
+	assert.NoError(t, EnsureGroup(root, OfflineGroup, schemata))
+	content, err = os.ReadFile(filepath.Join(root, OfflineGroup, SchemataFile))
+	assert.NoError(t, err)
+	assert.Equal(t, padded, string(content))
+
+	writeFile(t, filepath.Join(root, OfflineGroup, TasksFile), "100\n")
+	assert.NoError(t, AddTasks(root, OfflineGroup, []string{"100", "101", "102"}))
+	content, err = os.ReadFile(filepath.Join(root, OfflineGroup, TasksFile))
+	assert.NoError(t, err)
+	assert.Equal(t, "100\n101\n102\n", string(content))
+
+	assert.NoError(t, os.Remove(filepath.Join(root, OfflineGroup, SchemataFile)))
+	assert.NoError(t, os.Remove(filepath.Join(root, OfflineGroup, TasksFile)))
+	assert.NoError(t, RemoveGroup(root, OfflineGroup))
+	assert.NoDirExists(t, filepath.Join(root, OfflineGroup))
+	// removing a removed group is fine.
+	assert.NoError(t, RemoveGroup(root, OfflineGroup))
+}
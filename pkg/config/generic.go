@@ -72,6 +72,9 @@ type VolcanoAgentConfiguration struct {
 	// OverSubscriptionRatio is the over subscription ratio of idle resources, default to 60, which means 60%.
 	OverSubscriptionRatio int
 
+	// EvictionRankPolicy defines the policy ranking the offline pods to be evicted.
+	EvictionRankPolicy string
+
 	// IncludeSystemUsage determines whether considering system usage when calculate overSubscription resource and evict.
 	IncludeSystemUsage bool
 }
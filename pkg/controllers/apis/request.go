@@ -39,6 +39,9 @@ type Request struct {
 	ExitCode   int32
 	Action     v1alpha1.Action
 	JobVersion int32
+
+	// Checkpoint is the location of the checkpoint acknowledged by the evicted pod
+	Checkpoint string
 }
 
 // String function returns the request in string format.
@@ -31,3 +31,6 @@ const (
 	// is successfully deleted.
 	SuccessfulDeletePodReason = "SuccessfulDelete"
 )
+
+// CheckpointEnv is the env of the location of the last checkpoint of the job, which the restarted pods resume from.
+const CheckpointEnv = "VC_CHECKPOINT"
@@ -369,6 +369,8 @@ func (cc *jobcontroller) processNextReq(count uint32) bool {
 		return true
 	}
 
+	cc.recordCheckpoint(jobInfo, &req)
+
 	delayAct := applyPolicies(jobInfo.Job, &req)
 
 	if delayAct.delay != 0 {
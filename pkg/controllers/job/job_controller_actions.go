@@ -401,6 +401,20 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 
 	waitCreationGroup := sync.WaitGroup{}
 
+	// the missing pods of the elastic job shrunk by the scheduler are not created beyond its replicas,
+	// counting the pods which are neither being deleted nor completed
+	limit, elastic := elasticReplicas(pg)
+	var active int
+	if elastic {
+		for _, pods := range jobInfo.Pods {
+			for _, pod := range pods {
+				if pod.DeletionTimestamp == nil && pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+					active++
+				}
+			}
+		}
+	}
+
 	for _, ts := range job.Spec.Tasks {
 		ts.Template.Name = ts.Name
 		tc := ts.Template.DeepCopy()
@@ -415,6 +429,12 @@ func (cc *jobcontroller) syncJob(jobInfo *apis.JobInfo, updateStatus state.Updat
 		for i := 0; i < int(ts.Replicas); i++ {
 			podName := fmt.Sprintf(jobhelpers.PodNameFmt, job.Name, name, i)
 			if pod, found := pods[podName]; !found {
+				if elastic {
+					if active >= limit {
+						continue
+					}
+					active++
+				}
 				newPod := createJobPod(job, tc, ts.TopologyPolicy, i, jobForwarding)
 				if err := cc.pluginOnPodCreate(job, newPod); err != nil {
 					return err
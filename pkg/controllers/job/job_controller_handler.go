@@ -18,6 +18,7 @@ package job
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -37,6 +38,7 @@ import (
 	jobcache "volcano.sh/volcano/pkg/controllers/cache"
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/state"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 )
 
 func (cc *jobcontroller) addCommand(obj interface{}) {
@@ -378,6 +380,13 @@ func (cc *jobcontroller) deletePod(obj interface{}) {
 		Event:      bus.PodEvictedEvent,
 		JobVersion: int32(dVersion),
 	}
+	// the pods evicted to shrink elastic jobs only sync the job, instead of the policies of the evicted pods,
+	// e.g. restarting the job
+	if isShrunkByElastic(pod) {
+		req.Action = bus.SyncJobAction
+	}
+	// the checkpoint is recorded on the job by the worker, before the policies restart the pod
+	req.Checkpoint = pod.Annotations[schedulingapi.CheckpointAnnotation]
 
 	if err := cc.cache.DeletePod(pod); err != nil {
 		klog.Errorf("Failed to delete Pod <%s/%s>: %v in cache",
@@ -389,6 +398,39 @@ func (cc *jobcontroller) deletePod(obj interface{}) {
 	queue.Add(req)
 }
 
+// recordCheckpoint records the checkpoint acknowledged by the evicted pod of the request on its job,
+// so that the pods restarted later resume from it.
+func (cc *jobcontroller) recordCheckpoint(jobInfo *apis.JobInfo, req *apis.Request) {
+	location := req.Checkpoint
+	if location == "" || jobInfo.Job == nil {
+		return
+	}
+	if jobInfo.Job.Annotations[schedulingapi.CheckpointAnnotation] == location {
+		return
+	}
+	key := jobcache.JobKeyByReq(req)
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{schedulingapi.CheckpointAnnotation: location},
+		},
+	})
+	if err != nil {
+		klog.Errorf("Failed to build the checkpoint patch of job <%s>: %v", key, err)
+		return
+	}
+	job, err := cc.vcClient.BatchV1alpha1().Jobs(jobInfo.Job.Namespace).Patch(context.TODO(), jobInfo.Job.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		klog.Errorf("Failed to record checkpoint %q of Pod <%s/%s> on job <%s>: %v", location, req.Namespace, req.PodName, key, err)
+		return
+	}
+	klog.V(3).Infof("Recorded checkpoint %q of Pod <%s/%s> on job <%s>", location, req.Namespace, req.PodName, key)
+	// update the cache now, the pods may be restarted before the update of the job is watched
+	if err := cc.cache.Update(job); err != nil {
+		klog.V(4).Infof("Failed to update job <%s> in cache: %v", key, err)
+	}
+}
+
 func (cc *jobcontroller) recordJobEvent(namespace, name string, event batch.JobEvent, message string) {
 	job, err := cc.cache.Get(jobcache.JobKeyByName(namespace, name))
 	if err != nil {
@@ -464,7 +506,9 @@ func (cc *jobcontroller) updatePodGroup(oldObj, newObj interface{}) {
 			"Failed to find job in cache by PodGroup(%s/%s), this may not be a PodGroup for volcano job.", newPG.Namespace, newPG.Name)
 	}
 
-	if newPG.Status.Phase != oldPG.Status.Phase {
+	// the pods of elastic jobs are created or not by the replicas the scheduler shrinks and grows them to
+	elasticChanged := newPG.Annotations[schedulingapi.ElasticReplicasAnnotation] != oldPG.Annotations[schedulingapi.ElasticReplicasAnnotation]
+	if newPG.Status.Phase != oldPG.Status.Phase || elasticChanged {
 		req := apis.Request{
 			Namespace: newPG.Namespace,
 			JobName:   jobNameKey,
@@ -17,6 +17,7 @@ limitations under the License.
 package job
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -33,8 +34,11 @@ import (
 	"volcano.sh/apis/pkg/apis/helpers"
 	scheduling "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	vcfake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
 	informerfactory "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/controllers/apis"
 	"volcano.sh/volcano/pkg/controllers/framework"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 )
 
 func newController() *jobcontroller {
@@ -518,6 +522,101 @@ func TestDeletePodFunc(t *testing.T) {
 	}
 }
 
+func TestDeletePodRecordCheckpoint(t *testing.T) {
+	namespace := "test"
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "job1",
+			Namespace:       namespace,
+			ResourceVersion: "1",
+		},
+	}
+	annotations := map[string]string{
+		batch.JobNameKey:  "job1",
+		batch.JobVersion:  "0",
+		batch.TaskSpecKey: "task1",
+	}
+
+	controller := newController()
+	controller.vcClient = vcfake.NewSimpleClientset(job)
+	controller.addJob(job)
+	controller.addPod(addPodAnnotation(buildPod(namespace, "pod1", v1.PodRunning, nil), annotations))
+
+	evicted := addPodAnnotation(buildPod(namespace, "pod1", v1.PodRunning, nil), annotations)
+	evicted.Annotations[schedulingapi.CheckpointAnnotation] = "s3://ckpt/1"
+	controller.deletePod(evicted)
+
+	// the checkpoint is carried by the request, and recorded on the job by the worker
+	key := fmt.Sprintf("%s/%s", namespace, "job1")
+	queue := controller.getWorkerQueue(key)
+	var req *apis.Request
+	for queue.Len() > 0 {
+		item, _ := queue.Get()
+		if r := item.(apis.Request); r.Event == bus.PodEvictedEvent {
+			req = &r
+		}
+	}
+	if req == nil || req.Checkpoint != "s3://ckpt/1" {
+		t.Fatalf("expected the %s request with checkpoint s3://ckpt/1 queued, got %v", bus.PodEvictedEvent, req)
+	}
+
+	jobInfo, err := controller.cache.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get job from cache: %v", err)
+	}
+	controller.recordCheckpoint(jobInfo, req)
+	updated, err := controller.vcClient.BatchV1alpha1().Jobs(namespace).Get(context.TODO(), "job1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get job: %v", err)
+	}
+	if got := updated.Annotations[schedulingapi.CheckpointAnnotation]; got != "s3://ckpt/1" {
+		t.Errorf("expected checkpoint s3://ckpt/1 recorded on job, got %q", got)
+	}
+}
+
+func TestDeletePodShrunkByElastic(t *testing.T) {
+	namespace := "test"
+	job := &batch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job1",
+			Namespace: namespace,
+		},
+	}
+	annotations := map[string]string{
+		batch.JobNameKey:  "job1",
+		batch.JobVersion:  "0",
+		batch.TaskSpecKey: "task1",
+	}
+
+	controller := newController()
+	controller.addJob(job)
+	controller.addPod(addPodAnnotation(buildPod(namespace, "pod1", v1.PodRunning, nil), annotations))
+
+	evicted := addPodAnnotation(buildPod(namespace, "pod1", v1.PodRunning, nil), annotations)
+	evicted.Status.Conditions = []v1.PodCondition{{
+		Type:    v1.PodReady,
+		Status:  v1.ConditionFalse,
+		Reason:  "Evict",
+		Message: "Pod is evicted, because of " + schedulingapi.ElasticShrinkReason,
+	}}
+	controller.deletePod(evicted)
+
+	// the requests of adding the job and the pod are queued before
+	queue := controller.getWorkerQueue(fmt.Sprintf("%s/%s", namespace, "job1"))
+	for queue.Len() > 0 {
+		item, _ := queue.Get()
+		req := item.(apis.Request)
+		if req.Event != bus.PodEvictedEvent {
+			continue
+		}
+		if req.Action != bus.SyncJobAction {
+			t.Errorf("expected %s action, got %s", bus.SyncJobAction, req.Action)
+		}
+		return
+	}
+	t.Errorf("expected the %s request queued", bus.PodEvictedEvent)
+}
+
 func TestUpdatePodGroupFunc(t *testing.T) {
 
 	namespace := "test"
@@ -556,6 +655,51 @@ func TestUpdatePodGroupFunc(t *testing.T) {
 			},
 			ExpectValue: 1,
 		},
+		{
+			Name: "elastic replicas changed",
+			oldPodGroup: &scheduling.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pg1",
+					Namespace: namespace,
+				},
+				Status: scheduling.PodGroupStatus{
+					Phase: scheduling.PodGroupRunning,
+				},
+			},
+			newPodGroup: &scheduling.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pg1",
+					Namespace:   namespace,
+					Annotations: map[string]string{schedulingapi.ElasticReplicasAnnotation: "2"},
+				},
+				Status: scheduling.PodGroupStatus{
+					Phase: scheduling.PodGroupRunning,
+				},
+			},
+			ExpectValue: 1,
+		},
+		{
+			Name: "nothing changed",
+			oldPodGroup: &scheduling.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pg1",
+					Namespace: namespace,
+				},
+				Status: scheduling.PodGroupStatus{
+					Phase: scheduling.PodGroupRunning,
+				},
+			},
+			newPodGroup: &scheduling.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pg1",
+					Namespace: namespace,
+				},
+				Status: scheduling.PodGroupStatus{
+					Phase: scheduling.PodGroupRunning,
+				},
+			},
+			ExpectValue: 0,
+		},
 	}
 
 	for i, testcase := range testCases {
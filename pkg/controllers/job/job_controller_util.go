@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +37,7 @@ import (
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/state"
 	"volcano.sh/volcano/pkg/controllers/util"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 )
 
 // MakePodName append podname,jobname,taskName and index and returns the string.
@@ -138,6 +140,23 @@ func createJobPod(job *batch.Job, template *v1.PodTemplateSpec, topologyPolicy b
 		} else if value, found := job.Annotations[schedulingv2.JDBMaxUnavailable]; found {
 			pod.Annotations[schedulingv2.JDBMaxUnavailable] = value
 		}
+
+		if value, found := job.Annotations[schedulingapi.CheckpointHookAnnotation]; found {
+			pod.Annotations[schedulingapi.CheckpointHookAnnotation] = value
+		}
+		if value, found := job.Annotations[schedulingapi.CheckpointGracePeriodAnnotation]; found {
+			pod.Annotations[schedulingapi.CheckpointGracePeriodAnnotation] = value
+		}
+		// the restarted pods resume from the last checkpoint of the job
+		if value, found := job.Annotations[schedulingapi.CheckpointAnnotation]; found && value != "" {
+			checkpoint := v1.EnvVar{Name: CheckpointEnv, Value: value}
+			for i := range pod.Spec.Containers {
+				pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, checkpoint)
+			}
+			for i := range pod.Spec.InitContainers {
+				pod.Spec.InitContainers[i].Env = append(pod.Spec.InitContainers[i].Env, checkpoint)
+			}
+		}
 	}
 
 	if len(pod.Labels) == 0 {
@@ -320,6 +339,35 @@ func isControlledBy(obj metav1.Object, gvk schema.GroupVersionKind) bool {
 	return false
 }
 
+// isShrunkByElastic returns whether the pod was evicted by the elastic action of the scheduler to shrink its job.
+// The scheduler records the eviction in the Ready condition of the pod, with the reason "Evict" and a message
+// ending with the elastic shrink reason; such pods only sync the job instead of triggering its PodEvicted policies.
+func isShrunkByElastic(pod *v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady && c.Reason == "Evict" && strings.HasSuffix(c.Message, schedulingapi.ElasticShrinkReason) {
+			return true
+		}
+	}
+	return false
+}
+
+// elasticReplicas returns the number of pods the scheduler allows the elastic job to run, if it shrunk the job.
+func elasticReplicas(pg *schedulingv2.PodGroup) (int, bool) {
+	if pg == nil {
+		return 0, false
+	}
+	value, found := pg.Annotations[schedulingapi.ElasticReplicasAnnotation]
+	if !found {
+		return 0, false
+	}
+	replicas, err := strconv.Atoi(value)
+	if err != nil || replicas < 0 {
+		klog.Warningf("Invalid %s %q of PodGroup <%s/%s>", schedulingapi.ElasticReplicasAnnotation, value, pg.Namespace, pg.Name)
+		return 0, false
+	}
+	return replicas, true
+}
+
 // CalcFirstCountResources return the first count tasks resource, sorted by priority
 func (p TasksPriority) CalcFirstCountResources(count int32) v1.ResourceList {
 	sort.Sort(p)
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,7 @@ import (
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
 	busv1alpha1 "volcano.sh/apis/pkg/apis/bus/v1alpha1"
 	"volcano.sh/volcano/pkg/controllers/apis"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
 )
 
 func TestMakePodName(t *testing.T) {
@@ -251,6 +253,36 @@ func TestCreateJobPod(t *testing.T) {
 	}
 }
 
+func TestCreateJobPodWithCheckpoint(t *testing.T) {
+	job := &v1alpha1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "job1",
+			Namespace: "test",
+			Annotations: map[string]string{
+				schedulingapi.CheckpointHookAnnotation:        "8080/checkpoint",
+				schedulingapi.CheckpointGracePeriodAnnotation: "60s",
+				schedulingapi.CheckpointAnnotation:            "s3://ckpt/1",
+			},
+		},
+	}
+	template := &v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "task1"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init"}},
+			Containers:     []v1.Container{{Name: "worker"}},
+		},
+	}
+
+	pod := createJobPod(job, template, "", 0, false)
+	assert.Equal(t, "8080/checkpoint", pod.Annotations[schedulingapi.CheckpointHookAnnotation])
+	assert.Equal(t, "60s", pod.Annotations[schedulingapi.CheckpointGracePeriodAnnotation])
+	// the checkpoint is only passed to the containers, it is not acknowledged by the new pod
+	assert.NotContains(t, pod.Annotations, schedulingapi.CheckpointAnnotation)
+	checkpoint := v1.EnvVar{Name: CheckpointEnv, Value: "s3://ckpt/1"}
+	assert.Contains(t, pod.Spec.Containers[0].Env, checkpoint)
+	assert.Contains(t, pod.Spec.InitContainers[0].Env, checkpoint)
+}
+
 func TestApplyPolicies(t *testing.T) {
 	namespace := "test"
 	errorCode0 := int32(0)
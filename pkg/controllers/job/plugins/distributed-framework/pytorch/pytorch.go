@@ -31,6 +31,20 @@ const (
 	EnvWorldSize = "WORLD_SIZE"
 	// EnvRank is the env name of rank
 	EnvRank = "RANK"
+
+	// DefaultMaxRestarts is the default number of restarts of the workers of elastic jobs
+	DefaultMaxRestarts = 10
+	// EnvElasticNNodes is the env name of the range of nodes of elastic jobs, e.g. "1:4"
+	EnvElasticNNodes = "PET_NNODES"
+	// EnvElasticRdzvBackend is the env name of the rendezvous backend of elastic jobs
+	EnvElasticRdzvBackend = "PET_RDZV_BACKEND"
+	// EnvElasticRdzvEndpoint is the env name of the rendezvous endpoint of elastic jobs
+	EnvElasticRdzvEndpoint = "PET_RDZV_ENDPOINT"
+	// EnvElasticRdzvID is the env name of the rendezvous id of elastic jobs
+	EnvElasticRdzvID = "PET_RDZV_ID"
+	// EnvElasticMaxRestarts is the env name of the max restarts of the workers of elastic jobs, the workers are
+	// restarted when the nodes join or leave the rendezvous
+	EnvElasticMaxRestarts = "PET_MAX_RESTARTS"
 )
 
 type pytorchPlugin struct {
@@ -39,6 +53,8 @@ type pytorchPlugin struct {
 	masterName       string
 	workerName       string
 	port             int
+	elastic          bool
+	maxRestarts      int
 }
 
 // New creates pytorch plugin.
@@ -53,6 +69,8 @@ func (pp *pytorchPlugin) addFlags() {
 	flagSet.StringVar(&pp.masterName, "master", DefaultMaster, "name of master role task")
 	flagSet.StringVar(&pp.workerName, "worker", DefaultWorker, "name of worker role task")
 	flagSet.IntVar(&pp.port, "port", DefaultPort, "open port for containers")
+	flagSet.BoolVar(&pp.elastic, "elastic", false, "set the torchrun environment of elastic jobs")
+	flagSet.IntVar(&pp.maxRestarts, "max-restarts", DefaultMaxRestarts, "max restarts of the workers of elastic jobs")
 	if err := flagSet.Parse(pp.pytorchArguments); err != nil {
 		klog.Errorf("plugin %s flagset parse failed, err: %v", pp.Name(), err)
 	}
@@ -112,11 +130,48 @@ func (pp *pytorchPlugin) OnPodCreate(pod *v1.Pod, job *batch.Job) error {
 				Value: strconv.Itoa(masterRank),
 			})
 		}
+
+		if pp.isElastic(job, totalReplicas) {
+			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, pp.elasticEnvVars(job, masterAddr, totalReplicas)...)
+		}
 	}
 
 	return nil
 }
 
+// isElastic returns whether the job is declared elastic by the --elastic argument of the plugin and may run with
+// less replicas than it has, the scheduler shrinks and grows it between its minAvailable and replicas then.
+func (pp *pytorchPlugin) isElastic(job *batch.Job, totalReplicas int32) bool {
+	return pp.elastic && job.Spec.MinAvailable > 0 && job.Spec.MinAvailable < totalReplicas
+}
+
+// elasticEnvVars returns the envs of torchrun for the c10d rendezvous hosted on the master, so the workers
+// re-rendezvous with the new world size when the job is shrunk or grown instead of failing.
+func (pp *pytorchPlugin) elasticEnvVars(job *batch.Job, masterAddr string, totalReplicas int32) []v1.EnvVar {
+	return []v1.EnvVar{
+		{
+			Name:  EnvElasticNNodes,
+			Value: fmt.Sprintf("%d:%d", job.Spec.MinAvailable, totalReplicas),
+		},
+		{
+			Name:  EnvElasticRdzvBackend,
+			Value: "c10d",
+		},
+		{
+			Name:  EnvElasticRdzvEndpoint,
+			Value: fmt.Sprintf("%s:%d", masterAddr, pp.port),
+		},
+		{
+			Name:  EnvElasticRdzvID,
+			Value: job.Name,
+		},
+		{
+			Name:  EnvElasticMaxRestarts,
+			Value: strconv.Itoa(pp.maxRestarts),
+		},
+	}
+}
+
 func (pp *pytorchPlugin) getTotalReplicas(job *batch.Job) int32 {
 	jobReplicas := int32(0)
 	for _, task := range job.Spec.Tasks {
@@ -361,6 +361,139 @@ func TestPytorch(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "test elastic worker pod env",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pytorch"},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 2,
+					Plugins: map[string][]string{
+						PytorchPluginName: {"--elastic"},
+					},
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "master",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{},
+						},
+						{
+							Name:     "worker",
+							Replicas: 3,
+							Template: v1.PodTemplateSpec{},
+						},
+					},
+				},
+			},
+			Pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-pytorch-worker-0",
+					Annotations: map[string]string{
+						v1alpha1.TaskSpecKey: "worker",
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "worker",
+						},
+					},
+				},
+			},
+			port: DefaultPort,
+			envs: []v1.EnvVar{
+				{
+					Name:  EnvMasterAddr,
+					Value: "test-pytorch-master-0.test-pytorch",
+				},
+				{
+					Name:  EnvMasterPort,
+					Value: fmt.Sprintf("%v", DefaultPort),
+				},
+				{
+					Name:  "WORLD_SIZE",
+					Value: fmt.Sprintf("%v", 4),
+				},
+				{
+					Name:  "RANK",
+					Value: fmt.Sprintf("%v", 1),
+				},
+				{
+					Name:  EnvElasticNNodes,
+					Value: "2:4",
+				},
+				{
+					Name:  EnvElasticRdzvBackend,
+					Value: "c10d",
+				},
+				{
+					Name:  EnvElasticRdzvEndpoint,
+					Value: fmt.Sprintf("test-pytorch-master-0.test-pytorch:%v", DefaultPort),
+				},
+				{
+					Name:  EnvElasticRdzvID,
+					Value: "test-pytorch",
+				},
+				{
+					Name:  EnvElasticMaxRestarts,
+					Value: fmt.Sprintf("%v", DefaultMaxRestarts),
+				},
+			},
+		},
+		{
+			Name: "test worker pod env of job not declared elastic",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pytorch"},
+				Spec: v1alpha1.JobSpec{
+					MinAvailable: 2,
+					Tasks: []v1alpha1.TaskSpec{
+						{
+							Name:     "master",
+							Replicas: 1,
+							Template: v1.PodTemplateSpec{},
+						},
+						{
+							Name:     "worker",
+							Replicas: 3,
+							Template: v1.PodTemplateSpec{},
+						},
+					},
+				},
+			},
+			Pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-pytorch-worker-0",
+					Annotations: map[string]string{
+						v1alpha1.TaskSpecKey: "worker",
+					},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "worker",
+						},
+					},
+				},
+			},
+			port: DefaultPort,
+			envs: []v1.EnvVar{
+				{
+					Name:  EnvMasterAddr,
+					Value: "test-pytorch-master-0.test-pytorch",
+				},
+				{
+					Name:  EnvMasterPort,
+					Value: fmt.Sprintf("%v", DefaultPort),
+				},
+				{
+					Name:  "WORLD_SIZE",
+					Value: fmt.Sprintf("%v", 4),
+				},
+				{
+					Name:  "RANK",
+					Value: fmt.Sprintf("%v", 1),
+				},
+			},
+		},
 	}
 
 	for index, testcase := range testcases {
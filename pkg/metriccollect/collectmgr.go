@@ -56,3 +56,8 @@ func (cm *MetricCollectorManager) GetPluginByName(name string) (collector framew
 	}
 	return nil, fmt.Errorf("unsupported metric collector plugin %s", name)
 }
+
+// CgroupManager returns the cgroup manager the metrics are collected by.
+func (cm *MetricCollectorManager) CgroupManager() cgroup.CgroupManager {
+	return cm.cgroupManager
+}
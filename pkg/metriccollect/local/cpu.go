@@ -22,12 +22,14 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/prometheus/prometheus/prompb"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/agent/utils/cgroup"
@@ -44,23 +46,39 @@ const (
 
 type CPUResourceCollector struct {
 	cgroupManager cgroup.CgroupManager
+
+	lock sync.Mutex
+	// podSamples is the last cpu usage read of the pods.
+	podSamples map[types.UID]cpuSample
+}
+
+// cpuSample is the cpu usage of a cgroup in nanoseconds, read at a time.
+type cpuSample struct {
+	usage int64
+	time  time.Time
 }
 
 func NewCPUResourceCollector(cgroupManager cgroup.CgroupManager) (SubCollector, error) {
 	return &CPUResourceCollector{
 		cgroupManager: cgroupManager,
+		podSamples:    make(map[types.UID]cpuSample),
 	}, nil
 }
 
 func (c *CPUResourceCollector) Run() {}
 
 func (c *CPUResourceCollector) CollectLocalMetrics(metricInfo *LocalMetricInfo, start time.Time, window metav1.Duration) ([]*prompb.TimeSeries, error) {
+	if len(metricInfo.Pods) > 0 {
+		return c.collectPodMetrics(metricInfo.Pods)
+	}
+
 	cgroupPath, err := c.cgroupManager.GetRootCgroupPath(cgroup.CgroupCpuSubsystem)
 	if err != nil {
 		return nil, err
 	}
 
-	podAllUsage, err := getMilliCPUUsage(cgroupPath)
+	version := c.cgroupManager.GetCgroupVersion()
+	podAllUsage, err := getMilliCPUUsage(cgroupPath, version)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +91,7 @@ func (c *CPUResourceCollector) CollectLocalMetrics(metricInfo *LocalMetricInfo,
 				return nil, err
 			}
 
-			count, err := getMilliCPUUsage(cgroupPath)
+			count, err := getMilliCPUUsage(cgroupPath, version)
 			if err != nil {
 				return nil, err
 			}
@@ -102,16 +120,57 @@ func (c *CPUResourceCollector) CollectLocalMetrics(metricInfo *LocalMetricInfo,
 	return []*prompb.TimeSeries{&sample}, nil
 }
 
-func getMilliCPUUsage(cgroupRoot string) (int64, error) {
+// collectPodMetrics returns the milli cpu usage of every pod since its last collection, or since the pod started
+// for its first one, so that the pods are ranked without waiting for a new sample. The pods whose cgroup can not be
+// read are skipped.
+func (c *CPUResourceCollector) collectPodMetrics(pods []*corev1.Pod) ([]*prompb.TimeSeries, error) {
+	version := c.cgroupManager.GetCgroupVersion()
+	now := time.Now()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	samples := make(map[types.UID]cpuSample, len(pods))
+	var series []*prompb.TimeSeries
+	for _, pod := range pods {
+		cgroupPath, err := podCgroupPath(c.cgroupManager, pod, cgroup.CgroupCpuSubsystem)
+		if err != nil {
+			klog.V(4).InfoS("Failed to get pod cpu cgroup path", "pod", klog.KObj(pod), "err", err)
+			continue
+		}
+		usage, err := readCPUUsage(cgroupPath, version)
+		if err != nil {
+			klog.V(4).InfoS("Failed to read pod cpu usage", "pod", klog.KObj(pod), "err", err)
+			continue
+		}
+		samples[pod.UID] = cpuSample{usage: usage, time: now}
+
+		last, found := c.podSamples[pod.UID]
+		if !found {
+			if pod.Status.StartTime == nil {
+				continue
+			}
+			last = cpuSample{time: pod.Status.StartTime.Time}
+		}
+		elapsed := now.Sub(last.time)
+		if elapsed <= 0 || usage < last.usage {
+			continue
+		}
+		series = append(series, podTimeSeries(pod, float64(usage-last.usage)*1000/float64(elapsed)))
+	}
+	// the pods which are no longer collected are forgotten
+	c.podSamples = samples
+	return series, nil
+}
+
+func getMilliCPUUsage(cgroupRoot string, version cgroup.CgroupVersion) (int64, error) {
 	startTime := time.Now().UnixNano()
-	cgroupsCPU := filepath.Join(cgroupRoot, cgroup.CPUUsageFile)
-	startUsage, err := file.ReadIntFromFile(cgroupsCPU)
+	startUsage, err := readCPUUsage(cgroupRoot, version)
 	if err != nil {
 		return 0, err
 	}
 	time.Sleep(1 * time.Second)
 	endTime := time.Now().UnixNano()
-	endUsage, err := file.ReadIntFromFile(cgroupsCPU)
+	endUsage, err := readCPUUsage(cgroupRoot, version)
 	if err != nil {
 		return 0, err
 	}
@@ -121,6 +180,31 @@ func getMilliCPUUsage(cgroupRoot string) (int64, error) {
 	return (endUsage - startUsage) * 1000 / (endTime - startTime), nil
 }
 
+// readCPUUsage returns the cpu usage of the cgroup in nanoseconds, from cpuacct.usage on cgroup v1 and from
+// usage_usec of cpu.stat on cgroup v2.
+func readCPUUsage(cgroupRoot string, version cgroup.CgroupVersion) (int64, error) {
+	if version != cgroup.CgroupV2 {
+		return file.ReadIntFromFile(filepath.Join(cgroupRoot, cgroup.CPUUsageFile))
+	}
+	cpuStatFile := filepath.Join(cgroupRoot, cgroup.CPUStatFile)
+	contents, err := os.ReadFile(cpuStatFile)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(contents), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != cgroup.CPUUsageUsecKey {
+			continue
+		}
+		usage, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse cpu usage %s: %v", fields[1], err)
+		}
+		return usage * int64(time.Microsecond), nil
+	}
+	return 0, fmt.Errorf("no %s in %s", cgroup.CPUUsageUsecKey, cpuStatFile)
+}
+
 func nodeCPUState() (uint64, error) {
 	procStatFile := os.Getenv(procStatPathEnv)
 	if procStatFile == "" {
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+)
+
+// fakeCgroupManager puts the cgroups of the pods under a root, the pods without a cgroup have no path.
+type fakeCgroupManager struct {
+	cgroup.CgroupManager
+	root string
+	pods map[types.UID]bool
+}
+
+func (m *fakeCgroupManager) GetPodCgroupPath(qos corev1.PodQOSClass, subsystem cgroup.CgroupSubsystem, podUID types.UID) (string, error) {
+	if !m.pods[podUID] {
+		return "", fmt.Errorf("no cgroup of pod %s", podUID)
+	}
+	return filepath.Join(m.root, string(podUID)), nil
+}
+
+func (m *fakeCgroupManager) GetCgroupVersion() cgroup.CgroupVersion {
+	return cgroup.CgroupV1
+}
+
+func TestCollectPodCPUMetrics(t *testing.T) {
+	root := t.TempDir()
+	writeUsage := func(uid types.UID, usage time.Duration) {
+		assert.NoError(t, os.MkdirAll(filepath.Join(root, string(uid)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(root, string(uid), cgroup.CPUUsageFile), []byte(fmt.Sprint(int64(usage))), 0644))
+	}
+	buildPod := func(uid types.UID, started time.Duration) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: string(uid), UID: uid}}
+		if started != 0 {
+			pod.Status.StartTime = &metav1.Time{Time: time.Now().Add(-started)}
+		}
+		return pod
+	}
+	writeUsage("running", 50*time.Second)
+	writeUsage("not-started", time.Second)
+
+	collector, err := NewCPUResourceCollector(&fakeCgroupManager{
+		root: root,
+		pods: map[types.UID]bool{"running": true, "not-started": true},
+	})
+	assert.NoError(t, err)
+	pods := []*corev1.Pod{buildPod("running", 100*time.Second), buildPod("not-started", 0), buildPod("no-cgroup", time.Minute)}
+
+	start := time.Now()
+	series, err := collector.CollectLocalMetrics(&LocalMetricInfo{Pods: pods}, time.Now(), metav1.Duration{})
+	assert.NoError(t, err, "the pods without cgroup should be skipped")
+	assert.Less(t, time.Since(start), time.Second, "the pods should not be sampled twice")
+	assert.Len(t, series, 1, "the first usage of a pod should be its average since it started")
+	assert.Equal(t, "running", series[0].Labels[0].Value)
+	assert.InDelta(t, 500, series[0].Samples[0].Value, 5)
+
+	writeUsage("running", 60*time.Second)
+	writeUsage("not-started", 2*time.Second)
+	series, err = collector.CollectLocalMetrics(&LocalMetricInfo{Pods: pods}, time.Now(), metav1.Duration{})
+	assert.NoError(t, err)
+	assert.Len(t, series, 2, "the usage should be the average since the last collection")
+	for _, s := range series {
+		assert.Greater(t, s.Samples[0].Value, float64(1000), "pod %s", s.Labels[0].Value)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/model/timestamp"
+	"github.com/prometheus/prometheus/prompb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+)
+
+const (
+	// IOMetricLabel is the label telling the io metrics apart.
+	IOMetricLabel = "metric"
+	// IOBytesMetric is the bytes read and written per second.
+	IOBytesMetric = "bytes"
+	// IOOpsMetric is the io operations read and written per second.
+	IOOpsMetric = "ios"
+)
+
+type ioStat struct {
+	bytes int64
+	ios   int64
+}
+
+type IOResourceCollector struct {
+	cgroupManager cgroup.CgroupManager
+}
+
+func NewIOResourceCollector(cgroupManager cgroup.CgroupManager) (SubCollector, error) {
+	return &IOResourceCollector{
+		cgroupManager: cgroupManager,
+	}, nil
+}
+
+func (c *IOResourceCollector) Run() {}
+
+// CollectLocalMetrics returns the io throughput and iops of all pods on all block devices.
+func (c *IOResourceCollector) CollectLocalMetrics(metricInfo *LocalMetricInfo, start time.Time, window metav1.Duration) ([]*prompb.TimeSeries, error) {
+	cgroupPath, err := c.cgroupManager.GetRootCgroupPath(cgroup.CgroupBlkioSubsystem)
+	if err != nil {
+		return nil, err
+	}
+
+	version := c.cgroupManager.GetCgroupVersion()
+	startTime := time.Now().UnixNano()
+	startStat, err := readIOStat(cgroupPath, version)
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(1 * time.Second)
+	endTime := time.Now().UnixNano()
+	endStat, err := readIOStat(cgroupPath, version)
+	if err != nil {
+		return nil, err
+	}
+	if endTime-startTime == 0 {
+		return nil, fmt.Errorf("statistic time is zero")
+	}
+
+	now := timestamp.FromTime(time.Now())
+	elapsed := float64(endTime-startTime) / float64(time.Second)
+	var series []*prompb.TimeSeries
+	for _, metric := range []struct {
+		name  string
+		value int64
+	}{
+		{name: IOBytesMetric, value: endStat.bytes - startStat.bytes},
+		{name: IOOpsMetric, value: endStat.ios - startStat.ios},
+	} {
+		series = append(series, &prompb.TimeSeries{
+			Labels:  []prompb.Label{{Name: IOMetricLabel, Value: metric.name}},
+			Samples: []prompb.Sample{{Timestamp: now, Value: float64(metric.value) / elapsed}},
+		})
+	}
+	return series, nil
+}
+
+// readIOStat returns the bytes and io operations read and written by the cgroup on all devices, from the recursive
+// blkio throttle stats on cgroup v1 and from io.stat on cgroup v2.
+func readIOStat(cgroupRoot string, version cgroup.CgroupVersion) (ioStat, error) {
+	if version == cgroup.CgroupV2 {
+		return readIOStatV2(filepath.Join(cgroupRoot, cgroup.IOStatFile))
+	}
+
+	var stat ioStat
+	for file, value := range map[string]*int64{
+		cgroup.BlkioThrottleServiceBytesFile: &stat.bytes,
+		cgroup.BlkioThrottleServicedFile:     &stat.ios,
+	} {
+		content, err := os.ReadFile(filepath.Join(cgroupRoot, file))
+		if err != nil {
+			return stat, err
+		}
+		// e.g. "8:0 Read 4096", the Total lines and the Sync/Async lines duplicate the Read/Write ones.
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 3 || (fields[1] != "Read" && fields[1] != "Write") {
+				continue
+			}
+			count, err := strconv.ParseInt(fields[2], 10, 64)
+			if err != nil {
+				continue
+			}
+			*value += count
+		}
+	}
+	return stat, nil
+}
+
+// readIOStatV2 reads io.stat, e.g. "8:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0".
+func readIOStatV2(ioStatFile string) (ioStat, error) {
+	var stat ioStat
+	content, err := os.ReadFile(ioStatFile)
+	if err != nil {
+		return stat, err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				continue
+			}
+			count, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes", "wbytes":
+				stat.bytes += count
+			case "rios", "wios":
+				stat.ios += count
+			}
+		}
+	}
+	return stat, nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"volcano.sh/volcano/pkg/agent/utils/cgroup"
+)
+
+func TestReadIOStat(t *testing.T) {
+	v1Root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(v1Root, cgroup.BlkioThrottleServiceBytesFile), []byte(
+		"8:0 Read 4096\n8:0 Write 8192\n8:0 Sync 12288\n8:0 Async 0\n8:0 Total 12288\n8:16 Read 1024\nTotal 13312\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(v1Root, cgroup.BlkioThrottleServicedFile), []byte(
+		"8:0 Read 1\n8:0 Write 2\n8:0 Total 3\n8:16 Read 1\nTotal 4\n"), 0644))
+	stat, err := readIOStat(v1Root, cgroup.CgroupV1)
+	assert.NoError(t, err)
+	assert.Equal(t, ioStat{bytes: 13312, ios: 4}, stat)
+
+	v2Root := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(v2Root, cgroup.IOStatFile), []byte(
+		"8:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n8:16 rbytes=1024 wbytes=0 rios=1 wios=0 dbytes=0 dios=0\n"), 0644))
+	stat, err = readIOStat(v2Root, cgroup.CgroupV2)
+	assert.NoError(t, err)
+	assert.Equal(t, ioStat{bytes: 13312, ios: 4}, stat)
+
+	_, err = readIOStat(t.TempDir(), cgroup.CgroupV2)
+	assert.Error(t, err)
+}
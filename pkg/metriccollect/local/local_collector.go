@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/prometheus/prometheus/prompb"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
@@ -31,10 +33,17 @@ import (
 
 const CollectorName = "LocalCollector"
 
+// PodUIDLabel is the label of the uid of the pod whose usage is collected.
+const PodUIDLabel = "pod_uid"
+
 type LocalMetricInfo struct {
 	ResourceType          string
 	IncludeGuaranteedPods bool
 	IncludeSystemUsed     bool
+	// Pods are the pods whose usage is collected one by one, a time series labeled with PodUIDLabel is returned for
+	// each of them, the pods whose cgroup can not be found or read are skipped. The cpu usage of a pod is its average
+	// since its last collection, or since it started.
+	Pods []*corev1.Pod
 }
 
 type SubCollector interface {
@@ -48,6 +57,7 @@ func getSubLocalCollectors() map[string]func(cgroupManager cgroup.CgroupManager)
 	initiatedCollectorFuncs := make(map[string]func(cgroupManager cgroup.CgroupManager) (SubCollector, error))
 	initiatedCollectorFuncs["cpu"] = NewCPUResourceCollector
 	initiatedCollectorFuncs["memory"] = NewMemoryResourceCollector
+	initiatedCollectorFuncs["io"] = NewIOResourceCollector
 	return initiatedCollectorFuncs
 }
 
@@ -99,3 +109,20 @@ func (c *LocalCollector) CollectMetrics(metricInfo interface{}, start time.Time,
 
 	return subCollector.CollectLocalMetrics(metric, start, window)
 }
+
+// podCgroupPath returns the cgroup path of the pod in the subsystem.
+func podCgroupPath(cgroupManager cgroup.CgroupManager, pod *corev1.Pod, subsystem cgroup.CgroupSubsystem) (string, error) {
+	return cgroupManager.GetPodCgroupPath(pod.Status.QOSClass, subsystem, pod.UID)
+}
+
+func podTimeSeries(pod *corev1.Pod, value float64) *prompb.TimeSeries {
+	return &prompb.TimeSeries{
+		Labels: []prompb.Label{{Name: PodUIDLabel, Value: string(pod.UID)}},
+		Samples: []prompb.Sample{
+			{
+				Timestamp: timestamp.FromTime(time.Now()),
+				Value:     value,
+			},
+		},
+	}
+}
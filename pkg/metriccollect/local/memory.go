@@ -26,6 +26,7 @@ import (
 
 	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/prometheus/prometheus/prompb"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
@@ -38,6 +39,13 @@ var memoryStatMetrics = map[string]bool{
 	"total_swap":  true,
 }
 
+// memoryStatMetricsV2 are the keys of memory.stat of cgroup v2 which sum up to the usage, the same as those of v1
+// without the swap, which is not in memory.stat on cgroup v2.
+var memoryStatMetricsV2 = map[string]bool{
+	"anon": true,
+	"file": true,
+}
+
 const (
 	defaultMemInfoPath = "/host/proc/meminfo"
 	memInfoPathEnv     = "MEM_INFO_PATH_ENV"
@@ -56,6 +64,10 @@ func NewMemoryResourceCollector(cgroupManager cgroup.CgroupManager) (SubCollecto
 func (c *MemoryResourceCollector) Run() {}
 
 func (c *MemoryResourceCollector) CollectLocalMetrics(metricInfo *LocalMetricInfo, start time.Time, window metav1.Duration) ([]*prompb.TimeSeries, error) {
+	if len(metricInfo.Pods) > 0 {
+		return c.collectPodMetrics(metricInfo.Pods)
+	}
+
 	var (
 		count int64
 		err   error
@@ -65,7 +77,7 @@ func (c *MemoryResourceCollector) CollectLocalMetrics(metricInfo *LocalMetricInf
 		return nil, err
 	}
 
-	count, err = getMemoryUsage(cgroupPath)
+	count, err = getMemoryUsage(cgroupPath, c.cgroupManager.GetCgroupVersion())
 	if err != nil {
 		return nil, err
 	}
@@ -87,8 +99,31 @@ func (c *MemoryResourceCollector) CollectLocalMetrics(metricInfo *LocalMetricInf
 	return []*prompb.TimeSeries{&sample}, nil
 }
 
-func getMemoryUsage(cgroupRoot string) (int64, error) {
+// collectPodMetrics returns the memory usage of every pod.
+func (c *MemoryResourceCollector) collectPodMetrics(pods []*corev1.Pod) ([]*prompb.TimeSeries, error) {
+	var series []*prompb.TimeSeries
+	for _, pod := range pods {
+		cgroupPath, err := podCgroupPath(c.cgroupManager, pod, cgroup.CgroupMemorySubsystem)
+		if err != nil {
+			klog.V(4).InfoS("Failed to get pod memory cgroup path", "pod", klog.KObj(pod), "err", err)
+			continue
+		}
+		usage, err := getMemoryUsage(cgroupPath, c.cgroupManager.GetCgroupVersion())
+		if err != nil {
+			klog.V(4).InfoS("Failed to read pod memory usage", "pod", klog.KObj(pod), "err", err)
+			continue
+		}
+		series = append(series, podTimeSeries(pod, float64(usage)))
+	}
+	return series, nil
+}
+
+func getMemoryUsage(cgroupRoot string, version cgroup.CgroupVersion) (int64, error) {
 	usage := int64(0)
+	metrics := memoryStatMetrics
+	if version == cgroup.CgroupV2 {
+		metrics = memoryStatMetricsV2
+	}
 	cgroupMemory := filepath.Join(cgroupRoot, cgroup.MemoryUsageFile)
 	date, err := os.ReadFile(cgroupMemory)
 	if err != nil {
@@ -101,7 +136,7 @@ func getMemoryUsage(cgroupRoot string) (int64, error) {
 			continue
 		}
 
-		if memoryStatMetrics[slices[0]] {
+		if metrics[slices[0]] {
 			value, err := strconv.Atoi(slices[1])
 			if err != nil {
 				continue
@@ -17,6 +17,8 @@
 package allocate
 
 import (
+	"maps"
+	"sort"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -33,6 +35,12 @@ type Action struct {
 	session *framework.Session
 	// configured flag for error cache
 	enablePredicateErrorCache bool
+	// stopAtBlockedJob leaves the jobs after the first blocked job to the backfill action, which only lets them
+	// start if they don't delay the reserved start of the blocked job
+	stopAtBlockedJob bool
+	// jobHyperNodes is the hypernode each job was placed in during the session, the tasks of the job
+	// left after it got ready try this hypernode first so that they join the tasks already placed
+	jobHyperNodes map[api.JobID]string
 }
 
 func New() *Action {
@@ -50,6 +58,11 @@ func (alloc *Action) Initialize() {}
 func (alloc *Action) parseArguments(ssn *framework.Session) {
 	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, alloc.Name())
 	arguments.GetBool(&alloc.enablePredicateErrorCache, conf.EnablePredicateErrCacheKey)
+
+	backfillArguments := framework.GetArgOfActionFromConf(ssn.Configurations, "backfill")
+	policy, _ := backfillArguments[conf.BackfillPolicyKey].(string)
+	alloc.stopAtBlockedJob = conf.EnabledActionMap["backfill"] &&
+		(policy == conf.BackfillPolicyEasy || policy == conf.BackfillPolicyConservative)
 }
 
 func (alloc *Action) Execute(ssn *framework.Session) {
@@ -71,6 +84,7 @@ func (alloc *Action) Execute(ssn *framework.Session) {
 	jobsMap := map[api.QueueID]*util.PriorityQueue{}
 
 	alloc.session = ssn
+	alloc.jobHyperNodes = map[api.JobID]string{}
 	alloc.pickUpQueuesAndJobs(queues, jobsMap)
 	klog.V(3).Infof("Try to allocate resource to %d Queues", len(jobsMap))
 	alloc.allocateResources(queues, jobsMap)
@@ -176,7 +190,23 @@ func (alloc *Action) allocateResources(queues *util.PriorityQueue, jobsMap map[a
 		klog.V(3).Infof("Try to allocate resource to %d tasks of Job <%v/%v>",
 			tasks.Len(), job.Namespace, job.Name)
 
-		alloc.allocateResourcesForTasks(tasks, job, jobs, queue, allNodes)
+		if topology := job.NetworkTopology(); topology != nil && ssn.HyperNodes != nil {
+			if remaining, allocated := alloc.allocateResourcesForHyperNodes(tasks, job, jobs, queue, topology); allocated {
+				pendingTasks[job.UID] = remaining
+			} else if topology.Mode == api.SoftNetworkTopologyMode {
+				klog.V(3).Infof("No hypernode fits Job <%v/%v>, fall back to all nodes", job.Namespace, job.Name)
+				alloc.allocateResourcesForTasks(tasks, job, jobs, queue, allNodes)
+			} else {
+				klog.V(3).Infof("No hypernode up to tier %d fits Job <%v/%v>", topology.HighestTierAllowed, job.Namespace, job.Name)
+			}
+		} else {
+			alloc.allocateResourcesForTasks(tasks, job, jobs, queue, allNodes)
+		}
+
+		if alloc.stopAtBlockedJob && isBlocked(ssn, job) {
+			klog.V(3).Infof("Job <%v/%v> is blocked, leave the other jobs to backfill", job.Namespace, job.Name)
+			break
+		}
 
 		// Put back the queue to priority queue after job's resource allocating finished,
 		// To ensure that the priority of the queue is calculated based on the latest resource allocation situation.
@@ -184,9 +214,127 @@ func (alloc *Action) allocateResources(queues *util.PriorityQueue, jobsMap map[a
 	}
 }
 
+// allocateResourcesForHyperNodes tries to allocate the tasks of the job inside one hypernode, from
+// the lowest tier to the highest tier allowed by the network topology of the job. The hypernodes of
+// a tier are tried in the order of their scores, and the first one in which the job gets ready or
+// pipelined is kept. It returns the tasks which are left to allocate and whether the job was placed.
+func (alloc *Action) allocateResourcesForHyperNodes(tasks *util.PriorityQueue, job *api.JobInfo, jobs *util.PriorityQueue,
+	queue *api.QueueInfo, topology *api.NetworkTopology) (*util.PriorityQueue, bool) {
+	ssn := alloc.session
+
+	// the fit errors of a failed attempt are reverted, otherwise the next attempts would skip
+	// the tasks of the same role; only those of the last attempt are kept for the hard mode
+	fitErrors := maps.Clone(job.NodesFitErrors)
+	var lastFitErrors map[api.TaskID]*api.FitErrors
+	defer func() {
+		if lastFitErrors != nil && topology.Mode == api.HardNetworkTopologyMode {
+			job.NodesFitErrors = lastFitErrors
+		}
+	}()
+
+	// tryHyperNode allocates the tasks on the nodes of the hypernode, it returns the tasks left and
+	// whether the job is placed in the hypernode
+	tryHyperNode := func(hn *api.HyperNodeInfo) (*util.PriorityQueue, bool) {
+		var nodes []*api.NodeInfo
+		for _, node := range ssn.NodeList {
+			if hn.Nodes.Has(node.Name) {
+				nodes = append(nodes, node)
+			}
+		}
+		if len(nodes) == 0 {
+			return nil, false
+		}
+
+		klog.V(4).Infof("Try to allocate Job <%v/%v> in hypernode %s of tier %d", job.Namespace, job.Name, hn.Name, hn.Tier)
+		remaining := tasks.Clone()
+		placed := placedTaskNum(job)
+		stmt := framework.NewStatement(ssn)
+		more := alloc.allocateTasksOnNodes(stmt, remaining, job, queue, nodes)
+		if placedTaskNum(job) == placed || (!ssn.JobReady(job) && !ssn.JobPipelined(job)) {
+			stmt.Discard()
+			lastFitErrors = job.NodesFitErrors
+			job.NodesFitErrors = maps.Clone(fitErrors)
+			return nil, false
+		}
+		if ssn.JobReady(job) {
+			stmt.Commit()
+		}
+		lastFitErrors = nil
+
+		klog.V(3).Infof("Job <%v/%v> is placed in hypernode %s of tier %d", job.Namespace, job.Name, hn.Name, hn.Tier)
+		alloc.jobHyperNodes[job.UID] = hn.Name
+		if more {
+			jobs.Push(job)
+		}
+		return remaining, true
+	}
+
+	// the job pushed back for its remaining tasks tries the hypernode it was placed in first, so that
+	// in soft mode the remaining tasks don't land in another hypernode of the same tier
+	if name, found := alloc.jobHyperNodes[job.UID]; found {
+		if hn, found := ssn.HyperNodes.HyperNodes[name]; found {
+			if remaining, placed := tryHyperNode(hn); placed {
+				return remaining, true
+			}
+		}
+	}
+
+	for _, tier := range ssn.HyperNodes.Tiers() {
+		if topology.HighestTierAllowed > 0 && tier > topology.HighestTierAllowed {
+			break
+		}
+
+		hyperNodes := ssn.HyperNodes.HyperNodesOfTier(tier)
+		scores, err := ssn.HyperNodeOrderFn(job, hyperNodes)
+		if err != nil {
+			klog.Errorf("Failed to score hypernodes of tier %d for Job <%v/%v>: %v", tier, job.Namespace, job.Name, err)
+			return nil, false
+		}
+		sort.SliceStable(hyperNodes, func(i, j int) bool {
+			return scores[hyperNodes[i].Name] > scores[hyperNodes[j].Name]
+		})
+
+		for _, hn := range hyperNodes {
+			if remaining, placed := tryHyperNode(hn); placed {
+				return remaining, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// isBlocked returns whether the job can't start for lack of resources.
+func isBlocked(ssn *framework.Session, job *api.JobInfo) bool {
+	return !ssn.JobReady(job) && !ssn.JobPipelined(job) && len(job.NodesFitErrors) > 0
+}
+
+// placedTaskNum returns the number of tasks of the job which are allocated or pipelined.
+func placedTaskNum(job *api.JobInfo) int {
+	return len(job.TaskStatusIndex[api.Allocated]) + len(job.TaskStatusIndex[api.Pipelined])
+}
+
 func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *api.JobInfo, jobs *util.PriorityQueue, queue *api.QueueInfo, allNodes []*api.NodeInfo) {
 	ssn := alloc.session
 	stmt := framework.NewStatement(ssn)
+
+	if alloc.allocateTasksOnNodes(stmt, tasks, job, queue, allNodes) {
+		jobs.Push(job)
+	}
+
+	if ssn.JobReady(job) {
+		stmt.Commit()
+	} else {
+		if !ssn.JobPipelined(job) {
+			stmt.Discard()
+		}
+	}
+}
+
+// allocateTasksOnNodes allocates the tasks on the nodes in the statement. It returns true if it stops
+// because the job is ready while some tasks are left, so that the other jobs of the queue go first.
+func (alloc *Action) allocateTasksOnNodes(stmt *framework.Statement, tasks *util.PriorityQueue, job *api.JobInfo,
+	queue *api.QueueInfo, allNodes []*api.NodeInfo) bool {
+	ssn := alloc.session
 	ph := util.NewPredicateHelper()
 
 	for !tasks.Empty() {
@@ -221,7 +369,8 @@ func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *a
 		// "NominatedNodeName" can potentially be set in a previous scheduling cycle as a result of preemption.
 		// This node is likely the only candidate that will fit the pod, and hence we try it first before iterating over all nodes.
 		if len(task.Pod.Status.NominatedNodeName) > 0 {
-			if nominatedNodeInfo, ok := ssn.Nodes[task.Pod.Status.NominatedNodeName]; ok && task.InitResreq.LessEqual(nominatedNodeInfo.Idle, api.Zero) {
+			if nominatedNodeInfo, ok := ssn.Nodes[task.Pod.Status.NominatedNodeName]; ok && containsNode(allNodes, nominatedNodeInfo) &&
+				task.InitResreq.LessEqual(nominatedNodeInfo.Idle, api.Zero) {
 				predicateNodes, fitErrors = ph.PredicateNodes(task, []*api.NodeInfo{nominatedNodeInfo}, alloc.predicate, alloc.enablePredicateErrorCache)
 			}
 		}
@@ -328,18 +477,19 @@ func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *a
 		}
 
 		if ssn.JobReady(job) && !tasks.Empty() {
-			jobs.Push(job)
-			break
+			return true
 		}
 	}
+	return false
+}
 
-	if ssn.JobReady(job) {
-		stmt.Commit()
-	} else {
-		if !ssn.JobPipelined(job) {
-			stmt.Discard()
+func containsNode(nodes []*api.NodeInfo, node *api.NodeInfo) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
 		}
 	}
+	return false
 }
 
 func (alloc *Action) predicate(task *api.TaskInfo, node *api.NodeInfo) error {
@@ -41,6 +41,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/drf"
 	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	networktopologyaware "volcano.sh/volcano/pkg/scheduler/plugins/network-topology-aware"
 	"volcano.sh/volcano/pkg/scheduler/plugins/nodeorder"
 	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
 	"volcano.sh/volcano/pkg/scheduler/plugins/priority"
@@ -386,6 +387,185 @@ func TestFareShareAllocate(t *testing.T) {
 	}
 }
 
+func TestAllocateWithNetworkTopology(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{
+		gang.PluginName:                 gang.New,
+		predicates.PluginName:           predicates.New,
+		nodeorder.PluginName:            nodeorder.New,
+		networktopologyaware.PluginName: networktopologyaware.New,
+	}
+	buildPodGroup := func(mode, highestTier string) *schedulingv1.PodGroup {
+		pg := util.BuildPodGroup("pg1", "c1", "c1", 2, nil, schedulingv1.PodGroupInqueue)
+		pg.Annotations = map[string]string{
+			api.NetworkTopologyModeAnnotation:        mode,
+			api.NetworkTopologyHighestTierAnnotation: highestTier,
+		}
+		return pg
+	}
+	buildNode := func(name, cpu, leaf string) *v1.Node {
+		return util.BuildNode(name, api.BuildResourceList(cpu, "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...),
+			map[string]string{"leaf": leaf, "spine": "spine0"})
+	}
+	buildPods := func() []*v1.Pod {
+		return []*v1.Pod{
+			util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("2", "1G"), "pg1", nil, nil),
+			util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("2", "1G"), "pg1", nil, nil),
+		}
+	}
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:      "hard mode, the gang is placed in the only leaf which fits it",
+			PodGroups: []*schedulingv1.PodGroup{buildPodGroup("hard", "1")},
+			Pods:      buildPods(),
+			Nodes: []*v1.Node{
+				buildNode("n1", "2", "leaf0"),
+				buildNode("n2", "1", "leaf0"),
+				buildNode("n3", "4", "leaf1"),
+			},
+			Queues: []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap: map[string]string{
+				"c1/p1": "n3",
+				"c1/p2": "n3",
+			},
+			ExpectBindsNum: 2,
+		},
+		{
+			Name:      "hard mode, no leaf fits the gang and the spine is not allowed",
+			PodGroups: []*schedulingv1.PodGroup{buildPodGroup("hard", "1")},
+			Pods:      buildPods(),
+			Nodes: []*v1.Node{
+				buildNode("n1", "3", "leaf0"),
+				buildNode("n2", "2", "leaf1"),
+			},
+			Queues:         []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap:  map[string]string{},
+			ExpectBindsNum: 0,
+		},
+		{
+			Name:      "hard mode, the gang spans the leaves of the spine when allowed",
+			PodGroups: []*schedulingv1.PodGroup{buildPodGroup("hard", "2")},
+			Pods:      buildPods(),
+			Nodes: []*v1.Node{
+				buildNode("n1", "3", "leaf0"),
+				buildNode("n2", "2", "leaf1"),
+			},
+			Queues: []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap: map[string]string{
+				"c1/p1": "n1",
+				"c1/p2": "n2",
+			},
+			ExpectBindsNum: 2,
+		},
+		{
+			Name:      "soft mode, the gang falls back to all nodes",
+			PodGroups: []*schedulingv1.PodGroup{buildPodGroup("soft", "1")},
+			Pods:      buildPods(),
+			Nodes: []*v1.Node{
+				buildNode("n1", "3", "leaf0"),
+				util.BuildNode("n2", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			},
+			Queues: []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+			ExpectBindMap: map[string]string{
+				"c1/p1": "n1",
+				"c1/p2": "n2",
+			},
+			ExpectBindsNum: 2,
+		},
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:                gang.PluginName,
+					EnabledJobReady:     &trueValue,
+					EnabledJobPipelined: &trueValue,
+				},
+				{
+					Name:             predicates.PluginName,
+					EnabledPredicate: &trueValue,
+				},
+				{
+					Name:             nodeorder.PluginName,
+					EnabledNodeOrder: &trueValue,
+				},
+				{
+					Name:                  networktopologyaware.PluginName,
+					EnabledPredicate:      &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledHyperNodeOrder: &trueValue,
+					Arguments: map[string]interface{}{
+						networktopologyaware.TierLabelsKey: "leaf,spine",
+					},
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = plugins
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestAllocateInRememberedHyperNode(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{
+		gang.PluginName:                 gang.New,
+		predicates.PluginName:           predicates.New,
+		networktopologyaware.PluginName: networktopologyaware.New,
+	}
+	pg := util.BuildPodGroup("pg1", "c1", "c1", 1, nil, schedulingv1.PodGroupRunning)
+	pg.Annotations = map[string]string{api.NetworkTopologyModeAnnotation: "soft", api.NetworkTopologyHighestTierAnnotation: "1"}
+	buildNode := func(name, leaf string) *v1.Node {
+		return util.BuildNode(name, api.BuildResourceList("4", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...),
+			map[string]string{"leaf": leaf})
+	}
+	test := uthelper.TestCommonStruct{
+		Plugins:   plugins,
+		PodGroups: []*schedulingv1.PodGroup{pg},
+		Pods:      []*v1.Pod{util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil)},
+		Nodes:     []*v1.Node{buildNode("n1", "leaf0"), buildNode("n2", "leaf1")},
+		Queues:    []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+	}
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{Name: gang.PluginName, EnabledJobReady: &trueValue, EnabledJobPipelined: &trueValue},
+				{Name: predicates.PluginName, EnabledPredicate: &trueValue},
+				{Name: networktopologyaware.PluginName, Arguments: map[string]interface{}{networktopologyaware.TierLabelsKey: "leaf"}},
+			},
+		},
+	}
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+
+	job := ssn.Jobs["c1/pg1"]
+	tasks := util.NewPriorityQueue(ssn.TaskOrderFn)
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		tasks.Push(task)
+	}
+
+	// leaf0 comes first among the hypernodes of the tier, but the job was placed in leaf1 earlier in the session
+	alloc := New()
+	alloc.session = ssn
+	alloc.jobHyperNodes = map[api.JobID]string{job.UID: "leaf=leaf1"}
+	_, placed := alloc.allocateResourcesForHyperNodes(tasks, job, util.NewPriorityQueue(ssn.JobOrderFn), ssn.Queues[job.Queue], job.NetworkTopology())
+	assert.True(t, placed)
+	for _, task := range job.Tasks {
+		assert.Equal(t, "n2", task.NodeName)
+	}
+}
+
 func TestAllocateWithDynamicPVC(t *testing.T) {
 	var tmp *cache.SchedulerCache
 	patches := gomonkey.ApplyMethod(reflect.TypeOf(tmp), "AddBindTask", func(scCache *cache.SchedulerCache, task *api.TaskInfo) error {
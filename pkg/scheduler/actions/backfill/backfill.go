@@ -17,6 +17,7 @@ limitations under the License.
 package backfill
 
 import (
+	"fmt"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -28,8 +29,15 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
+// timeNow is the clock of the reservations, it is replaced in tests
+var timeNow = time.Now
+
 type Action struct {
 	enablePredicateErrorCache bool
+	// policy is the policy of the reservations for the blocked jobs, the jobs which are not BestEffort are not
+	// backfilled if it is not set
+	policy          string
+	defaultEstimate time.Duration
 }
 
 func New() *Action {
@@ -47,6 +55,25 @@ func (backfill *Action) Initialize() {}
 func (backfill *Action) parseArguments(ssn *framework.Session) {
 	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, backfill.Name())
 	arguments.GetBool(&backfill.enablePredicateErrorCache, conf.EnablePredicateErrCacheKey)
+
+	backfill.policy = ""
+	if policy, ok := arguments[conf.BackfillPolicyKey].(string); ok {
+		switch policy {
+		case conf.BackfillPolicyEasy, conf.BackfillPolicyConservative:
+			backfill.policy = policy
+		default:
+			klog.Warningf("Unknown %s %q of backfill action, only BestEffort tasks are backfilled", conf.BackfillPolicyKey, policy)
+		}
+	}
+	backfill.defaultEstimate = 0
+	if value, found := arguments[DefaultRuntimeEstimateKey]; found {
+		estimate, err := time.ParseDuration(fmt.Sprint(value))
+		if err != nil || estimate <= 0 {
+			klog.Warningf("Invalid %s %v of backfill action, ignore it", DefaultRuntimeEstimateKey, value)
+		} else {
+			backfill.defaultEstimate = estimate
+		}
+	}
 }
 
 func (backfill *Action) Execute(ssn *framework.Session) {
@@ -61,36 +88,15 @@ func (backfill *Action) Execute(ssn *framework.Session) {
 	pendingTasks := backfill.pickUpPendingTasks(ssn)
 	for _, task := range pendingTasks {
 		job := ssn.Jobs[task.Job]
-		ph := util.NewPredicateHelper()
-		fe := api.NewFitErrors()
-
-		if err := ssn.PrePredicateFn(task); err != nil {
-			klog.V(3).Infof("PrePredicate for task %s/%s failed in backfill for: %v", task.Namespace, task.Name, err)
-			for _, ni := range ssn.Nodes {
-				fe.SetNodeError(ni.Name, err)
-			}
-			job.NodesFitErrors[task.UID] = fe
+		node := backfill.selectNode(ssn, job, task, predicateFunc)
+		if node == nil {
 			continue
 		}
 
-		predicateNodes, fitErrors := ph.PredicateNodes(task, ssn.NodeList, predicateFunc, backfill.enablePredicateErrorCache)
-		if len(predicateNodes) == 0 {
-			job.NodesFitErrors[task.UID] = fitErrors
-			continue
-		}
-
-		node := predicateNodes[0]
-		if len(predicateNodes) > 1 {
-			nodeScores := util.PrioritizeNodes(task, predicateNodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
-			node = ssn.BestNodeFn(task, nodeScores)
-			if node == nil {
-				node = util.SelectBestNode(nodeScores)
-			}
-		}
-
 		klog.V(3).Infof("Binding Task <%v/%v> to node <%v>", task.Namespace, task.Name, node.Name)
 		if err := ssn.Allocate(task, node); err != nil {
 			klog.Errorf("Failed to bind Task %v on %v in Session %v", task.UID, node.Name, ssn.UID)
+			fe := api.NewFitErrors()
 			fe.SetNodeError(node.Name, err)
 			job.NodesFitErrors[task.UID] = fe
 			continue
@@ -98,9 +104,141 @@ func (backfill *Action) Execute(ssn *framework.Session) {
 
 		metrics.UpdateE2eSchedulingDurationByJob(job.Name, string(job.Queue), job.Namespace, metrics.Duration(job.CreationTimestamp.Time))
 		metrics.UpdateE2eSchedulingLastTimeByJob(job.Name, string(job.Queue), job.Namespace, time.Now())
+	}
+
+	if backfill.policy != "" {
+		backfill.backfillWithReservation(ssn)
+	}
+}
+
+// selectNode returns the best node the task fits on by the predicate, or nil after recording the fit errors.
+func (backfill *Action) selectNode(ssn *framework.Session, job *api.JobInfo, task *api.TaskInfo, predicateFunc api.PredicateFn) *api.NodeInfo {
+	if err := ssn.PrePredicateFn(task); err != nil {
+		klog.V(3).Infof("PrePredicate for task %s/%s failed in backfill for: %v", task.Namespace, task.Name, err)
+		fe := api.NewFitErrors()
+		for _, ni := range ssn.Nodes {
+			fe.SetNodeError(ni.Name, err)
+		}
+		job.NodesFitErrors[task.UID] = fe
+		return nil
+	}
+
+	ph := util.NewPredicateHelper()
+	predicateNodes, fitErrors := ph.PredicateNodes(task, ssn.NodeList, predicateFunc, backfill.enablePredicateErrorCache)
+	if len(predicateNodes) == 0 {
+		job.NodesFitErrors[task.UID] = fitErrors
+		return nil
+	}
+
+	node := predicateNodes[0]
+	if len(predicateNodes) > 1 {
+		nodeScores := util.PrioritizeNodes(task, predicateNodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
+		node = ssn.BestNodeFn(task, nodeScores)
+		if node == nil {
+			node = util.SelectBestNode(nodeScores)
+		}
+	}
+	return node
+}
+
+// backfillWithReservation places the pending jobs left by the allocate action in the order of the queues and jobs.
+// A job is placed only if it doesn't delay the reserved starts of the blocked jobs before it, and a job which can't
+// be placed gets its earliest start reserved by the policy.
+func (backfill *Action) backfillWithReservation(ssn *framework.Session) {
+	r := newReservation(backfill.policy, timeNow(), backfill.defaultEstimate, ssn.NodeList, ssn.Jobs)
+	for _, job := range backfill.pickUpPendingJobs(ssn) {
+		if !r.permits(job) {
+			klog.V(3).Infof("Job <%s/%s> would delay the reserved jobs, skip backfilling it", job.Namespace, job.Name)
+			r.block(job)
+			continue
+		}
+		if !backfill.allocateJob(ssn, job) {
+			r.block(job)
+			continue
+		}
+		r.place(job)
+	}
+}
+
+// allocateJob allocates the pending tasks of the job on the idle resources, it commits the allocation only if the
+// job gets ready.
+func (backfill *Action) allocateJob(ssn *framework.Session, job *api.JobInfo) bool {
+	queue := ssn.Queues[job.Queue]
+	predicateFunc := func(task *api.TaskInfo, node *api.NodeInfo) error {
+		if ok, resources := task.InitResreq.LessEqualWithResourcesName(node.Idle, api.Zero); !ok {
+			return api.NewFitErrWithStatus(task, node, &api.Status{Code: api.Unschedulable, Reason: api.WrapInsufficientResourceReason(resources)})
+		}
+		return ssn.PredicateForAllocateAction(task, node)
+	}
+
+	tasks := util.NewPriorityQueue(ssn.TaskOrderFn)
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		if !task.BestEffort && !task.SchGated {
+			tasks.Push(task)
+		}
+	}
+
+	stmt := framework.NewStatement(ssn)
+	for !tasks.Empty() {
+		task := tasks.Pop().(*api.TaskInfo)
+		if !ssn.Allocatable(queue, task) {
+			klog.V(3).Infof("Queue <%s> is overused when considering task <%s>, ignore it.", queue.Name, task.Name)
+			continue
+		}
+		node := backfill.selectNode(ssn, job, task, predicateFunc)
+		if node == nil {
+			continue
+		}
+		klog.V(3).Infof("Backfilling Task <%v/%v> to node <%v>", task.Namespace, task.Name, node.Name)
+		if err := stmt.Allocate(task, node); err != nil {
+			klog.Errorf("Failed to backfill Task %v on %v in Session %v, err: %v", task.UID, node.Name, ssn.UID, err)
+		}
+	}
+
+	if !ssn.JobReady(job) {
+		stmt.Discard()
+		return false
+	}
+	stmt.Commit()
+	metrics.UpdateE2eSchedulingDurationByJob(job.Name, string(job.Queue), job.Namespace, metrics.Duration(job.CreationTimestamp.Time))
+	metrics.UpdateE2eSchedulingLastTimeByJob(job.Name, string(job.Queue), job.Namespace, time.Now())
+	return true
+}
+
+// pickUpPendingJobs returns the jobs with pending tasks which are not BestEffort, in the order of the queues and jobs.
+func (backfill *Action) pickUpPendingJobs(ssn *framework.Session) []*api.JobInfo {
+	queues := util.NewPriorityQueue(ssn.QueueOrderFn)
+	jobs := map[api.QueueID]*util.PriorityQueue{}
+	for _, job := range ssn.Jobs {
+		if job.IsPending() || pendingRequest(job).IsEmpty() {
+			continue
+		}
+
+		if vr := ssn.JobValid(job); vr != nil && !vr.Pass {
+			klog.V(4).Infof("Job <%s/%s> Queue <%s> skip backfill, reason: %v, message %v", job.Namespace, job.Name, job.Queue, vr.Reason, vr.Message)
+			continue
+		}
 
-		// TODO (k82cn): backfill for other case.
+		queue, found := ssn.Queues[job.Queue]
+		if !found || ssn.Overused(queue) {
+			continue
+		}
+
+		if _, existed := jobs[queue.UID]; !existed {
+			queues.Push(queue)
+			jobs[queue.UID] = util.NewPriorityQueue(ssn.JobOrderFn)
+		}
+		jobs[queue.UID].Push(job)
+	}
+
+	var pendingJobs []*api.JobInfo
+	for !queues.Empty() {
+		queue := queues.Pop().(*api.QueueInfo)
+		for !jobs[queue.UID].Empty() {
+			pendingJobs = append(pendingJobs, jobs[queue.UID].Pop().(*api.JobInfo))
+		}
 	}
+	return pendingJobs
 }
 
 func (backfill *Action) UnInitialize() {}
@@ -0,0 +1,350 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backfill
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+)
+
+const (
+	// RuntimeEstimateAnnotation is the annotation of the podgroup of the estimated runtime of the job, e.g. "2h".
+	RuntimeEstimateAnnotation = "volcano.sh/runtime-estimate"
+	// RuntimeGroupLabel is the label of the podgroup grouping the jobs of similar runtime, e.g. the runs of a
+	// pipeline. The runtime of a job without estimate is learned from the completed jobs of its group.
+	RuntimeGroupLabel = "volcano.sh/runtime-group"
+
+	// DefaultRuntimeEstimateKey is the argument of the runtime of the jobs without estimate, they are considered
+	// to run forever if it is not set.
+	DefaultRuntimeEstimateKey = "defaultRuntimeEstimate"
+
+	// runtimeHistorySize is the number of the runtimes of the completed jobs kept per group.
+	runtimeHistorySize = 10
+)
+
+// unknownRuntime is the runtime of the jobs without estimate.
+const unknownRuntime time.Duration = -1
+
+// runtimeHistory keeps the runtimes of the completed jobs across the sessions.
+type runtimeHistory struct {
+	sync.Mutex
+	runtimes map[string][]time.Duration
+	recorded map[api.JobID]struct{}
+}
+
+var history = &runtimeHistory{
+	runtimes: map[string][]time.Duration{},
+	recorded: map[api.JobID]struct{}{},
+}
+
+// jobRuntime returns the runtime of the completed job, from the start of its first task to the end of its last one.
+func jobRuntime(job *api.JobInfo) (time.Duration, bool) {
+	if len(job.Tasks) == 0 || len(job.TaskStatusIndex[api.Succeeded]) != len(job.Tasks) {
+		return 0, false
+	}
+	var start, finish time.Time
+	for _, task := range job.Tasks {
+		if task.Pod == nil || task.Pod.Status.StartTime == nil {
+			return 0, false
+		}
+		if started := task.Pod.Status.StartTime.Time; start.IsZero() || started.Before(start) {
+			start = started
+		}
+		for _, status := range task.Pod.Status.ContainerStatuses {
+			if terminated := status.State.Terminated; terminated != nil && terminated.FinishedAt.Time.After(finish) {
+				finish = terminated.FinishedAt.Time
+			}
+		}
+	}
+	if !finish.After(start) {
+		return 0, false
+	}
+	return finish.Sub(start), true
+}
+
+// learn records the runtimes of the jobs of the runtime groups completed since the last session.
+func (h *runtimeHistory) learn(jobs map[api.JobID]*api.JobInfo) {
+	h.Lock()
+	defer h.Unlock()
+	for uid := range h.recorded {
+		if _, found := jobs[uid]; !found {
+			delete(h.recorded, uid)
+		}
+	}
+	for uid, job := range jobs {
+		if job.PodGroup == nil {
+			continue
+		}
+		group := job.PodGroup.Labels[RuntimeGroupLabel]
+		if _, found := h.recorded[uid]; found || group == "" {
+			continue
+		}
+		runtime, completed := jobRuntime(job)
+		if !completed {
+			continue
+		}
+		h.recorded[uid] = struct{}{}
+		runtimes := append(h.runtimes[group], runtime)
+		if len(runtimes) > runtimeHistorySize {
+			runtimes = runtimes[len(runtimes)-runtimeHistorySize:]
+		}
+		h.runtimes[group] = runtimes
+		klog.V(4).Infof("Learned runtime %v of Job <%s/%s> in runtime group %q", runtime, job.Namespace, job.Name, group)
+	}
+}
+
+// estimate returns the longest recent runtime of the group, so that the jobs backfilled by it rarely overrun.
+func (h *runtimeHistory) estimate(group string) (time.Duration, bool) {
+	h.Lock()
+	defer h.Unlock()
+	runtimes := h.runtimes[group]
+	if len(runtimes) == 0 {
+		return 0, false
+	}
+	longest := runtimes[0]
+	for _, runtime := range runtimes[1:] {
+		if runtime > longest {
+			longest = runtime
+		}
+	}
+	return longest, true
+}
+
+// profile is the free resources of the cluster over time: free[i] is free from times[i] until times[i+1], the
+// last one forever.
+type profile struct {
+	times []time.Time
+	free  []*api.Resource
+}
+
+func newProfile(now time.Time, free *api.Resource) *profile {
+	return &profile{times: []time.Time{now}, free: []*api.Resource{free}}
+}
+
+// split returns the index of the segment starting at t, it splits the segment t falls in if needed.
+func (p *profile) split(t time.Time) int {
+	i := len(p.times) - 1
+	for i > 0 && p.times[i].After(t) {
+		i--
+	}
+	if p.times[i].Equal(t) || t.Before(p.times[0]) {
+		return i
+	}
+	p.times = append(p.times[:i+1], append([]time.Time{t}, p.times[i+1:]...)...)
+	p.free = append(p.free[:i+1], append([]*api.Resource{p.free[i].Clone()}, p.free[i+1:]...)...)
+	return i + 1
+}
+
+// end returns the index of the first segment after the interval from start for the runtime.
+func (p *profile) end(start time.Time, runtime time.Duration) int {
+	if runtime == unknownRuntime {
+		return len(p.times)
+	}
+	return p.split(start.Add(runtime))
+}
+
+// release adds the resources released at t to the profile.
+func (p *profile) release(t time.Time, r *api.Resource) {
+	for i := p.split(t); i < len(p.times); i++ {
+		p.free[i].Add(r)
+	}
+}
+
+// fits returns whether the request is free from start for the runtime.
+func (p *profile) fits(start time.Time, runtime time.Duration, request *api.Resource) bool {
+	for i, t := range p.times {
+		if runtime != unknownRuntime && !t.Before(start.Add(runtime)) {
+			break
+		}
+		if i+1 < len(p.times) && !p.times[i+1].After(start) {
+			continue
+		}
+		if !request.LessEqual(p.free[i], api.Zero) {
+			return false
+		}
+	}
+	return true
+}
+
+// earliestStart returns the first time after now the request is free for the runtime.
+func (p *profile) earliestStart(runtime time.Duration, request *api.Resource) (time.Time, bool) {
+	for _, t := range p.times[1:] {
+		if p.fits(t, runtime, request) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// take removes the request from the profile from start for the runtime.
+func (p *profile) take(start time.Time, runtime time.Duration, request *api.Resource) {
+	first := p.split(start)
+	last := p.end(start, runtime)
+	for i := first; i < last; i++ {
+		subtract(p.free[i], request)
+	}
+}
+
+// subtract subtracts rr from r, the result may be negative where the profile is overcommitted.
+func subtract(r, rr *api.Resource) {
+	r.MilliCPU -= rr.MilliCPU
+	r.Memory -= rr.Memory
+	for name, quantity := range rr.ScalarResources {
+		r.AddScalar(name, -quantity)
+	}
+}
+
+// reservation plans the starts of the jobs on the profile of the session: a job may start now only if it doesn't
+// delay the reserved starts of the blocked jobs before it.
+type reservation struct {
+	policy          string
+	now             time.Time
+	defaultEstimate time.Duration
+	profile         *profile
+	// placed is the resources of the jobs already taken from the profile
+	placed map[api.JobID]*api.Resource
+	// reserved is the reserved start of the blocked jobs
+	reserved map[api.JobID]time.Time
+}
+
+func newReservation(policy string, now time.Time, defaultEstimate time.Duration,
+	nodes []*api.NodeInfo, jobs map[api.JobID]*api.JobInfo) *reservation {
+	history.learn(jobs)
+
+	free := api.EmptyResource()
+	for _, node := range nodes {
+		free.Add(node.FutureIdle())
+	}
+	r := &reservation{
+		policy:          policy,
+		now:             now,
+		defaultEstimate: defaultEstimate,
+		profile:         newProfile(now, free),
+		placed:          map[api.JobID]*api.Resource{},
+		reserved:        map[api.JobID]time.Time{},
+	}
+	for _, job := range jobs {
+		runtime := r.estimate(job)
+		if runtime == unknownRuntime {
+			continue
+		}
+		for _, task := range job.Tasks {
+			if !api.AllocatedStatus(task.Status) {
+				continue
+			}
+			start := now
+			if task.Pod != nil && task.Pod.Status.StartTime != nil {
+				start = task.Pod.Status.StartTime.Time
+			}
+			// the tasks overrunning their estimate are expected to end at any moment
+			end := start.Add(runtime)
+			if end.Before(now) {
+				end = now
+			}
+			r.profile.release(end, task.Resreq)
+		}
+	}
+	return r
+}
+
+// estimate returns the estimated runtime of the job: its annotation, the runtime learned for its runtime group,
+// or the default estimate.
+func (r *reservation) estimate(job *api.JobInfo) time.Duration {
+	if job.PodGroup != nil {
+		if value, found := job.PodGroup.Annotations[RuntimeEstimateAnnotation]; found {
+			runtime, err := time.ParseDuration(value)
+			if err == nil && runtime > 0 {
+				return runtime
+			}
+			klog.V(3).Infof("Invalid runtime estimate %q of Job <%s/%s>", value, job.Namespace, job.Name)
+		}
+		if group := job.PodGroup.Labels[RuntimeGroupLabel]; group != "" {
+			if runtime, found := history.estimate(group); found {
+				return runtime
+			}
+		}
+	}
+	if r.defaultEstimate > 0 {
+		return r.defaultEstimate
+	}
+	return unknownRuntime
+}
+
+// pendingRequest returns the resources requested by the pending tasks of the job.
+func pendingRequest(job *api.JobInfo) *api.Resource {
+	request := api.EmptyResource()
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		if !task.BestEffort {
+			request.Add(task.Resreq)
+		}
+	}
+	return request
+}
+
+// permits returns whether the pending tasks of the job may start now: either they end before the reserved starts,
+// or they only take the resources left over by the reservations.
+func (r *reservation) permits(job *api.JobInfo) bool {
+	return r.profile.fits(r.now, r.estimate(job), pendingRequest(job))
+}
+
+// place takes the resources allocated to the job in the session from the profile.
+func (r *reservation) place(job *api.JobInfo) {
+	allocated := api.EmptyResource()
+	for _, status := range []api.TaskStatus{api.Allocated, api.Pipelined} {
+		for _, task := range job.TaskStatusIndex[status] {
+			allocated.Add(task.Resreq)
+		}
+	}
+	placed, found := r.placed[job.UID]
+	if !found {
+		placed = api.EmptyResource()
+		r.placed[job.UID] = placed
+	}
+	delta := allocated.Clone()
+	subtract(delta, placed)
+	placed.Add(delta)
+	if delta.IsEmpty() {
+		return
+	}
+	r.profile.take(r.now, r.estimate(job), delta)
+}
+
+// block reserves the earliest start of the blocked job: the easy policy reserves for the first blocked job only,
+// the conservative policy for every blocked job.
+func (r *reservation) block(job *api.JobInfo) {
+	if _, found := r.reserved[job.UID]; found {
+		return
+	}
+	if r.policy == conf.BackfillPolicyEasy && len(r.reserved) > 0 {
+		return
+	}
+	runtime, request := r.estimate(job), pendingRequest(job)
+	start, found := r.profile.earliestStart(runtime, request)
+	if !found {
+		klog.V(3).Infof("No start can be reserved for blocked Job <%s/%s>, request <%v>", job.Namespace, job.Name, request)
+		return
+	}
+	r.profile.take(start, runtime, request)
+	r.reserved[job.UID] = start
+	klog.V(3).Infof("Reserved start %v for blocked Job <%s/%s>, request <%v>, runtime %v",
+		start.Format(time.RFC3339), job.Namespace, job.Name, request, runtime)
+}
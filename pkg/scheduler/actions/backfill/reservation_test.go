@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backfill
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func buildJob(name string, runtime string, pendingCPU string) *api.JobInfo {
+	pg := scheduling.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+		Spec:       scheduling.PodGroupSpec{MinMember: 1, Queue: "q1"},
+	}
+	if runtime != "" {
+		pg.Annotations = map[string]string{RuntimeEstimateAnnotation: runtime}
+	}
+	job := api.NewJobInfo(api.JobID("ns1/" + name))
+	job.SetPodGroup(&api.PodGroup{PodGroup: pg, Version: api.PodGroupVersionV1Beta1})
+	pod := util.BuildPod("ns1", name+"-0", "", v1.PodPending, api.BuildResourceList(pendingCPU, "1Gi"), name, nil, nil)
+	job.AddTaskInfo(api.NewTaskInfo(pod))
+	return job
+}
+
+func TestReservation(t *testing.T) {
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	node := api.NewNodeInfo(util.BuildNode("n1", api.BuildResourceList("8", "16Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+
+	// the running job takes 6 cpus for 30 more minutes
+	running := buildJob("running", "1h", "6")
+	for _, task := range running.Tasks {
+		task.Pod.Status.StartTime = &metav1.Time{Time: now.Add(-30 * time.Minute)}
+		task.NodeName = "n1"
+		running.UpdateTaskStatus(task, api.Running)
+		assert.NoError(t, node.AddTask(task))
+	}
+
+	tests := []struct {
+		name           string
+		policy         string
+		permitted      []string
+		expectReserved map[string]time.Duration
+	}{
+		{
+			name:           "easy policy reserves the first blocked job only",
+			policy:         conf.BackfillPolicyEasy,
+			permitted:      []string{"short"},
+			expectReserved: map[string]time.Duration{"big": 30 * time.Minute},
+		},
+		{
+			name:      "conservative policy reserves every blocked job",
+			policy:    conf.BackfillPolicyConservative,
+			permitted: []string{"short"},
+			expectReserved: map[string]time.Duration{
+				"big": 30 * time.Minute, "big2": 90 * time.Minute, "long": 150 * time.Minute, "unknown": 150 * time.Minute,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jobs := []*api.JobInfo{
+				buildJob("big", "1h", "8"),
+				buildJob("big2", "1h", "8"),
+				buildJob("short", "20m", "2"),
+				buildJob("long", "1h", "2"),
+				buildJob("unknown", "", "2"),
+			}
+			jobMap := map[api.JobID]*api.JobInfo{running.UID: running}
+			for _, job := range jobs {
+				jobMap[job.UID] = job
+			}
+
+			r := newReservation(test.policy, now, 0, []*api.NodeInfo{node}, jobMap)
+			var permitted []string
+			for _, job := range jobs {
+				if r.permits(job) {
+					permitted = append(permitted, job.Name)
+					r.place(job)
+					continue
+				}
+				r.block(job)
+			}
+			assert.Equal(t, test.permitted, permitted)
+
+			reserved := map[string]time.Duration{}
+			for uid, start := range r.reserved {
+				reserved[jobMap[uid].Name] = start.Sub(now)
+			}
+			assert.Equal(t, test.expectReserved, reserved)
+		})
+	}
+}
+
+func TestLearnRuntime(t *testing.T) {
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	completed := buildJob("completed", "", "1")
+	completed.PodGroup.Labels = map[string]string{RuntimeGroupLabel: "nightly"}
+	for _, task := range completed.Tasks {
+		task.Pod.Status.StartTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+		task.Pod.Status.ContainerStatuses = []v1.ContainerStatus{{State: v1.ContainerState{
+			Terminated: &v1.ContainerStateTerminated{FinishedAt: metav1.Time{Time: now.Add(-30 * time.Minute)}},
+		}}}
+		completed.UpdateTaskStatus(task, api.Succeeded)
+	}
+	pending := buildJob("pending", "", "1")
+	pending.PodGroup.Labels = map[string]string{RuntimeGroupLabel: "nightly"}
+
+	jobs := map[api.JobID]*api.JobInfo{completed.UID: completed, pending.UID: pending}
+	r := newReservation(conf.BackfillPolicyEasy, now, time.Hour, nil, jobs)
+	assert.Equal(t, 90*time.Minute, r.estimate(pending), "runtime should be learned from the completed job of the group")
+	assert.Equal(t, time.Hour, r.estimate(buildJob("other", "", "1")), "default estimate should be used")
+	assert.Equal(t, 10*time.Minute, r.estimate(buildJob("annotated", "10m", "1")))
+
+	// the completed job is learned once
+	newReservation(conf.BackfillPolicyEasy, now, 0, nil, jobs)
+	assert.Len(t, history.runtimes["nightly"], 1)
+}
+
+func TestBackfillWithReservation(t *testing.T) {
+	options.Default()
+	defer func() { timeNow = time.Now }()
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+
+	plugins := map[string]framework.PluginBuilder{gang.PluginName: gang.New, predicates.PluginName: predicates.New}
+	trueValue := true
+
+	buildPods := func() []*v1.Pod {
+		// the running pod frees 2 cpus in 30 minutes, the big job needs 4
+		running := util.BuildPod("ns1", "p0", "n1", v1.PodRunning, api.BuildResourceList("2", "2Gi"), "pg0", nil, nil)
+		running.Status.StartTime = &metav1.Time{Time: now.Add(-30 * time.Minute)}
+		return []*v1.Pod{
+			running,
+			util.BuildPod("ns1", "p1-0", "", v1.PodPending, api.BuildResourceList("2", "2Gi"), "pg1", nil, nil),
+			util.BuildPod("ns1", "p1-1", "", v1.PodPending, api.BuildResourceList("2", "2Gi"), "pg1", nil, nil),
+			util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("2", "2Gi"), "pg2", nil, nil),
+			util.BuildPod("ns1", "p3", "", v1.PodPending, api.BuildResourceList("2", "2Gi"), "pg3", nil, nil),
+		}
+	}
+	buildPodGroup := func(name string, minMember int32, runtime string) *schedulingv1beta1.PodGroup {
+		pg := util.BuildPodGroup(name, "ns1", "q1", minMember, nil, schedulingv1beta1.PodGroupInqueue)
+		pg.Annotations = map[string]string{RuntimeEstimateAnnotation: runtime}
+		return pg
+	}
+	podGroups := []*schedulingv1beta1.PodGroup{
+		buildPodGroup("pg0", 1, "1h"),
+		buildPodGroup("pg1", 2, "1h"),
+		buildPodGroup("pg2", 1, "2h"),
+		buildPodGroup("pg3", 1, "10m"),
+	}
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+	q1 := util.BuildQueue("q1", 1, nil)
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		arguments framework.Arguments
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "without reservation the next job takes the freed resources",
+				Plugins:        plugins,
+				Pods:           buildPods(),
+				Nodes:          []*v1.Node{n1},
+				PodGroups:      podGroups,
+				Queues:         []*schedulingv1beta1.Queue{q1},
+				ExpectBindMap:  map[string]string{"ns1/p2": "n1"},
+				ExpectBindsNum: 1,
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "with reservation only the job ending before the reserved start is backfilled",
+				Plugins:        plugins,
+				Pods:           buildPods(),
+				Nodes:          []*v1.Node{n1},
+				PodGroups:      podGroups,
+				Queues:         []*schedulingv1beta1.Queue{q1},
+				ExpectBindMap:  map[string]string{"ns1/p3": "n1"},
+				ExpectBindsNum: 1,
+			},
+			arguments: framework.Arguments{conf.BackfillPolicyKey: conf.BackfillPolicyEasy},
+		},
+	}
+
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:                gang.PluginName,
+					EnabledJobReady:     &trueValue,
+					EnabledJobPipelined: &trueValue,
+				},
+				{
+					Name:             predicates.PluginName,
+					EnabledPredicate: &trueValue,
+				},
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers, []conf.Configuration{{Name: "backfill", Arguments: test.arguments}})
+			defer test.Close()
+			test.Run([]framework.Action{allocate.New(), New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+const (
+	// Elastic indicates the action name
+	Elastic = "elastic"
+)
+
+// Action shrinks the elastic jobs, which run more pods than their minAvailable, to free resources for the
+// starving jobs, and grows them back when no job is starving. The number of pods the elastic job is allowed
+// to run is published on its podgroup for the job controller.
+type Action struct{}
+
+// New returns the action instance
+func New() *Action {
+	return &Action{}
+}
+
+// Name returns the action name
+func (ea *Action) Name() string {
+	return Elastic
+}
+
+// Initialize inits the action
+func (ea *Action) Initialize() {}
+
+// Execute shrinks the elastic jobs for the starving jobs, or grows them if no job is starving.
+func (ea *Action) Execute(ssn *framework.Session) {
+	klog.V(5).Infof("Enter Elastic ...")
+	defer klog.V(5).Infof("Leaving Elastic ...")
+
+	starvingJobs := util.NewPriorityQueue(ssn.JobOrderFn)
+	for _, job := range ssn.Jobs {
+		if job.IsPending() || !job.HasPendingTasks() {
+			continue
+		}
+		if vr := ssn.JobValid(job); vr != nil && !vr.Pass {
+			klog.V(4).Infof("Job <%s/%s> Queue <%s> skip elastic, reason: %v, message %v", job.Namespace, job.Name, job.Queue, vr.Reason, vr.Message)
+			continue
+		}
+		if _, found := ssn.Queues[job.Queue]; !found {
+			continue
+		}
+		if ssn.JobStarving(job) {
+			starvingJobs.Push(job)
+		}
+	}
+
+	if starvingJobs.Empty() {
+		ea.grow(ssn)
+		return
+	}
+
+	idle := api.EmptyResource()
+	for _, node := range ssn.Nodes {
+		idle.Add(node.FutureIdle())
+	}
+	for !starvingJobs.Empty() {
+		job := starvingJobs.Pop().(*api.JobInfo)
+		ea.shrink(ssn, job, idle)
+	}
+}
+
+// shrink evicts the surplus tasks of the elastic jobs until the idle resources of the cluster are enough for the
+// tasks the starving job misses to reach its minAvailable.
+func (ea *Action) shrink(ssn *framework.Session, job *api.JobInfo, idle *api.Resource) {
+	tasks := util.NewPriorityQueue(ssn.TaskOrderFn)
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		if task.SchGated {
+			continue
+		}
+		tasks.Push(task)
+	}
+
+	var preemptor *api.TaskInfo
+	request := api.EmptyResource()
+	for missing := job.MinAvailable - job.ReadyTaskNum() - job.WaitingTaskNum(); missing > 0 && !tasks.Empty(); missing-- {
+		task := tasks.Pop().(*api.TaskInfo)
+		if preemptor == nil {
+			preemptor = task
+		}
+		request.Add(task.Resreq)
+	}
+	if preemptor == nil {
+		return
+	}
+	if request.LessEqual(idle, api.Zero) {
+		idle.Sub(request)
+		return
+	}
+
+	freed := idle.Clone()
+	evictees := map[api.JobID][]*api.TaskInfo{}
+	victimsQueue := ssn.BuildVictimsPriorityQueue(ea.victims(ssn, job, preemptor), preemptor)
+	for !victimsQueue.Empty() && !request.LessEqual(freed, api.Zero) {
+		victim := victimsQueue.Pop().(*api.TaskInfo)
+		evictees[victim.Job] = append(evictees[victim.Job], victim)
+		freed.Add(victim.Resreq)
+	}
+	if !request.LessEqual(freed, api.Zero) {
+		klog.V(3).Infof("Elastic jobs can not be shrunk enough for job <%s/%s>, request <%v>, freed <%v>",
+			job.Namespace, job.Name, request, freed)
+		return
+	}
+
+	stmt := framework.NewStatement(ssn)
+	for uid, victims := range evictees {
+		victimJob := ssn.Jobs[uid]
+		replicas := activeTaskNum(victimJob)
+		maxReplicas := replicas
+		if _, elasticMax, found := elasticReplicas(victimJob); found {
+			maxReplicas = elasticMax
+		}
+		// the replicas is published before the eviction, so that the job controller doesn't recreate the pods
+		if err := ea.updateReplicas(ssn, victimJob, replicas-len(victims), maxReplicas); err != nil {
+			klog.Errorf("Failed to shrink job <%s/%s>: %v", victimJob.Namespace, victimJob.Name, err)
+			continue
+		}
+		for _, victim := range victims {
+			klog.V(3).Infof("Shrink job <%s/%s> by evicting Task <%s/%s> for job <%s/%s>",
+				victimJob.Namespace, victimJob.Name, victim.Namespace, victim.Name, job.Namespace, job.Name)
+			if err := stmt.Evict(victim, api.ElasticShrinkReason); err != nil {
+				klog.Errorf("Failed to evict Task <%s/%s>: %v", victim.Namespace, victim.Name, err)
+				continue
+			}
+			idle.Add(victim.Resreq)
+		}
+	}
+	stmt.Commit()
+
+	if request.LessEqual(idle, api.Zero) {
+		idle.Sub(request)
+	}
+}
+
+// victims returns the running tasks of the elastic jobs above their minAvailable which the preemptor may preempt
+// in its queue or reclaim from the other queues, as the preemption policies of the queues allow.
+func (ea *Action) victims(ssn *framework.Session, job *api.JobInfo, preemptor *api.TaskInfo) []*api.TaskInfo {
+	var preemptees, reclaimees []*api.TaskInfo
+	for _, elasticJob := range ssn.Jobs {
+		// the jobs which have pending tasks are not shrunk, their pods would take the resources freed by the shrink
+		if elasticJob.UID == job.UID || elasticJob.HasPendingTasks() || elasticJob.ReadyTaskNum() <= elasticJob.MinAvailable {
+			continue
+		}
+		queue, found := ssn.Queues[elasticJob.Queue]
+		if !found {
+			continue
+		}
+		for _, task := range elasticJob.TaskStatusIndex[api.Running] {
+			if !task.Preemptable {
+				continue
+			}
+			if elasticJob.Queue == job.Queue {
+				preemptees = append(preemptees, task.Clone())
+			} else if queue.Reclaimable() {
+				reclaimees = append(reclaimees, task.Clone())
+			}
+		}
+	}
+
+	// the candidates are given in the order of eviction, so that the plugins which allow only part of the tasks
+	// of a job to be victims, e.g. gang, keep the tasks of the lowest index
+	var victims []*api.TaskInfo
+	if len(preemptees) != 0 {
+		victims = append(victims, ssn.Preemptable(preemptor, victimOrder(ssn, preemptees, preemptor))...)
+	}
+	if len(reclaimees) != 0 {
+		victims = append(victims, ssn.Reclaimable(preemptor, victimOrder(ssn, reclaimees, preemptor))...)
+	}
+	return ssn.FilterVictimsByQueuePolicy(preemptor, victims)
+}
+
+func victimOrder(ssn *framework.Session, tasks []*api.TaskInfo, preemptor *api.TaskInfo) []*api.TaskInfo {
+	queue := ssn.BuildVictimsPriorityQueue(tasks, preemptor)
+	ordered := make([]*api.TaskInfo, 0, len(tasks))
+	for !queue.Empty() {
+		ordered = append(ordered, queue.Pop().(*api.TaskInfo))
+	}
+	return ordered
+}
+
+// grow raises the replicas of the shrunk jobs by the tasks which fit on the idle resources of the nodes.
+func (ea *Action) grow(ssn *framework.Session) {
+	idle := map[string]*api.Resource{}
+	for _, node := range ssn.NodeList {
+		idle[node.Name] = node.FutureIdle()
+	}
+
+	jobs := util.NewPriorityQueue(ssn.JobOrderFn)
+	for _, job := range ssn.Jobs {
+		if _, _, found := elasticReplicas(job); found {
+			jobs.Push(job)
+		}
+	}
+	for !jobs.Empty() {
+		job := jobs.Pop().(*api.JobInfo)
+		replicas, maxReplicas, _ := elasticReplicas(job)
+		// the pods of the last growth are not placed yet
+		if job.HasPendingTasks() || activeTaskNum(job) < replicas {
+			continue
+		}
+
+		// the tasks of the highest index are shrunk first, so they are the ones to grow
+		var sample *api.TaskInfo
+		for _, task := range job.TaskStatusIndex[api.Running] {
+			if sample == nil || ssn.TaskOrderFn(sample, task) {
+				sample = task
+			}
+		}
+		if sample == nil {
+			continue
+		}
+		if err := ssn.PrePredicateFn(sample); err != nil {
+			klog.V(4).Infof("PrePredicate for task %s/%s failed for: %v", sample.Namespace, sample.Name, err)
+			continue
+		}
+
+		grown := 0
+		for _, node := range ssn.NodeList {
+			if replicas+grown >= maxReplicas {
+				break
+			}
+			if !sample.Resreq.LessEqual(idle[node.Name], api.Zero) || ssn.PredicateFn(sample, node) != nil {
+				continue
+			}
+			for replicas+grown < maxReplicas && sample.Resreq.LessEqual(idle[node.Name], api.Zero) {
+				idle[node.Name].Sub(sample.Resreq)
+				grown++
+			}
+		}
+		if grown == 0 {
+			continue
+		}
+
+		klog.V(3).Infof("Grow job <%s/%s> from %d to %d replicas", job.Namespace, job.Name, replicas, replicas+grown)
+		if err := ea.updateReplicas(ssn, job, replicas+grown, maxReplicas); err != nil {
+			klog.Errorf("Failed to grow job <%s/%s>: %v", job.Namespace, job.Name, err)
+		}
+	}
+}
+
+// updateReplicas publishes the replicas of the elastic job on its podgroup, both annotations are removed once the
+// job is grown back to its max replicas. The podgroup of the session is changed at once, the podgroup in the cluster
+// is updated in the background on its latest version, so that neither the session waits for the api server nor a
+// conflict with another update of the podgroup drops the resize.
+func (ea *Action) updateReplicas(ssn *framework.Session, job *api.JobInfo, replicas, maxReplicas int) error {
+	client := ssn.VCClient()
+	if client == nil || job.PodGroup == nil {
+		return fmt.Errorf("podgroup of job <%s/%s> can not be updated", job.Namespace, job.Name)
+	}
+
+	annotations := make(map[string]string, len(job.PodGroup.Annotations)+2)
+	for key, value := range job.PodGroup.Annotations {
+		annotations[key] = value
+	}
+	setReplicas(annotations, replicas, maxReplicas)
+	job.PodGroup.Annotations = annotations
+
+	namespace, name := job.PodGroup.Namespace, job.PodGroup.Name
+	go func() {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			podgroup, err := client.SchedulingV1beta1().PodGroups(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if podgroup.Annotations == nil {
+				podgroup.Annotations = map[string]string{}
+			}
+			setReplicas(podgroup.Annotations, replicas, maxReplicas)
+			_, err = client.SchedulingV1beta1().PodGroups(namespace).Update(context.TODO(), podgroup, metav1.UpdateOptions{})
+			return err
+		})
+		if err != nil {
+			klog.Errorf("Failed to update the replicas of podgroup <%s/%s> to %d: %v", namespace, name, replicas, err)
+		}
+	}()
+	return nil
+}
+
+func setReplicas(annotations map[string]string, replicas, maxReplicas int) {
+	if replicas >= maxReplicas {
+		delete(annotations, api.ElasticReplicasAnnotation)
+		delete(annotations, api.ElasticMaxReplicasAnnotation)
+		return
+	}
+	annotations[api.ElasticReplicasAnnotation] = strconv.Itoa(replicas)
+	annotations[api.ElasticMaxReplicasAnnotation] = strconv.Itoa(maxReplicas)
+}
+
+// elasticReplicas returns the replicas published for the shrunk job and the replicas it is grown back to.
+func elasticReplicas(job *api.JobInfo) (int, int, bool) {
+	if job.PodGroup == nil {
+		return 0, 0, false
+	}
+	replicas, err := strconv.Atoi(job.PodGroup.Annotations[api.ElasticReplicasAnnotation])
+	if err != nil {
+		return 0, 0, false
+	}
+	maxReplicas, err := strconv.Atoi(job.PodGroup.Annotations[api.ElasticMaxReplicasAnnotation])
+	if err != nil {
+		return 0, 0, false
+	}
+	return replicas, maxReplicas, true
+}
+
+// activeTaskNum returns the number of the pods of the job which are neither being deleted nor completed, as the job
+// controller counts them.
+func activeTaskNum(job *api.JobInfo) int {
+	return len(job.Tasks) - len(job.TaskStatusIndex[api.Releasing]) -
+		len(job.TaskStatusIndex[api.Succeeded]) - len(job.TaskStatusIndex[api.Failed])
+}
+
+// UnInitialize releases resource which is not useful.
+func (ea *Action) UnInitialize() {}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elastic
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/priority"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestElastic(t *testing.T) {
+	options.Default()
+
+	plugins := map[string]framework.PluginBuilder{
+		gang.PluginName:     gang.New,
+		priority.PluginName: priority.New,
+	}
+	highPrio := util.BuildPriorityClass("high-priority", 100000)
+	lowPrio := util.BuildPriorityClass("low-priority", 10)
+
+	buildNode := func(cpu string) *v1.Node {
+		return util.BuildNode("n1", api.BuildResourceList(cpu, "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)
+	}
+	buildElasticPods := func(num int) []*v1.Pod {
+		var pods []*v1.Pod
+		for _, name := range []string{"elastic-0", "elastic-1", "elastic-2", "elastic-3"}[:num] {
+			pods = append(pods, util.BuildPod("ns1", name, "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+		}
+		return pods
+	}
+	buildGangPods := func() []*v1.Pod {
+		return []*v1.Pod{
+			util.BuildPod("ns1", "gang-0", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+			util.BuildPod("ns1", "gang-1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+		}
+	}
+	buildElasticPodGroup := func(minMember int32, annotations map[string]string) *schedulingv1beta1.PodGroup {
+		pg := util.BuildPodGroupWithPrio("pg1", "ns1", "q1", minMember, nil, schedulingv1beta1.PodGroupRunning, "low-priority")
+		pg.Annotations = annotations
+		return pg
+	}
+	gangPodGroup := util.BuildPodGroupWithPrio("pg2", "ns1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue, "high-priority")
+	q1 := util.BuildQueue("q1", 1, nil)
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		expectAnnotations map[string]string
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "elastic job is shrunk to free resources for the starving gang job",
+				Pods:           append(buildElasticPods(4), buildGangPods()...),
+				Nodes:          []*v1.Node{buildNode("4")},
+				PodGroups:      []*schedulingv1beta1.PodGroup{buildElasticPodGroup(1, nil), gangPodGroup},
+				Queues:         []*schedulingv1beta1.Queue{q1},
+				ExpectEvicted:  []string{"ns1/elastic-3", "ns1/elastic-2"},
+				ExpectEvictNum: 2,
+			},
+			expectAnnotations: map[string]string{
+				api.ElasticReplicasAnnotation:    "2",
+				api.ElasticMaxReplicasAnnotation: "4",
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "completed tasks are not counted in the replicas of the shrunk job",
+				Pods: append(append(buildElasticPods(4), buildGangPods()...),
+					util.BuildPod("ns1", "elastic-done", "n1", v1.PodSucceeded, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)),
+				Nodes:          []*v1.Node{buildNode("4")},
+				PodGroups:      []*schedulingv1beta1.PodGroup{buildElasticPodGroup(1, nil), gangPodGroup},
+				Queues:         []*schedulingv1beta1.Queue{q1},
+				ExpectEvicted:  []string{"ns1/elastic-3", "ns1/elastic-2"},
+				ExpectEvictNum: 2,
+			},
+			expectAnnotations: map[string]string{
+				api.ElasticReplicasAnnotation:    "2",
+				api.ElasticMaxReplicasAnnotation: "4",
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "elastic job is not shrunk below its minAvailable",
+				Pods:      append(buildElasticPods(4), buildGangPods()...),
+				Nodes:     []*v1.Node{buildNode("4")},
+				PodGroups: []*schedulingv1beta1.PodGroup{buildElasticPodGroup(3, nil), gangPodGroup},
+				Queues:    []*schedulingv1beta1.Queue{q1},
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:  "shrunk job is grown by the idle resources",
+				Pods:  buildElasticPods(2),
+				Nodes: []*v1.Node{buildNode("3")},
+				PodGroups: []*schedulingv1beta1.PodGroup{buildElasticPodGroup(1, map[string]string{
+					api.ElasticReplicasAnnotation:    "2",
+					api.ElasticMaxReplicasAnnotation: "4",
+				})},
+				Queues: []*schedulingv1beta1.Queue{q1},
+			},
+			expectAnnotations: map[string]string{
+				api.ElasticReplicasAnnotation:    "3",
+				api.ElasticMaxReplicasAnnotation: "4",
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:  "shrunk job is grown back to its max replicas",
+				Pods:  buildElasticPods(2),
+				Nodes: []*v1.Node{buildNode("4")},
+				PodGroups: []*schedulingv1beta1.PodGroup{buildElasticPodGroup(1, map[string]string{
+					api.ElasticReplicasAnnotation:    "2",
+					api.ElasticMaxReplicasAnnotation: "4",
+				})},
+				Queues: []*schedulingv1beta1.Queue{q1},
+			},
+		},
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               gang.PluginName,
+					EnabledPreemptable: &trueValue,
+					EnabledJobStarving: &trueValue,
+				},
+				{
+					Name:               priority.PluginName,
+					EnabledTaskOrder:   &trueValue,
+					EnabledJobOrder:    &trueValue,
+					EnabledPreemptable: &trueValue,
+					EnabledJobStarving: &trueValue,
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		test.Plugins = plugins
+		test.PriClass = []*schedulingv1.PriorityClass{highPrio, lowPrio}
+		t.Run(test.Name, func(t *testing.T) {
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+			for _, pg := range test.PodGroups {
+				if _, err := ssn.VCClient().SchedulingV1beta1().PodGroups(pg.Namespace).Create(context.TODO(), pg, metav1.CreateOptions{}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			test.Run([]framework.Action{New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+
+			// the podgroup is updated in the background
+			assert.Eventually(t, func() bool {
+				pg, err := ssn.VCClient().SchedulingV1beta1().PodGroups("ns1").Get(context.TODO(), "pg1", metav1.GetOptions{})
+				if err != nil {
+					return false
+				}
+				var annotations map[string]string
+				for _, key := range []string{api.ElasticReplicasAnnotation, api.ElasticMaxReplicasAnnotation} {
+					if value, found := pg.Annotations[key]; found {
+						if annotations == nil {
+							annotations = map[string]string{}
+						}
+						annotations[key] = value
+					}
+				}
+				return reflect.DeepEqual(test.expectAnnotations, annotations)
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
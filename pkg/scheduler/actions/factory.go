@@ -19,6 +19,7 @@ package actions
 import (
 	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
 	"volcano.sh/volcano/pkg/scheduler/actions/backfill"
+	"volcano.sh/volcano/pkg/scheduler/actions/elastic"
 	"volcano.sh/volcano/pkg/scheduler/actions/enqueue"
 	"volcano.sh/volcano/pkg/scheduler/actions/preempt"
 	"volcano.sh/volcano/pkg/scheduler/actions/reclaim"
@@ -33,4 +34,5 @@ func init() {
 	framework.RegisterAction(preempt.New())
 	framework.RegisterAction(enqueue.New())
 	framework.RegisterAction(shuffle.New())
+	framework.RegisterAction(elastic.New())
 }
@@ -268,7 +268,7 @@ func (pmpt *Action) preempt(
 				preemptees = append(preemptees, task.Clone())
 			}
 		}
-		victims := ssn.Preemptable(preemptor, preemptees)
+		victims := ssn.FilterVictimsByQueuePolicy(preemptor, ssn.Preemptable(preemptor, preemptees))
 		metrics.UpdatePreemptionVictimsCount(len(victims))
 
 		if err := util.ValidateVictims(preemptor, node, victims); err != nil {
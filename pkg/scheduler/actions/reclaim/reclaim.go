@@ -175,7 +175,7 @@ func (ra *Action) Execute(ssn *framework.Session) {
 				continue
 			}
 
-			victims := ssn.Reclaimable(task, reclaimees)
+			victims := ssn.FilterVictimsByQueuePolicy(task, ssn.Reclaimable(task, reclaimees))
 
 			if err := util.ValidateVictims(task, n, victims); err != nil {
 				klog.V(3).Infof("No validated victims on Node <%s>: %v", n.Name, err)
@@ -20,6 +20,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 )
 
@@ -29,7 +30,10 @@ const (
 )
 
 // Action defines the action
-type Action struct{}
+type Action struct {
+	// dryRun only reports the victims instead of evicting them
+	dryRun bool
+}
 
 // New returns the action instance
 func New() *Action {
@@ -44,11 +48,19 @@ func (shuffle *Action) Name() string {
 // Initialize inits the action
 func (shuffle *Action) Initialize() {}
 
+func (shuffle *Action) parseArguments(ssn *framework.Session) {
+	shuffle.dryRun = false
+	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, shuffle.Name())
+	arguments.GetBool(&shuffle.dryRun, conf.DryRunKey)
+}
+
 // Execute select evictees according given strategies and evict them.
 func (shuffle *Action) Execute(ssn *framework.Session) {
 	klog.V(5).Infoln("Enter Shuffle ...")
 	defer klog.V(5).Infoln("Leaving Shuffle ...")
 
+	shuffle.parseArguments(ssn)
+
 	// select pods that may be evicted
 	tasks := make([]*api.TaskInfo, 0)
 	for _, jobInfo := range ssn.Jobs {
@@ -61,6 +73,14 @@ func (shuffle *Action) Execute(ssn *framework.Session) {
 
 	// Evict target workloads
 	victims := ssn.VictimTasks(tasks)
+	if shuffle.dryRun {
+		dryRunVictims := make([]*api.TaskInfo, 0, len(victims))
+		for victim := range victims {
+			dryRunVictims = append(dryRunVictims, victim)
+		}
+		ssn.ReportDryRunVictims(Shuffle, dryRunVictims)
+		return
+	}
 	for victim := range victims {
 		klog.V(3).Infof("pod %s from namespace %s and job %s will be evicted.\n", victim.Name, victim.Namespace, string(victim.Job))
 		if err := ssn.Evict(victim, "shuffle"); err != nil {
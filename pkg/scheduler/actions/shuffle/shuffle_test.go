@@ -119,3 +119,62 @@ func TestShuffle(t *testing.T) {
 		})
 	}
 }
+
+func TestShuffleDryRun(t *testing.T) {
+	var lowPriority int32 = 10
+
+	ctl := gomock.NewController(t)
+	fakePlugin := mock_framework.NewMockPlugin(ctl)
+	fakePlugin.EXPECT().Name().AnyTimes().Return("fake")
+	fakePlugin.EXPECT().OnSessionOpen(gomock.Any()).Return()
+	fakePlugin.EXPECT().OnSessionClose(gomock.Any()).Return()
+	plugins := map[string]framework.PluginBuilder{"fake": func(arguments framework.Arguments) framework.Plugin {
+		return fakePlugin
+	}}
+
+	test := uthelper.TestCommonStruct{
+		Name:    "victims are only reported in dry-run mode",
+		Plugins: plugins,
+		Nodes: []*v1.Node{
+			util.BuildNode("node1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+		},
+		Queues: []*schedulingv1beta1.Queue{
+			util.BuildQueue("default", 1, nil),
+		},
+		PodGroups: []*schedulingv1beta1.PodGroup{
+			util.BuildPodGroup("pg1", "test", "default", 0, nil, schedulingv1beta1.PodGroupRunning),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPodWithPriority("test", "pod1-1", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg1", make(map[string]string), make(map[string]string), &lowPriority),
+			util.BuildPodWithPriority("test", "pod1-2", "node1", v1.PodRunning, api.BuildResourceList("1", "2G"), "pg1", make(map[string]string), make(map[string]string), &lowPriority),
+		},
+		ExpectEvictNum: 0,
+		ExpectEvicted:  []string{},
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{Name: "fake", EnabledVictim: &trueValue}}}}
+	configurations := []conf.Configuration{{Name: Shuffle, Arguments: map[string]interface{}{conf.DryRunKey: true}}}
+
+	ssn := test.RegisterSession(tiers, configurations)
+	defer test.Close()
+	recorder := framework.NewDryRunRecorder(1)
+	ssn.SetDryRunRecorder(recorder)
+	ssn.AddVictimTasksFns("fake", []api.VictimTasksFn{func(candidates []*api.TaskInfo) []*api.TaskInfo {
+		return candidates
+	}})
+	test.Run([]framework.Action{New()})
+	if err := test.CheckEvict(0); err != nil {
+		t.Fatal(err)
+	}
+
+	reports := recorder.Reports()
+	if len(reports) != 1 || reports[0].Action != Shuffle || len(reports[0].Victims) != 2 {
+		t.Fatalf("unexpected dry-run reports: %+v", reports)
+	}
+	for _, victim := range reports[0].Victims {
+		if len(victim.Reasons) != 1 || victim.Reasons[0] != "fake" {
+			t.Errorf("unexpected reasons of victim %s: %v", victim.Name, victim.Reasons)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake is a device family of exclusive devices for unit tests, it shows how a device family
+// plugs into the deviceshare plugin with its own annotations, policies and node-side bookkeeping.
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api/devices"
+)
+
+const (
+	// DeviceName used to indicate this device
+	DeviceName = "fake"
+	// ResourceName is the extended resource of the fake devices, its allocatable is the number of devices of the node
+	ResourceName v1.ResourceName = "volcano.sh/fake-device"
+	// AssignedIDsAnnotation is the key of the ids of the devices assigned to the pod, e.g. "0,2"
+	AssignedIDsAnnotation = "volcano.sh/fake-device-ids"
+	// EnableArgument is the key of the deviceshare argument which enables the fake devices
+	EnableArgument = "deviceshare.FakeDeviceEnable"
+
+	binpackPolicy = "binpack"
+	spreadPolicy  = "spread"
+)
+
+// Enable indicates whether the fake devices are scheduled
+var Enable bool
+
+// Devices is the fake devices of a node, each device is used by one pod at most.
+type Devices struct {
+	Name string
+	// Total is the number of the devices
+	Total int
+	// Used is the pods using the devices, by device id
+	Used map[int]types.UID
+}
+
+// NewDevices creates the fake devices of the node, it returns nil if the node has none.
+func NewDevices(name string, node *v1.Node) *Devices {
+	if node == nil {
+		return nil
+	}
+	quantity, ok := node.Status.Allocatable[ResourceName]
+	if !ok || quantity.Value() <= 0 {
+		return nil
+	}
+	return &Devices{
+		Name:  name,
+		Total: int(quantity.Value()),
+		Used:  map[int]types.UID{},
+	}
+}
+
+// Configure enables the fake devices by the arguments of the deviceshare plugin.
+func Configure(arguments map[string]interface{}) {
+	if value, ok := arguments[EnableArgument].(bool); ok {
+		Enable = value
+	}
+}
+
+// request returns the number of the devices requested by the pod.
+func request(pod *v1.Pod) int {
+	count := int64(0)
+	for _, container := range pod.Spec.Containers {
+		if quantity, ok := container.Resources.Limits[ResourceName]; ok {
+			count += quantity.Value()
+		} else if quantity, ok := container.Resources.Requests[ResourceName]; ok {
+			count += quantity.Value()
+		}
+	}
+	return int(count)
+}
+
+// assignedIDs returns the ids of the devices assigned to the pod.
+func assignedIDs(pod *v1.Pod) []int {
+	value, ok := pod.Annotations[AssignedIDsAnnotation]
+	if !ok || value == "" {
+		return nil
+	}
+	var ids []int
+	for _, field := range strings.Split(value, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			klog.Warningf("Invalid %s %q of pod <%s/%s>", AssignedIDsAnnotation, value, pod.Namespace, pod.Name)
+			return nil
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (ds *Devices) free() []int {
+	ids := make([]int, 0, ds.Total)
+	for id := 0; id < ds.Total; id++ {
+		if _, used := ds.Used[id]; !used {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// AddResource marks the devices assigned to the pod as used
+func (ds *Devices) AddResource(pod *v1.Pod) {
+	if ds == nil {
+		return
+	}
+	for _, id := range assignedIDs(pod) {
+		if id < ds.Total {
+			ds.Used[id] = pod.UID
+		}
+	}
+}
+
+// SubResource frees the devices used by the pod
+func (ds *Devices) SubResource(pod *v1.Pod) {
+	if ds == nil {
+		return
+	}
+	for _, id := range assignedIDs(pod) {
+		if ds.Used[id] == pod.UID {
+			delete(ds.Used, id)
+		}
+	}
+}
+
+// HasDeviceRequest checks whether the pod requests fake devices
+func (ds *Devices) HasDeviceRequest(pod *v1.Pod) bool {
+	return Enable && request(pod) > 0
+}
+
+// FilterNode checks whether there are enough free devices for the pod
+func (ds *Devices) FilterNode(pod *v1.Pod, policy string) (int, string, error) {
+	if !Enable {
+		return devices.Success, "", nil
+	}
+	if ds == nil {
+		return devices.UnschedulableAndUnresolvable, "no fake devices on node", fmt.Errorf("no fake devices on node")
+	}
+	if requested, free := request(pod), len(ds.free()); requested > free {
+		return devices.Unschedulable, fmt.Sprintf("insufficient fake devices, requested %d, free %d", requested, free),
+			fmt.Errorf("insufficient fake devices")
+	}
+	return devices.Success, "", nil
+}
+
+// ScoreNode scores the node by the devices used after the pod is placed: binpack prefers the nodes
+// with more devices used, and spread the nodes with less.
+func (ds *Devices) ScoreNode(pod *v1.Pod, policy string) float64 {
+	if ds == nil || ds.Total == 0 {
+		return 0
+	}
+	used := float64(len(ds.Used)+request(pod)) / float64(ds.Total)
+	switch policy {
+	case binpackPolicy:
+		return used * 100
+	case spreadPolicy:
+		return (1 - used) * 100
+	default:
+		return 0
+	}
+}
+
+// Allocate assigns the free devices of the lowest ids to the pod, and records them in the annotation of the pod
+func (ds *Devices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) error {
+	if ds == nil {
+		return fmt.Errorf("no fake devices on node")
+	}
+	requested, free := request(pod), ds.free()
+	if requested > len(free) {
+		return fmt.Errorf("insufficient fake devices on node %s, requested %d, free %d", ds.Name, requested, len(free))
+	}
+	ids := free[:requested]
+	sort.Ints(ids)
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.Itoa(id))
+	}
+	value := strings.Join(values, ",")
+	if err := patchAssignedIDs(kubeClient, pod, &value); err != nil {
+		return err
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[AssignedIDsAnnotation] = value
+	ds.AddResource(pod)
+	return nil
+}
+
+// Release frees the devices assigned to the pod, and removes them from the annotation of the pod
+func (ds *Devices) Release(kubeClient kubernetes.Interface, pod *v1.Pod) error {
+	ds.SubResource(pod)
+	if err := patchAssignedIDs(kubeClient, pod, nil); err != nil {
+		return err
+	}
+	delete(pod.Annotations, AssignedIDsAnnotation)
+	return nil
+}
+
+// GetIgnoredDevices returns the resources ignored by the scheduler, the devices are checked by the deviceshare plugin
+func (ds *Devices) GetIgnoredDevices() []string {
+	return []string{string(ResourceName)}
+}
+
+// GetStatus returns the devices used
+func (ds *Devices) GetStatus() string {
+	if ds == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d fake devices used on node %s", len(ds.Used), ds.Total, ds.Name)
+}
+
+func patchAssignedIDs(kubeClient kubernetes.Interface, pod *v1.Pod, value *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{AssignedIDsAnnotation: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := kubeClient.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("patch pod %s/%s failed: %v", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"volcano.sh/volcano/pkg/scheduler/api/devices"
+)
+
+func buildPod(name string, count string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: name, UID: types.UID(name)},
+		Spec: v1.PodSpec{Containers: []v1.Container{{
+			Resources: v1.ResourceRequirements{Limits: v1.ResourceList{ResourceName: resource.MustParse(count)}},
+		}}},
+	}
+}
+
+func TestDevices(t *testing.T) {
+	Enable = true
+	defer func() { Enable = false }()
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+		Status:     v1.NodeStatus{Allocatable: v1.ResourceList{ResourceName: resource.MustParse("4")}},
+	}
+	assert.Nil(t, NewDevices("n0", &v1.Node{}))
+	ds := NewDevices("n1", node)
+	assert.Equal(t, 4, ds.Total)
+
+	p1, p2 := buildPod("p1", "3"), buildPod("p2", "2")
+	kubeClient := fake.NewSimpleClientset(p1, p2)
+
+	assert.True(t, ds.HasDeviceRequest(p1))
+	code, _, err := ds.FilterNode(p1, binpackPolicy)
+	assert.Equal(t, devices.Success, code)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(75), ds.ScoreNode(p1, binpackPolicy))
+	assert.Equal(t, float64(25), ds.ScoreNode(p1, spreadPolicy))
+
+	assert.NoError(t, ds.Allocate(kubeClient, p1))
+	assert.Equal(t, "0,1,2", p1.Annotations[AssignedIDsAnnotation])
+	patched, err := kubeClient.CoreV1().Pods("c1").Get(context.TODO(), "p1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "0,1,2", patched.Annotations[AssignedIDsAnnotation])
+
+	// only one device is left
+	code, _, err = ds.FilterNode(p2, binpackPolicy)
+	assert.Equal(t, devices.Unschedulable, code)
+	assert.Error(t, err)
+
+	assert.NoError(t, ds.Release(kubeClient, p1))
+	assert.Empty(t, ds.Used)
+	assert.NoError(t, ds.Allocate(kubeClient, p2))
+	assert.Equal(t, "0,1", p2.Annotations[AssignedIDsAnnotation])
+
+	// the node-side bookkeeping is rebuilt from the annotations of the pods
+	rebuilt := NewDevices("n1", node)
+	rebuilt.AddResource(p2)
+	assert.Equal(t, map[int]types.UID{0: "p2", 1: "p2"}, rebuilt.Used)
+	rebuilt.SubResource(p2)
+	assert.Empty(t, rebuilt.Used)
+}
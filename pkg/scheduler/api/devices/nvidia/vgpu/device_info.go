@@ -108,6 +108,7 @@ func NewGPUDevices(name string, node *v1.Node) *GPUDevices {
 	if (nodedevices == nil) || len(nodedevices.Device) == 0 {
 		return nil
 	}
+	setMigGeometries(nodedevices, node.Annotations[MigGeometriesAnnotations])
 	for _, val := range nodedevices.Device {
 		klog.V(3).InfoS("Nvidia Device registered name", "name", nodedevices.Name, "val", *val)
 		ResetDeviceMetrics(val.UUID, node.Name, float64(val.Memory))
@@ -158,9 +159,13 @@ func (gs *GPUDevices) AddResource(pod *v1.Pod) {
 	podDev := decodePodDevices(ids)
 	for _, val := range podDev {
 		for _, deviceused := range val {
+			uuid, instance, isMig := decodeMigUUID(deviceused.UUID)
 			for index, gsdevice := range gs.Device {
-				if gsdevice.UUID == deviceused.UUID {
+				if gsdevice.UUID == uuid {
 					klog.V(4).Infoln("VGPU recording pod", pod.Name, "device", deviceused)
+					if isMig {
+						gs.Device[index].useMigInstance(instance.group, instance.index, true)
+					}
 					gs.Device[index].UsedMem += uint(deviceused.Usedmem)
 					gs.Device[index].UsedNum++
 					gs.Device[index].UsedCore += uint(deviceused.Usedcores)
@@ -192,9 +197,13 @@ func (gs *GPUDevices) SubResource(pod *v1.Pod) {
 	podDev := decodePodDevices(ids)
 	for _, val := range podDev {
 		for _, deviceused := range val {
+			uuid, instance, isMig := decodeMigUUID(deviceused.UUID)
 			for index, gsdevice := range gs.Device {
-				if gsdevice.UUID == deviceused.UUID {
+				if gsdevice.UUID == uuid {
 					klog.V(4).Infoln("VGPU subsctracting pod", pod.Name, "device", deviceused)
+					if isMig {
+						gs.Device[index].useMigInstance(instance.group, instance.index, false)
+					}
 					gs.Device[index].UsedMem -= uint(deviceused.Usedmem)
 					gs.Device[index].UsedNum--
 					gs.Device[index].UsedCore -= uint(deviceused.Usedcores)
@@ -254,6 +263,10 @@ func (gs *GPUDevices) Allocate(kubeClient kubernetes.Interface, pod *v1.Pod) err
 		annotations[AssignedTimeAnnotations] = strconv.FormatInt(time.Now().Unix(), 10)
 		annotations[AssignedIDsAnnotations] = encodePodDevices(device)
 		annotations[AssignedIDsToAllocateAnnotations] = annotations[AssignedIDsAnnotations]
+		// the node side partitions the mig gpus by the geometries before the containers are started
+		if geometries := encodeMigGeometries(gs, device); geometries != "" {
+			annotations[AssignedMigGeometryAnnotations] = geometries
+		}
 
 		annotations[DeviceBindPhase] = "allocating"
 		annotations[BindTimeAnnotations] = strconv.FormatInt(time.Now().Unix(), 10)
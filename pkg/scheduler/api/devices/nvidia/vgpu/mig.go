@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgpu
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api/devices/config"
+)
+
+// migInstance is a mig instance of a geometry of a gpu.
+type migInstance struct {
+	// group is the index of the geometry in the mig template of the gpu
+	group int
+	// index is the index of the instance in the usage list of the geometry
+	index int
+	// memory is the device memory of the instance
+	memory int32
+}
+
+// newMigUsage expands the instances of the geometry into a usage list, all the instances are free.
+func newMigUsage(group int, geometry config.Geometry) config.MigInUse {
+	usage := config.MigInUse{Index: int32(group), UsageList: config.MIGS{}}
+	for _, template := range geometry.Instances {
+		for i := int32(0); i < template.Count; i++ {
+			usage.UsageList = append(usage.UsageList, config.MigTemplateUsage{Name: template.Name, Memory: template.Memory})
+		}
+	}
+	return usage
+}
+
+// copyMigUsage deep copies the usage list, so that the snapshot of a gpu can be used to fit pods.
+func copyMigUsage(usage config.MigInUse) config.MigInUse {
+	ret := config.MigInUse{Index: usage.Index}
+	if usage.UsageList != nil {
+		ret.UsageList = make(config.MIGS, len(usage.UsageList))
+		copy(ret.UsageList, usage.UsageList)
+	}
+	return ret
+}
+
+// hasMigGeometry checks whether the gpu is partitioned by a geometry of its mig template.
+func (d *GPUDevice) hasMigGeometry() bool {
+	return d.MigUsage.Index >= 0 && int(d.MigUsage.Index) < len(d.MigTemplate) && len(d.MigUsage.UsageList) > 0
+}
+
+// bestFreeMigInstance returns the free instance of the usage list with the least memory fitting the request.
+func bestFreeMigInstance(usage config.MigInUse, memreq int32) (int, bool) {
+	best := -1
+	for i, instance := range usage.UsageList {
+		if instance.InUse || instance.Memory < memreq {
+			continue
+		}
+		if best < 0 || instance.Memory < usage.UsageList[best].Memory {
+			best = i
+		}
+	}
+	return best, best >= 0
+}
+
+// largestFreeMigMemory returns the memory of the largest free instance of the usage list except the skipped one.
+func largestFreeMigMemory(usage config.MigInUse, skip int) int32 {
+	largest := int32(0)
+	for i, instance := range usage.UsageList {
+		if i != skip && !instance.InUse && instance.Memory > largest {
+			largest = instance.Memory
+		}
+	}
+	return largest
+}
+
+// fitMigInstance picks the mig instance of the gpu for the memory request. A gpu used by pods keeps its geometry,
+// so only its free instances fit. An idle gpu may be repartitioned, the geometry is chosen by:
+//  1. the smallest instance fitting the request, which wastes the least memory;
+//  2. the geometry the gpu is partitioned by, which needs no reconfiguration;
+//  3. the largest instance left free, so that the gpu is still able to host a bigger profile;
+//  4. the order of the geometries in the mig template.
+func (d *GPUDevice) fitMigInstance(memreq int32) (migInstance, bool) {
+	if d.UsedNum > 0 {
+		if !d.hasMigGeometry() {
+			return migInstance{}, false
+		}
+		index, ok := bestFreeMigInstance(d.MigUsage, memreq)
+		if !ok {
+			return migInstance{}, false
+		}
+		return migInstance{group: int(d.MigUsage.Index), index: index, memory: d.MigUsage.UsageList[index].Memory}, true
+	}
+
+	var best migInstance
+	var bestLeft int32
+	found := false
+	for group, geometry := range d.MigTemplate {
+		usage := newMigUsage(group, geometry)
+		index, ok := bestFreeMigInstance(usage, memreq)
+		if !ok {
+			continue
+		}
+		candidate := migInstance{group: group, index: index, memory: usage.UsageList[index].Memory}
+		left := largestFreeMigMemory(usage, index)
+		if !found || betterMigInstance(candidate, left, best, bestLeft, int(d.MigUsage.Index)) {
+			best, bestLeft, found = candidate, left, true
+		}
+	}
+	return best, found
+}
+
+func betterMigInstance(candidate migInstance, candidateLeft int32, best migInstance, bestLeft int32, current int) bool {
+	if candidate.memory != best.memory {
+		return candidate.memory < best.memory
+	}
+	if (candidate.group == current) != (best.group == current) {
+		return candidate.group == current
+	}
+	return candidateLeft > bestLeft
+}
+
+// useMigInstance marks the instance of the gpu as used or free, the gpu is repartitioned by the geometry of the
+// instance if it is not yet.
+func (d *GPUDevice) useMigInstance(group int, index int, inUse bool) {
+	if group < 0 || group >= len(d.MigTemplate) {
+		klog.Warningf("Mig geometry %d of gpu %s is out of the mig template", group, d.UUID)
+		return
+	}
+	if int(d.MigUsage.Index) != group || len(d.MigUsage.UsageList) == 0 {
+		d.MigUsage = newMigUsage(group, d.MigTemplate[group])
+	}
+	if index < 0 || index >= len(d.MigUsage.UsageList) {
+		klog.Warningf("Mig instance %d of geometry %s of gpu %s is out of range", index, d.MigTemplate[group].Group, d.UUID)
+		return
+	}
+	d.MigUsage.UsageList[index].InUse = inUse
+}
+
+// migGeometry returns the name of the geometry the gpu is partitioned by.
+func (d *GPUDevice) migGeometry() string {
+	if !d.hasMigGeometry() {
+		return ""
+	}
+	return d.MigTemplate[d.MigUsage.Index].Group
+}
+
+// setMigGeometries sets the geometries the mig gpus are partitioned by from the node annotation, e.g.
+// "GPU-0:group2,GPU-1:group1". The mig gpus not in the annotation are not partitioned.
+func setMigGeometries(gs *GPUDevices, annotation string) {
+	geometries := map[string]string{}
+	for _, item := range strings.Split(annotation, ",") {
+		if uuid, group, ok := strings.Cut(strings.TrimSpace(item), ":"); ok {
+			geometries[uuid] = group
+		}
+	}
+	for _, d := range gs.Device {
+		if d.Mode != vGPUControllerMIG {
+			continue
+		}
+		d.MigUsage = config.MigInUse{Index: -1}
+		for group, geometry := range d.MigTemplate {
+			if geometry.Group == geometries[d.UUID] {
+				d.MigUsage = newMigUsage(group, geometry)
+				break
+			}
+		}
+	}
+}
+
+// encodeMigUUID encodes the mig instance into the uuid of the assigned device, e.g. "GPU-0[2-1]" is the
+// instance 1 of the geometry 2 of the gpu GPU-0.
+func encodeMigUUID(uuid string, instance migInstance) string {
+	return fmt.Sprintf("%s[%d-%d]", uuid, instance.group, instance.index)
+}
+
+// decodeMigUUID decodes the uuid of the assigned device, it returns false if the device is not a mig instance.
+func decodeMigUUID(id string) (string, migInstance, bool) {
+	start := strings.LastIndex(id, "[")
+	if start < 0 || !strings.HasSuffix(id, "]") {
+		return id, migInstance{}, false
+	}
+	group, index, ok := strings.Cut(id[start+1:len(id)-1], "-")
+	if !ok {
+		return id, migInstance{}, false
+	}
+	g, err := strconv.Atoi(group)
+	if err != nil {
+		return id, migInstance{}, false
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return id, migInstance{}, false
+	}
+	return id[:start], migInstance{group: g, index: i}, true
+}
+
+// encodeMigGeometries encodes the geometries of the mig gpus assigned to the pod, e.g. "GPU-0:group2", the node
+// side reconfigures the gpus by them before the containers are started.
+func encodeMigGeometries(gs *GPUDevices, pd []ContainerDevices) string {
+	geometries := map[string]string{}
+	for _, cd := range pd {
+		for _, device := range cd {
+			uuid, instance, ok := decodeMigUUID(device.UUID)
+			if !ok {
+				continue
+			}
+			for _, d := range gs.Device {
+				if d.UUID == uuid && instance.group < len(d.MigTemplate) {
+					geometries[uuid] = d.MigTemplate[instance.group].Group
+				}
+			}
+		}
+	}
+	items := make([]string, 0, len(geometries))
+	for uuid, group := range geometries {
+		items = append(items, uuid+":"+group)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vgpu
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api/devices/config"
+)
+
+var a100Geometries = []config.Geometry{
+	{Group: "group1", Instances: []config.MigTemplate{{Name: "1g.5gb", Memory: 5120, Count: 7}}},
+	{Group: "group2", Instances: []config.MigTemplate{{Name: "2g.10gb", Memory: 10240, Count: 3}, {Name: "1g.5gb", Memory: 5120, Count: 1}}},
+	{Group: "group3", Instances: []config.MigTemplate{{Name: "3g.20gb", Memory: 20480, Count: 2}}},
+	{Group: "group4", Instances: []config.MigTemplate{{Name: "7g.40gb", Memory: 40960, Count: 1}}},
+}
+
+func buildMigDevices(geometries ...string) *GPUDevices {
+	gs := &GPUDevices{Name: "n1", Device: map[int]*GPUDevice{}}
+	for i := range geometries {
+		gs.Device[i] = &GPUDevice{
+			ID:          i,
+			Node:        "n1",
+			UUID:        "GPU-" + string(rune('0'+i)),
+			PodMap:      map[string]*GPUUsage{},
+			Memory:      40960,
+			Number:      10,
+			Type:        "NVIDIA-A100-PCIE-40GB",
+			Health:      true,
+			Mode:        vGPUControllerMIG,
+			MigTemplate: a100Geometries,
+		}
+	}
+	annotation := ""
+	for i, geometry := range geometries {
+		if geometry != "" {
+			annotation += gs.Device[i].UUID + ":" + geometry + ","
+		}
+	}
+	setMigGeometries(gs, annotation)
+	return gs
+}
+
+func buildVGPUPod(name string, number, memory string, annotations map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{
+					Limits: v1.ResourceList{
+						config.VolcanoVGPUNumber: resource.MustParse(number),
+						config.VolcanoVGPUMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestMigPredicate(t *testing.T) {
+	tests := []struct {
+		name      string
+		devices   *GPUDevices
+		pod       *v1.Pod
+		fit       bool
+		expectIDs []string
+	}{
+		{
+			name:      "idle gpu is partitioned by the geometry of the smallest fitting profile",
+			devices:   buildMigDevices(""),
+			pod:       buildVGPUPod("p1", "1", "8000", nil),
+			fit:       true,
+			expectIDs: []string{"GPU-0[1-0]"},
+		},
+		{
+			name:      "bigger request takes a bigger profile",
+			devices:   buildMigDevices(""),
+			pod:       buildVGPUPod("p1", "1", "15000", nil),
+			fit:       true,
+			expectIDs: []string{"GPU-0[2-0]"},
+		},
+		{
+			name:      "geometry leaving the largest profile free is preferred",
+			devices:   buildMigDevices(""),
+			pod:       buildVGPUPod("p1", "1", "4000", nil),
+			fit:       true,
+			expectIDs: []string{"GPU-0[1-3]"},
+		},
+		{
+			name:      "current geometry of the gpu is preferred to repartitioning",
+			devices:   buildMigDevices("group1"),
+			pod:       buildVGPUPod("p1", "1", "4000", nil),
+			fit:       true,
+			expectIDs: []string{"GPU-0[0-0]"},
+		},
+		{
+			name:      "pod asking for hami-core does not fit into mig gpus",
+			devices:   buildMigDevices(""),
+			pod:       buildVGPUPod("p1", "1", "4000", map[string]string{VGPUModeAnnotations: vGPUControllerHAMICore}),
+			fit:       false,
+			expectIDs: nil,
+		},
+		{
+			name:      "request bigger than any profile does not fit",
+			devices:   buildMigDevices(""),
+			pod:       buildVGPUPod("p1", "1", "50000", nil),
+			fit:       false,
+			expectIDs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fit, devs, _, _ := checkNodeGPUSharingPredicateAndScore(tt.pod, tt.devices, true, binpackPolicy)
+			if fit != tt.fit {
+				t.Fatalf("fit = %v, want %v", fit, tt.fit)
+			}
+			var ids []string
+			for _, cd := range devs {
+				for _, d := range cd {
+					ids = append(ids, d.UUID)
+				}
+			}
+			if len(ids) != len(tt.expectIDs) {
+				t.Fatalf("assigned %v, want %v", ids, tt.expectIDs)
+			}
+			for i := range ids {
+				if ids[i] != tt.expectIDs[i] {
+					t.Errorf("assigned %v, want %v", ids, tt.expectIDs)
+				}
+			}
+			for _, d := range tt.devices.Device {
+				if d.UsedNum != 0 {
+					t.Errorf("snapshot fitting changed gpu %s", d.UUID)
+				}
+			}
+		})
+	}
+}
+
+func TestMigPartitionedGPUIsPreferred(t *testing.T) {
+	gs := buildMigDevices("", "")
+	running := buildVGPUPod("running", "1", "8000", map[string]string{AssignedIDsAnnotations: "GPU-0[1-0],NVIDIA,10240,0:"})
+	gs.AddResource(running)
+	if gs.Device[0].migGeometry() != "group2" || !gs.Device[0].MigUsage.UsageList[0].InUse {
+		t.Fatalf("gpu is not partitioned by the running pod: %v", gs.Device[0].MigUsage)
+	}
+
+	// GPU-1 is fitted first by index, but GPU-0 is already partitioned with a free 2g.10gb instance
+	fit, devs, _, err := checkNodeGPUSharingPredicateAndScore(buildVGPUPod("p1", "1", "8000", nil), gs, false, binpackPolicy)
+	if !fit || err != nil {
+		t.Fatalf("pod does not fit: %v", err)
+	}
+	if devs[0][0].UUID != "GPU-0[1-1]" {
+		t.Errorf("assigned %s, want GPU-0[1-1]", devs[0][0].UUID)
+	}
+	if geometries := encodeMigGeometries(gs, devs); geometries != "GPU-0:group2" {
+		t.Errorf("geometries = %q, want GPU-0:group2", geometries)
+	}
+
+	gs.SubResource(running)
+	if gs.Device[0].MigUsage.UsageList[0].InUse {
+		t.Errorf("instance of the deleted pod is still in use")
+	}
+	if gs.Device[0].migGeometry() != "group2" {
+		t.Errorf("gpu lost its geometry after the pod is deleted")
+	}
+}
+
+func TestDecodeMigUUID(t *testing.T) {
+	uuid, instance, ok := decodeMigUUID(encodeMigUUID("GPU-a1", migInstance{group: 3, index: 12}))
+	if !ok || uuid != "GPU-a1" || instance.group != 3 || instance.index != 12 {
+		t.Errorf("decoded %s %v %v", uuid, instance, ok)
+	}
+	if uuid, _, ok := decodeMigUUID("GPU-a1"); ok || uuid != "GPU-a1" {
+		t.Errorf("gpu uuid is decoded as a mig instance")
+	}
+}
@@ -28,6 +28,12 @@ const (
 	AssignedNodeAnnotations          = "volcano.sh/vgpu-node"
 	BindTimeAnnotations              = "volcano.sh/bind-time"
 	DeviceBindPhase                  = "volcano.sh/bind-phase"
+	// AssignedMigGeometryAnnotations is the geometries the mig gpus assigned to the pod are to be partitioned by
+	AssignedMigGeometryAnnotations = "volcano.sh/vgpu-mig-geometry"
+	// MigGeometriesAnnotations is the node annotation of the geometries the mig gpus are partitioned by
+	MigGeometriesAnnotations = "volcano.sh/node-vgpu-mig-geometries"
+	// VGPUModeAnnotations is the pod annotation which restricts the pod to the gpus of a mode, mig or hami-core
+	VGPUModeAnnotations = "volcano.sh/vgpu-mode"
 
 	NvidiaGPUDevice = "NVIDIA"
 
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -327,12 +328,47 @@ func getGPUDeviceSnapShot(snap *GPUDevices) *GPUDevices {
 				UsedNum:  val.UsedNum,
 				UsedMem:  val.UsedMem,
 				UsedCore: val.UsedCore,
+				Mode:     val.Mode,
+				// the mig template is read only, the usage is changed when the pods are fitted
+				MigTemplate: val.MigTemplate,
+				MigUsage:    copyMigUsage(val.MigUsage),
 			}
 		}
 	}
 	return &ret
 }
 
+// deviceOrder returns the order the devices are fitted in: the mig gpus which are used by pods come first, so
+// that the pods are packed into the gpus already partitioned, and the idle gpus are left for the bigger profiles.
+// The other devices are fitted from the highest index to the lowest.
+func deviceOrder(gs *GPUDevices) []int {
+	order := make([]int, 0, len(gs.Device))
+	for i := len(gs.Device) - 1; i >= 0; i-- {
+		order = append(order, i)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return migInUse(gs.Device[order[a]]) && !migInUse(gs.Device[order[b]])
+	})
+	return order
+}
+
+func migInUse(d *GPUDevice) bool {
+	return d.Mode == vGPUControllerMIG && d.UsedNum > 0
+}
+
+// deviceScore scores the device after a container is fitted into it by the schedule policy.
+func deviceScore(d *GPUDevice, schedulePolicy string) float64 {
+	switch schedulePolicy {
+	case binpackPolicy:
+		return binpackMultiplier * (float64(d.UsedMem) / float64(d.Memory))
+	case spreadPolicy:
+		if d.UsedNum == 1 {
+			return spreadMultiplier
+		}
+	}
+	return 0
+}
+
 // checkNodeGPUSharingPredicate checks if a pod with gpu requirement can be scheduled on a node.
 func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, replicate bool, schedulePolicy string) (bool, []ContainerDevices, float64, error) {
 	// no gpu sharing request
@@ -358,9 +394,38 @@ func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, repli
 		}
 		klog.V(3).InfoS("Allocating device for container", "request", val)
 
-		for i := len(gs.Device) - 1; i >= 0; i-- {
+		for _, i := range deviceOrder(gs) {
 			klog.V(3).InfoS("Scoring pod request", "memReq", val.Memreq, "memPercentageReq", val.MemPercentagereq, "coresReq", val.Coresreq, "Nums", val.Nums, "Index", i, "ID", gs.Device[i].ID)
 			klog.V(3).InfoS("Current Device", "Index", i, "TotalMemory", gs.Device[i].Memory, "UsedMemory", gs.Device[i].UsedMem, "UsedCores", gs.Device[i].UsedCore, "replicate", replicate)
+			if mode, ok := pod.Annotations[VGPUModeAnnotations]; ok && mode != gs.Device[i].Mode {
+				continue
+			}
+			if gs.Device[i].Mode == vGPUControllerMIG {
+				if val.Nums == 0 {
+					break
+				}
+				memreq := val.Memreq
+				if val.MemPercentagereq != 101 && memreq == 0 {
+					memreq = int32(gs.Device[i].Memory * uint(val.MemPercentagereq) / 100)
+				}
+				instance, ok := gs.Device[i].fitMigInstance(memreq)
+				if !ok || !checkType(pod.Annotations, *gs.Device[i], val) {
+					continue
+				}
+				klog.V(3).InfoS("mig instance fitted", "ID", gs.Device[i].ID, "geometry", gs.Device[i].MigTemplate[instance.group].Group, "instance", instance.index, "memory", instance.memory)
+				val.Nums--
+				// the instance is used exclusively by the container
+				gs.Device[i].useMigInstance(instance.group, instance.index, true)
+				gs.Device[i].UsedNum++
+				gs.Device[i].UsedMem += uint(instance.memory)
+				devs = append(devs, ContainerDevice{
+					UUID:    encodeMigUUID(gs.Device[i].UUID, instance),
+					Type:    val.Type,
+					Usedmem: instance.memory,
+				})
+				score += deviceScore(gs.Device[i], schedulePolicy)
+				continue
+			}
 			if gs.Device[i].Number <= uint(gs.Device[i].UsedNum) {
 				continue
 			}
@@ -399,16 +464,7 @@ func checkNodeGPUSharingPredicateAndScore(pod *v1.Pod, gssnap *GPUDevices, repli
 					Usedmem:   val.Memreq,
 					Usedcores: val.Coresreq,
 				})
-				switch schedulePolicy {
-				case binpackPolicy:
-					score += binpackMultiplier * (float64(gs.Device[i].UsedMem) / float64(gs.Device[i].Memory))
-				case spreadPolicy:
-					if gs.Device[i].UsedNum == 1 {
-						score += spreadMultiplier
-					}
-				default:
-					score = float64(0)
-				}
+				score += deviceScore(gs.Device[i], schedulePolicy)
 			}
 			if val.Nums == 0 {
 				break
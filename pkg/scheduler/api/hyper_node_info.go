@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// NetworkTopologyModeAnnotation is the annotation of a podgroup which sets the network topology
+	// mode of the job, hard or soft
+	NetworkTopologyModeAnnotation = "volcano.sh/network-topology-mode"
+	// NetworkTopologyHighestTierAnnotation is the annotation of a podgroup which sets the highest
+	// tier of hypernode the job is allowed to span
+	NetworkTopologyHighestTierAnnotation = "volcano.sh/network-topology-highest-tier-allowed"
+)
+
+// NetworkTopologyMode is the mode of the network topology constraint of a job.
+type NetworkTopologyMode string
+
+const (
+	// HardNetworkTopologyMode means all the tasks of the job must be placed in one hypernode
+	// whose tier is not higher than the highest tier allowed.
+	HardNetworkTopologyMode NetworkTopologyMode = "hard"
+	// SoftNetworkTopologyMode means the job is placed in the lowest tier possible, and falls back
+	// to any node if no hypernode fits it.
+	SoftNetworkTopologyMode NetworkTopologyMode = "soft"
+)
+
+// NetworkTopology is the network topology constraint of a job.
+type NetworkTopology struct {
+	Mode NetworkTopologyMode
+	// HighestTierAllowed is the highest tier of hypernode the job can span, 0 means no limit
+	HighestTierAllowed int
+}
+
+// NetworkTopology returns the network topology constraint of the job set by the annotations of
+// its podgroup, or nil if the job has none.
+func (ji *JobInfo) NetworkTopology() *NetworkTopology {
+	if ji.PodGroup == nil {
+		return nil
+	}
+	mode, found := ji.PodGroup.Annotations[NetworkTopologyModeAnnotation]
+	if !found {
+		return nil
+	}
+
+	topology := &NetworkTopology{Mode: NetworkTopologyMode(mode)}
+	if topology.Mode != HardNetworkTopologyMode && topology.Mode != SoftNetworkTopologyMode {
+		return nil
+	}
+	if tier, found := ji.PodGroup.Annotations[NetworkTopologyHighestTierAnnotation]; found {
+		highestTier, err := strconv.Atoi(tier)
+		if err != nil || highestTier < 0 {
+			return nil
+		}
+		topology.HighestTierAllowed = highestTier
+	}
+	return topology
+}
+
+// HyperNodeInfo is a performance domain which consists of a group of nodes or hypernodes of
+// lower tiers, e.g. the nodes connected to the same leaf switch.
+type HyperNodeInfo struct {
+	Name string
+	// Tier is the level of the hypernode, the lower the tier the higher the bandwidth
+	Tier int
+	// Parent is the name of the hypernode of an upper tier which contains this one, if any
+	Parent string
+	// Members are the names of the hypernodes contained in this one
+	Members sets.Set[string]
+	// Nodes are the names of all the nodes in the hypernode, including the nodes of its members
+	Nodes sets.Set[string]
+}
+
+// NewHyperNodeInfo returns an empty hypernode of the tier.
+func NewHyperNodeInfo(name string, tier int) *HyperNodeInfo {
+	return &HyperNodeInfo{
+		Name:    name,
+		Tier:    tier,
+		Members: sets.New[string](),
+		Nodes:   sets.New[string](),
+	}
+}
+
+// HyperNodesInfo is the hierarchy of the hypernodes of the cluster.
+type HyperNodesInfo struct {
+	HyperNodes map[string]*HyperNodeInfo
+
+	// tiers are the tiers of the hypernodes in ascending order
+	tiers []int
+	// nodeHyperNodes are the hypernodes which contain a node indexed by node name then tier
+	nodeHyperNodes map[string]map[int]*HyperNodeInfo
+}
+
+// NewHyperNodesInfo builds the hierarchy of the hypernodes. The nodes of the hypernodes of
+// upper tiers are resolved from their members, a member must be of a lower tier than the
+// hypernode and can only be part of one hypernode.
+func NewHyperNodesInfo(hyperNodes []*HyperNodeInfo) (*HyperNodesInfo, error) {
+	hni := &HyperNodesInfo{
+		HyperNodes:     make(map[string]*HyperNodeInfo, len(hyperNodes)),
+		nodeHyperNodes: map[string]map[int]*HyperNodeInfo{},
+	}
+	tiers := sets.New[int]()
+	for _, hn := range hyperNodes {
+		if _, found := hni.HyperNodes[hn.Name]; found {
+			return nil, fmt.Errorf("duplicated hypernode %s", hn.Name)
+		}
+		hni.HyperNodes[hn.Name] = hn
+		tiers.Insert(hn.Tier)
+	}
+	hni.tiers = sets.List(tiers)
+
+	for _, hn := range hyperNodes {
+		for member := range hn.Members {
+			child, found := hni.HyperNodes[member]
+			if !found {
+				return nil, fmt.Errorf("member %s of hypernode %s is not found", member, hn.Name)
+			}
+			if child.Tier >= hn.Tier {
+				return nil, fmt.Errorf("member %s of hypernode %s must be of a lower tier than %d", member, hn.Name, hn.Tier)
+			}
+			if child.Parent != "" {
+				return nil, fmt.Errorf("hypernode %s is a member of both %s and %s", member, child.Parent, hn.Name)
+			}
+			child.Parent = hn.Name
+		}
+	}
+
+	// members are always of a lower tier, so they are resolved before the hypernodes containing them
+	for _, tier := range hni.tiers {
+		for _, hn := range hni.HyperNodesOfTier(tier) {
+			for member := range hn.Members {
+				hn.Nodes = hn.Nodes.Union(hni.HyperNodes[member].Nodes)
+			}
+			for node := range hn.Nodes {
+				if hni.nodeHyperNodes[node] == nil {
+					hni.nodeHyperNodes[node] = map[int]*HyperNodeInfo{}
+				}
+				hni.nodeHyperNodes[node][tier] = hn
+			}
+		}
+	}
+	return hni, nil
+}
+
+// Tiers returns the tiers of the hypernodes in ascending order.
+func (hni *HyperNodesInfo) Tiers() []int {
+	return hni.tiers
+}
+
+// HyperNodesOfTier returns the hypernodes of the tier sorted by name.
+func (hni *HyperNodesInfo) HyperNodesOfTier(tier int) []*HyperNodeInfo {
+	var hyperNodes []*HyperNodeInfo
+	for _, hn := range hni.HyperNodes {
+		if hn.Tier == tier {
+			hyperNodes = append(hyperNodes, hn)
+		}
+	}
+	sort.Slice(hyperNodes, func(i, j int) bool {
+		return hyperNodes[i].Name < hyperNodes[j].Name
+	})
+	return hyperNodes
+}
+
+// HyperNodeOf returns the hypernode of the tier which contains the node, or nil if there is none.
+func (hni *HyperNodesInfo) HyperNodeOf(node string, tier int) *HyperNodeInfo {
+	return hni.nodeHyperNodes[node][tier]
+}
+
+// LowestCommonHyperNode returns the hypernode of the lowest tier which contains all the nodes,
+// or nil if there is none.
+func (hni *HyperNodesInfo) LowestCommonHyperNode(nodes sets.Set[string]) *HyperNodeInfo {
+	if nodes.Len() == 0 {
+		return nil
+	}
+	for _, tier := range hni.tiers {
+		var common *HyperNodeInfo
+		for node := range nodes {
+			hn := hni.HyperNodeOf(node, tier)
+			if hn == nil || (common != nil && hn != common) {
+				common = nil
+				break
+			}
+			common = hn
+		}
+		if common != nil {
+			return common
+		}
+	}
+	return nil
+}
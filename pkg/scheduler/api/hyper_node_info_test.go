@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+)
+
+func buildHyperNode(name string, tier int, nodes []string, members []string) *HyperNodeInfo {
+	hn := NewHyperNodeInfo(name, tier)
+	hn.Nodes.Insert(nodes...)
+	hn.Members.Insert(members...)
+	return hn
+}
+
+func TestNewHyperNodesInfo(t *testing.T) {
+	// s0 -> {s1 -> {n0, n1}, s2 -> {n2, n3}}, n4 is only in s0
+	hni, err := NewHyperNodesInfo([]*HyperNodeInfo{
+		buildHyperNode("s0", 2, []string{"n4"}, []string{"s1", "s2"}),
+		buildHyperNode("s1", 1, []string{"n0", "n1"}, nil),
+		buildHyperNode("s2", 1, []string{"n2", "n3"}, nil),
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, hni.Tiers())
+	assert.Equal(t, sets.New("n0", "n1", "n2", "n3", "n4"), hni.HyperNodes["s0"].Nodes)
+	assert.Equal(t, "s0", hni.HyperNodes["s1"].Parent)
+	assert.Equal(t, "s2", hni.HyperNodeOf("n3", 1).Name)
+	assert.Nil(t, hni.HyperNodeOf("n4", 1))
+	assert.Equal(t, "s0", hni.HyperNodeOf("n4", 2).Name)
+
+	var names []string
+	for _, hn := range hni.HyperNodesOfTier(1) {
+		names = append(names, hn.Name)
+	}
+	assert.Equal(t, []string{"s1", "s2"}, names)
+
+	assert.Equal(t, "s1", hni.LowestCommonHyperNode(sets.New("n0", "n1")).Name)
+	assert.Equal(t, "s0", hni.LowestCommonHyperNode(sets.New("n0", "n2")).Name)
+	assert.Nil(t, hni.LowestCommonHyperNode(sets.New("n0", "n5")))
+	assert.Nil(t, hni.LowestCommonHyperNode(sets.New[string]()))
+}
+
+func TestNewHyperNodesInfoErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		hyperNodes []*HyperNodeInfo
+	}{
+		{
+			name: "member not found",
+			hyperNodes: []*HyperNodeInfo{
+				buildHyperNode("s0", 2, nil, []string{"s1"}),
+			},
+		},
+		{
+			name: "member of the same tier",
+			hyperNodes: []*HyperNodeInfo{
+				buildHyperNode("s0", 1, nil, []string{"s1"}),
+				buildHyperNode("s1", 1, []string{"n0"}, nil),
+			},
+		},
+		{
+			name: "member of two hypernodes",
+			hyperNodes: []*HyperNodeInfo{
+				buildHyperNode("s0", 2, nil, []string{"s2"}),
+				buildHyperNode("s1", 2, nil, []string{"s2"}),
+				buildHyperNode("s2", 1, []string{"n0"}, nil),
+			},
+		},
+		{
+			name: "duplicated hypernode",
+			hyperNodes: []*HyperNodeInfo{
+				buildHyperNode("s0", 1, []string{"n0"}, nil),
+				buildHyperNode("s0", 1, []string{"n1"}, nil),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewHyperNodesInfo(test.hyperNodes)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJobNetworkTopology(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *NetworkTopology
+	}{
+		{
+			name:     "no annotation",
+			expected: nil,
+		},
+		{
+			name: "hard mode with highest tier",
+			annotations: map[string]string{
+				NetworkTopologyModeAnnotation:        "hard",
+				NetworkTopologyHighestTierAnnotation: "2",
+			},
+			expected: &NetworkTopology{Mode: HardNetworkTopologyMode, HighestTierAllowed: 2},
+		},
+		{
+			name: "soft mode without highest tier",
+			annotations: map[string]string{
+				NetworkTopologyModeAnnotation: "soft",
+			},
+			expected: &NetworkTopology{Mode: SoftNetworkTopologyMode},
+		},
+		{
+			name: "invalid mode",
+			annotations: map[string]string{
+				NetworkTopologyModeAnnotation: "strict",
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid highest tier",
+			annotations: map[string]string{
+				NetworkTopologyModeAnnotation:        "hard",
+				NetworkTopologyHighestTierAnnotation: "spine",
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			job := &JobInfo{PodGroup: &PodGroup{PodGroup: scheduling.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations},
+			}}}
+			assert.Equal(t, test.expected, job.NetworkTopology())
+		})
+	}
+}
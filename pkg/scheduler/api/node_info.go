@@ -26,9 +26,6 @@ import (
 	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
 
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
-
-	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/gpushare"
-	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/vgpu"
 )
 
 type AllocateFailError struct {
@@ -337,12 +334,15 @@ func (ni *NodeInfo) setNodeOthersResource(node *v1.Node) {
 		return
 	}
 
-	ni.Others[gpushare.DeviceName] = gpushare.NewGPUDevices(ni.Name, node)
-	ni.Others[vgpu.DeviceName] = vgpu.NewGPUDevices(ni.Name, node)
-	IgnoredDevicesList.Set(
-		ni.Others[gpushare.DeviceName].(Devices).GetIgnoredDevices(),
-		ni.Others[vgpu.DeviceName].(Devices).GetIgnoredDevices(),
-	)
+	ignoredDevices := make([][]string, 0, len(RegisteredDevices))
+	for _, name := range RegisteredDevices {
+		delete(ni.Others, name)
+	}
+	for name, dev := range newDevices(ni.Name, node) {
+		ni.Others[name] = dev
+		ignoredDevices = append(ignoredDevices, dev.GetIgnoredDevices())
+	}
+	IgnoredDevicesList.Set(ignoredDevices...)
 }
 
 // setNode sets kubernetes node object to nodeInfo object without assertion
@@ -485,14 +485,20 @@ func (ni *NodeInfo) RemoveTask(ti *TaskInfo) error {
 
 // addResource is used to add sharable devices
 func (ni *NodeInfo) addResource(pod *v1.Pod) {
-	ni.Others[gpushare.DeviceName].(Devices).AddResource(pod)
-	ni.Others[vgpu.DeviceName].(Devices).AddResource(pod)
+	for _, name := range RegisteredDevices {
+		if dev, ok := ni.Others[name].(Devices); ok {
+			dev.AddResource(pod)
+		}
+	}
 }
 
 // subResource is used to subtract sharable devices
 func (ni *NodeInfo) subResource(pod *v1.Pod) {
-	ni.Others[gpushare.DeviceName].(Devices).SubResource(pod)
-	ni.Others[vgpu.DeviceName].(Devices).SubResource(pod)
+	for _, name := range RegisteredDevices {
+		if dev, ok := ni.Others[name].(Devices); ok {
+			dev.SubResource(pod)
+		}
+	}
 }
 
 // UpdateTask is used to update a task in nodeInfo object.
@@ -18,6 +18,8 @@ package api
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
@@ -38,8 +40,30 @@ const (
 	NumaInfoLessFlag NumaChgFlag = 0b10
 	// DefaultMaxNodeScore indicates the default max node score
 	DefaultMaxNodeScore = 100
+
+	// numaResourceSeparator separates the resource name and the numa node of the resources reported per numa
+	// node in the Numatopology, e.g. "memory@numa0" or "nvidia.com/gpu@numa1"
+	numaResourceSeparator = "@numa"
 )
 
+// NumaResourceName returns the name of the resource on the numa node in the Numatopology.
+func NumaResourceName(resName string, numaID int) string {
+	return resName + numaResourceSeparator + strconv.Itoa(numaID)
+}
+
+// ParseNumaResourceName returns the resource name and the numa node of a resource reported per numa node.
+func ParseNumaResourceName(name string) (string, int, bool) {
+	index := strings.LastIndex(name, numaResourceSeparator)
+	if index <= 0 {
+		return "", 0, false
+	}
+	numaID, err := strconv.Atoi(name[index+len(numaResourceSeparator):])
+	if err != nil || numaID < 0 {
+		return "", 0, false
+	}
+	return name[:index], numaID, true
+}
+
 // PodResourceDecision is resource allocation determined by scheduler,
 // and passed to kubelet through pod annotation.
 type PodResourceDecision struct {
@@ -65,6 +89,17 @@ type NumatopoInfo struct {
 	ResReserved v1.ResourceList
 }
 
+// NumaResources returns the names of the resource on the numa nodes it is reported on, by numa id.
+func (info *NumatopoInfo) NumaResources(resName string) map[int]string {
+	names := make(map[int]string)
+	for name := range info.NumaResMap {
+		if res, numaID, ok := ParseNumaResourceName(name); ok && res == resName {
+			names[numaID] = name
+		}
+	}
+	return names
+}
+
 // DeepCopy used to copy NumatopoInfo
 func (info *NumatopoInfo) DeepCopy() *NumatopoInfo {
 	numaInfo := &NumatopoInfo{
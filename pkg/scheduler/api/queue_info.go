@@ -17,7 +17,11 @@ limitations under the License.
 package api
 
 import (
+	"strconv"
+	"time"
+
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
 
 	"volcano.sh/apis/pkg/apis/scheduling"
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -26,6 +30,19 @@ import (
 // QueueID is UID type, serves as unique ID for each queue
 type QueueID types.UID
 
+// PreemptionPolicy is the policy of the jobs of a queue on which tasks they preempt or reclaim
+type PreemptionPolicy string
+
+const (
+	// PreemptNever means the jobs never preempt or reclaim
+	PreemptNever PreemptionPolicy = "Never"
+	// PreemptLowerPriority means the jobs only preempt or reclaim the tasks of lower priority
+	PreemptLowerPriority PreemptionPolicy = "LowerPriority"
+	// PreemptLowerPriorityAndOlder means the jobs only preempt or reclaim the tasks of lower priority of the jobs
+	// created after them
+	PreemptLowerPriorityAndOlder PreemptionPolicy = "LowerPriorityAndOlder"
+)
+
 // QueueInfo will have all details about queue
 type QueueInfo struct {
 	UID  QueueID
@@ -86,3 +103,55 @@ func (q *QueueInfo) Reclaimable() bool {
 
 	return *q.Queue.Spec.Reclaimable
 }
+
+// PreemptionPolicy returns the preemption policy of the jobs of the queue, it is empty if unset or invalid.
+func (q *QueueInfo) PreemptionPolicy() PreemptionPolicy {
+	if q == nil || q.Queue == nil {
+		return ""
+	}
+	value, found := q.Queue.Annotations[QueuePreemptionPolicyAnnotation]
+	if !found {
+		return ""
+	}
+	switch policy := PreemptionPolicy(value); policy {
+	case PreemptNever, PreemptLowerPriority, PreemptLowerPriorityAndOlder:
+		return policy
+	default:
+		klog.Warningf("Invalid %s %q of queue <%s>", QueuePreemptionPolicyAnnotation, value, q.Name)
+		return ""
+	}
+}
+
+// PreemptionProtectionPeriod returns how long the tasks of the queue are not evicted after they started.
+func (q *QueueInfo) PreemptionProtectionPeriod() time.Duration {
+	if q == nil || q.Queue == nil {
+		return 0
+	}
+	value, found := q.Queue.Annotations[QueuePreemptionProtectionAnnotation]
+	if !found {
+		return 0
+	}
+	period, err := time.ParseDuration(value)
+	if err != nil || period < 0 {
+		klog.Warningf("Invalid %s %q of queue <%s>", QueuePreemptionProtectionAnnotation, value, q.Name)
+		return 0
+	}
+	return period
+}
+
+// PreemptionBudget returns how many tasks of the queue are evicted per hour at most, and whether it is set.
+func (q *QueueInfo) PreemptionBudget() (int, bool) {
+	if q == nil || q.Queue == nil {
+		return 0, false
+	}
+	value, found := q.Queue.Annotations[QueuePreemptionBudgetAnnotation]
+	if !found {
+		return 0, false
+	}
+	budget, err := strconv.Atoi(value)
+	if err != nil || budget < 0 {
+		klog.Warningf("Invalid %s %q of queue <%s>", QueuePreemptionBudgetAnnotation, value, q.Name)
+		return 0, false
+	}
+	return budget, true
+}
@@ -76,9 +76,74 @@ type Devices interface {
 var _ Devices = new(gpushare.GPUDevices)
 var _ Devices = new(vgpu.GPUDevices)
 
-var RegisteredDevices = []string{
-	gpushare.DeviceName,
-	vgpu.DeviceName,
+// RegisteredDevices is the names of the registered device families, in the order they are registered
+var RegisteredDevices = []string{}
+
+// DeviceBuilder builds the devices of a family on the node. If the node has none of them, it returns a
+// nil pointer of the devices type, whose methods must handle the nil receiver.
+type DeviceBuilder func(nodeName string, node *v1.Node) Devices
+
+// DeviceConfigurer configures a device family with the arguments of the deviceshare plugin, e.g. to enable it.
+type DeviceConfigurer func(arguments map[string]interface{})
+
+type deviceRegistration struct {
+	builder   DeviceBuilder
+	configure DeviceConfigurer
+}
+
+var deviceRegistry = map[string]*deviceRegistration{}
+
+func init() {
+	RegisterDevice(gpushare.DeviceName, func(nodeName string, node *v1.Node) Devices {
+		return gpushare.NewGPUDevices(nodeName, node)
+	}, nil)
+	RegisterDevice(vgpu.DeviceName, func(nodeName string, node *v1.Node) Devices {
+		return vgpu.NewGPUDevices(nodeName, node)
+	}, nil)
+}
+
+// RegisterDevice registers a device family shared by the deviceshare plugin, the devices are kept in
+// NodeInfo.Others by its name. It is not thread safe and is supposed to be called in init(), a family
+// registered again replaces the former one.
+func RegisterDevice(name string, builder DeviceBuilder, configure DeviceConfigurer) {
+	if _, found := deviceRegistry[name]; !found {
+		RegisteredDevices = append(RegisteredDevices, name)
+	}
+	deviceRegistry[name] = &deviceRegistration{builder: builder, configure: configure}
+}
+
+// UnregisterDevice removes a registered device family, e.g. a fake one registered by tests.
+func UnregisterDevice(name string) {
+	if _, found := deviceRegistry[name]; !found {
+		return
+	}
+	delete(deviceRegistry, name)
+	for i, registered := range RegisteredDevices {
+		if registered == name {
+			RegisteredDevices = append(RegisteredDevices[:i:i], RegisteredDevices[i+1:]...)
+			break
+		}
+	}
+}
+
+// ConfigureDevices configures the registered device families with the arguments of the deviceshare plugin.
+func ConfigureDevices(arguments map[string]interface{}) {
+	for _, name := range RegisteredDevices {
+		if configure := deviceRegistry[name].configure; configure != nil {
+			configure(arguments)
+		}
+	}
+}
+
+// newDevices builds the devices of all the registered families on the node.
+func newDevices(nodeName string, node *v1.Node) map[string]Devices {
+	devices := make(map[string]Devices, len(RegisteredDevices))
+	for _, name := range RegisteredDevices {
+		if dev := deviceRegistry[name].builder(nodeName, node); dev != nil {
+			devices[name] = dev
+		}
+	}
+	return devices
 }
 
 var IgnoredDevicesList = ignoredDevicesList{}
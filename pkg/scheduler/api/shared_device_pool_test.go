@@ -5,6 +5,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/volcano/pkg/scheduler/api/devices/fake"
+	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/gpushare"
+	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/vgpu"
 )
 
 func Test_ignoredDevicesList_Set_BasicUsage(t *testing.T) {
@@ -124,3 +132,53 @@ func Test_ignoredDevicesList_NoRace(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+var _ Devices = new(fake.Devices)
+
+func TestRegisterDevice(t *testing.T) {
+	RegisterDevice(fake.DeviceName, func(nodeName string, node *v1.Node) Devices {
+		return fake.NewDevices(nodeName, node)
+	}, fake.Configure)
+	defer UnregisterDevice(fake.DeviceName)
+	defer func() { fake.Enable = false }()
+
+	assert.Equal(t, []string{gpushare.DeviceName, vgpu.DeviceName, fake.DeviceName}, RegisteredDevices)
+	ConfigureDevices(map[string]interface{}{fake.EnableArgument: true})
+	assert.True(t, fake.Enable)
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+		Status: v1.NodeStatus{
+			Capacity:    v1.ResourceList{fake.ResourceName: resource.MustParse("2")},
+			Allocatable: v1.ResourceList{fake.ResourceName: resource.MustParse("2")},
+		},
+	}
+	ni := NewNodeInfo(node)
+	devices, ok := ni.Others[fake.DeviceName].(*fake.Devices)
+	assert.True(t, ok)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "c1",
+			Name:        "p1",
+			UID:         types.UID("p1"),
+			Annotations: map[string]string{fake.AssignedIDsAnnotation: "1"},
+		},
+		Spec: v1.PodSpec{
+			NodeName: "n1",
+			Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{Limits: v1.ResourceList{fake.ResourceName: resource.MustParse("1")}},
+			}},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+	task := NewTaskInfo(pod)
+	assert.NoError(t, ni.AddTask(task))
+	assert.Equal(t, map[int]types.UID{1: "p1"}, devices.Used)
+	assert.NoError(t, ni.RemoveTask(task))
+	assert.Empty(t, devices.Used)
+
+	UnregisterDevice(fake.DeviceName)
+	assert.Equal(t, []string{gpushare.DeviceName, vgpu.DeviceName}, RegisteredDevices)
+	assert.NotContains(t, NewNodeInfo(node).Others, fake.DeviceName)
+}
@@ -303,6 +303,9 @@ type NodeOrderMapFn func(*TaskInfo, *NodeInfo) (map[string]float64, float64, err
 // NodeOrderReduceFn is the func declaration used to reduce priority score of all nodes for a plugin for a particular task.
 type NodeOrderReduceFn func(*TaskInfo, map[string]k8sframework.NodeScoreList) (map[string]float64, error)
 
+// HyperNodeOrderFn is the func declaration used to get priority score for hypernodes of the same tier for a particular job.
+type HyperNodeOrderFn func(*JobInfo, []*HyperNodeInfo) (map[string]float64, error)
+
 // TargetJobFn is the func declaration used to select the target job satisfies some conditions
 type TargetJobFn func([]*JobInfo) *JobInfo
 
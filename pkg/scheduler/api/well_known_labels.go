@@ -40,6 +40,37 @@ const (
 	// OfflineJobEvicting node will not schedule pod due to offline job evicting
 	OfflineJobEvicting = "volcano.sh/offline-job-evicting"
 
+	// CheckpointHookAnnotation is the key of the checkpoint hook of the job and its pods, the pods are asked to
+	// checkpoint before they are evicted: "condition" adds the CheckpointRequested condition to the pod, and a
+	// port and a path, e.g. "8080/checkpoint", are posted to over http on the pod ip
+	CheckpointHookAnnotation = "volcano.sh/checkpoint-hook"
+	// CheckpointGracePeriodAnnotation is the key of how long the eviction waits for the checkpoint, e.g. "60s"
+	CheckpointGracePeriodAnnotation = "volcano.sh/checkpoint-grace-period"
+	// CheckpointAnnotation is the key of the location of the last checkpoint, it acknowledges the checkpoint
+	// on the pod and is recorded on the job to resume the restarted pods from
+	CheckpointAnnotation = "volcano.sh/checkpoint"
+
+	// ElasticReplicasAnnotation is the key of the number of pods the scheduler allows an elastic job to run, it is
+	// set on the podgroup when the job is shrunk, and the job controller doesn't create more pods than it
+	ElasticReplicasAnnotation = "volcano.sh/elastic-replicas"
+	// ElasticMaxReplicasAnnotation is the key of the number of pods the elastic job ran before it was shrunk, the
+	// scheduler grows the job back up to it and removes both annotations then
+	ElasticMaxReplicasAnnotation = "volcano.sh/elastic-max-replicas"
+	// ElasticShrinkReason is the reason of the eviction of the pods of shrunk elastic jobs
+	ElasticShrinkReason = "elastic-shrink"
+
+	// QueuePreemptionPolicyAnnotation is the key of the preemption policy of the jobs of a queue: "Never",
+	// "LowerPriority" or "LowerPriorityAndOlder", the jobs preempt and reclaim by the plugins only if it is unset
+	QueuePreemptionPolicyAnnotation = "volcano.sh/preemption-policy"
+	// QueuePreemptionProtectionAnnotation is the key of how long the tasks of a queue are not preempted or reclaimed
+	// after they started, e.g. "10m"
+	QueuePreemptionProtectionAnnotation = "volcano.sh/preemption-protection-period"
+	// QueuePreemptionBudgetAnnotation is the key of how many tasks of a queue are evicted per hour at most, the
+	// preempt and reclaim actions don't evict more of its tasks once the evictions in the last hour reach it
+	QueuePreemptionBudgetAnnotation = "volcano.sh/preemption-budget"
+	// QueuePriorityClassAnnotation is the key of the priority class of the jobs of a queue which have none
+	QueuePriorityClassAnnotation = "volcano.sh/priority-class"
+
 	// topologyDecisionAnnotation is the key of topology decision about pod request resource
 	topologyDecisionAnnotation = "volcano.sh/topology-decision"
 )
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -43,6 +44,7 @@ import (
 	storagev1beta1 "k8s.io/client-go/informers/storage/v1beta1"
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -108,6 +110,10 @@ type SchedulerCache struct {
 	schedulerNames     []string
 	nodeSelectorLabels map[string]sets.Empty
 	metricsConf        map[string]string
+	// metricsClient is reused until the metrics configuration changes, it keeps the last metrics
+	// of the nodes when the metrics source is unreachable
+	metricsClient     source.MetricsClient
+	metricsClientConf map[string]string
 
 	podInformer                infov1.PodInformer
 	nodeInformer               infov1.NodeInformer
@@ -219,8 +225,9 @@ func NewDefaultBinder(kbclient kubernetes.Interface, record record.EventRecorder
 }
 
 type defaultEvictor struct {
-	kubeclient kubernetes.Interface
-	recorder   record.EventRecorder
+	kubeclient   kubernetes.Interface
+	recorder     record.EventRecorder
+	checkpointer *checkpointer
 }
 
 // Evict will send delete pod request to api server
@@ -233,6 +240,13 @@ func (de *defaultEvictor) Evict(p *v1.Pod, reason string) error {
 	de.recorder.AnnotatedEventf(p, annotations, v1.EventTypeWarning, "Evict", evictMsg)
 
 	pod := p.DeepCopy()
+	if _, found := p.Annotations[schedulingapi.CheckpointHookAnnotation]; found && de.checkpointer != nil {
+		de.checkpointer.Checkpoint(p, reason)
+		// the pod is updated by its checkpoint, get the latest one to update its status
+		if latest, err := de.kubeclient.CoreV1().Pods(p.Namespace).Get(context.TODO(), p.Name, metav1.GetOptions{}); err == nil {
+			pod = latest
+		}
+	}
 	condition := &v1.PodCondition{
 		Type:    v1.PodReady,
 		Status:  v1.ConditionFalse,
@@ -615,8 +629,9 @@ func newSchedulerCache(config *rest.Config, schedulerNames []string, defaultQueu
 	sc.Binder = GetBindMethod()
 
 	sc.Evictor = &defaultEvictor{
-		kubeclient: sc.kubeClient,
-		recorder:   sc.Recorder,
+		kubeclient:   sc.kubeClient,
+		recorder:     sc.Recorder,
+		checkpointer: newCheckpointer(sc.kubeClient, sc.Recorder),
 	}
 
 	sc.StatusUpdater = &defaultStatusUpdater{
@@ -1354,10 +1369,16 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 
 	cloneJob := func(value *schedulingapi.JobInfo) {
 		defer wg.Done()
+		var queueClass *schedulingv1.PriorityClass
 		if value.PodGroup != nil {
 			value.Priority = sc.defaultPriority
 
 			priName := value.PodGroup.Spec.PriorityClassName
+			// the jobs without a priority class have the one of their queue
+			if queue, found := sc.Queues[value.Queue]; priName == "" && found && queue.Queue != nil {
+				priName = queue.Queue.Annotations[schedulingapi.QueuePriorityClassAnnotation]
+				queueClass = sc.PriorityClasses[priName]
+			}
 			if priorityClass, found := sc.PriorityClasses[priName]; found {
 				value.Priority = priorityClass.Value
 			}
@@ -1367,6 +1388,19 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 		}
 
 		clonedJob := value.Clone()
+		// so do their tasks without a priority of their own; the cloned tasks are
+		// updated to leave the ones of the cache alone
+		if queueClass != nil {
+			for _, task := range clonedJob.Tasks {
+				if task.Pod == nil || task.Pod.Spec.PriorityClassName != "" {
+					continue
+				}
+				if _, found := task.Pod.Annotations[schedulingapi.TaskPriorityAnnotation]; found {
+					continue
+				}
+				task.Priority = queueClass.Value
+			}
+		}
 
 		cloneJobLock.Lock()
 		snapshot.Jobs[value.UID] = clonedJob
@@ -1546,10 +1580,18 @@ func (sc *SchedulerCache) GetMetricsData() {
 		return
 	}
 
-	client, err := source.NewMetricsClient(sc.restConfig, sc.metricsConf)
-	if err != nil {
-		klog.Errorf("Error creating client: %v\n", err)
-		return
+	if sc.metricsClient == nil || !reflect.DeepEqual(sc.metricsClientConf, sc.metricsConf) {
+		var nodeLister corelisters.NodeLister
+		if sc.nodeInformer != nil {
+			nodeLister = sc.nodeInformer.Lister()
+		}
+		client, err := source.NewMetricsClient(sc.restConfig, nodeLister, sc.metricsConf)
+		if err != nil {
+			klog.Errorf("Error creating client: %v\n", err)
+			return
+		}
+		sc.metricsClient = client
+		sc.metricsClientConf = sc.metricsConf
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
 	defer cancel()
@@ -1561,10 +1603,9 @@ func (sc *SchedulerCache) GetMetricsData() {
 	}
 	sc.Mutex.Unlock()
 
-	err = client.NodesMetricsAvg(ctx, nodeMetricsMap)
-	if err != nil {
+	// the cached metrics are still returned on error, and the stale ones are cleared
+	if err := sc.metricsClient.NodesMetricsAvg(ctx, nodeMetricsMap); err != nil {
 		klog.Errorf("Error getting node metrics: %v\n", err)
-		return
 	}
 
 	sc.setMetricsData(nodeMetricsMap)
@@ -62,8 +62,9 @@ func checkAndSetDefaultInterface(sc *SchedulerCache) {
 	}
 	if sc.Evictor == nil {
 		sc.Evictor = &defaultEvictor{
-			kubeclient: sc.kubeClient,
-			recorder:   sc.Recorder,
+			kubeclient:   sc.kubeClient,
+			recorder:     sc.Recorder,
+			checkpointer: newCheckpointer(sc.kubeClient, sc.Recorder),
 		}
 	}
 	if sc.StatusUpdater == nil {
@@ -28,6 +28,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
@@ -339,3 +343,34 @@ func TestBindTasks(t *testing.T) {
 		t.Fatalf("successfully binding task should have 1 event")
 	}
 }
+
+func TestSnapshotQueuePriorityClass(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.AddPriorityClass(util.BuildPriorityClass("high", 100))
+	sc.AddPriorityClass(util.BuildPriorityClass("low", 10))
+	sc.AddQueueV1beta1(util.BuildQueueWithAnnos("q1", 1, nil, map[string]string{api.QueuePriorityClassAnnotation: "high"}))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPodGroupV1beta1(util.BuildPodGroupWithPrio("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "low"))
+	sc.AddPod(util.BuildPod("ns1", "p1", "", v1.PodPending, nil, "pg1", nil, nil))
+	p2 := util.BuildPod("ns1", "p2", "", v1.PodPending, nil, "pg1", nil, nil)
+	p2.Spec.PriorityClassName = "low"
+	p2.Spec.Priority = ptr.To(int32(10))
+	sc.AddPod(p2)
+
+	snapshot := sc.Snapshot()
+	if priority := snapshot.Jobs["ns1/pg1"].Priority; priority != 100 {
+		t.Errorf("job without priority class: want the priority 100 of its queue, got %d", priority)
+	}
+	if priority := snapshot.Jobs["ns1/pg2"].Priority; priority != 10 {
+		t.Errorf("job with priority class: want its priority 10, got %d", priority)
+	}
+	if priority := snapshot.Jobs["ns1/pg1"].Tasks["ns1-p1"].Priority; priority != 100 {
+		t.Errorf("task without priority class: want the priority 100 of its queue, got %d", priority)
+	}
+	if priority := snapshot.Jobs["ns1/pg1"].Tasks["ns1-p2"].Priority; priority != 10 {
+		t.Errorf("task with priority class: want its priority 10, got %d", priority)
+	}
+	if priority := sc.Jobs["ns1/pg1"].Tasks["ns1-p1"].Priority; priority == 100 {
+		t.Errorf("task of the cache: want its priority left alone, got %d", priority)
+	}
+}
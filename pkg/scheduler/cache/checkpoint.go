@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// CheckpointHookCondition is the checkpoint hook which asks the pod to checkpoint by a pod condition
+	CheckpointHookCondition = "condition"
+	// PodCheckpointRequested is the condition added to the pods asked to checkpoint before they are evicted
+	PodCheckpointRequested v1.PodConditionType = "CheckpointRequested"
+
+	// DefaultCheckpointGracePeriod is how long the eviction waits for the checkpoint by default
+	DefaultCheckpointGracePeriod = 30 * time.Second
+	// maxCheckpointGracePeriod bounds the grace period given by the users
+	maxCheckpointGracePeriod = 10 * time.Minute
+	// checkpointPollInterval is how often the pod is checked for the acknowledgement of the checkpoint
+	checkpointPollInterval = time.Second
+)
+
+// checkpointer asks the pods with a checkpoint hook to checkpoint before they are evicted, and waits
+// for the acknowledgement until the grace period of the pod is over.
+type checkpointer struct {
+	kubeclient   kubernetes.Interface
+	recorder     record.EventRecorder
+	httpClient   *http.Client
+	pollInterval time.Duration
+}
+
+func newCheckpointer(kubeclient kubernetes.Interface, recorder record.EventRecorder) *checkpointer {
+	return &checkpointer{
+		kubeclient: kubeclient,
+		recorder:   recorder,
+		// the hook is only called on the pod, it must not redirect the scheduler elsewhere
+		httpClient: &http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		pollInterval: checkpointPollInterval,
+	}
+}
+
+// checkpointGracePeriod returns how long the eviction of the pod waits for its checkpoint.
+func checkpointGracePeriod(pod *v1.Pod) time.Duration {
+	value, found := pod.Annotations[schedulingapi.CheckpointGracePeriodAnnotation]
+	if !found {
+		return DefaultCheckpointGracePeriod
+	}
+	period, err := time.ParseDuration(value)
+	if err != nil || period <= 0 {
+		klog.Warningf("Invalid checkpoint grace period %q of pod <%s/%s>, use %v", value, pod.Namespace, pod.Name, DefaultCheckpointGracePeriod)
+		return DefaultCheckpointGracePeriod
+	}
+	if period > maxCheckpointGracePeriod {
+		return maxCheckpointGracePeriod
+	}
+	return period
+}
+
+// Checkpoint asks the pod to checkpoint if it has a checkpoint hook, and returns whether the checkpoint is
+// acknowledged. The pod is evicted anyway, a failed or timed out checkpoint is only reported by an event.
+func (c *checkpointer) Checkpoint(pod *v1.Pod, reason string) bool {
+	hook, found := pod.Annotations[schedulingapi.CheckpointHookAnnotation]
+	if !found || hook == "" {
+		return false
+	}
+
+	gracePeriod := checkpointGracePeriod(pod)
+	ctx, cancel := context.WithTimeout(context.TODO(), gracePeriod)
+	defer cancel()
+
+	klog.V(3).Infof("Asking pod <%s/%s> to checkpoint by %s before it is evicted, grace period %v", pod.Namespace, pod.Name, hook, gracePeriod)
+	c.recorder.Eventf(pod, v1.EventTypeNormal, "CheckpointRequested", "Pod is asked to checkpoint before it is evicted, because of %v", reason)
+
+	var location string
+	var err error
+	if hook == CheckpointHookCondition {
+		location, err = c.checkpointByCondition(ctx, pod, reason)
+	} else {
+		location, err = c.checkpointByHTTP(ctx, pod, hook, reason)
+	}
+	if err != nil {
+		klog.Warningf("Pod <%s/%s> is evicted without checkpoint: %v", pod.Namespace, pod.Name, err)
+		c.recorder.Eventf(pod, v1.EventTypeWarning, "CheckpointFailed", "Pod is evicted without checkpoint: %v", err)
+		return false
+	}
+
+	klog.V(3).Infof("Pod <%s/%s> checkpointed to %q", pod.Namespace, pod.Name, location)
+	c.recorder.Eventf(pod, v1.EventTypeNormal, "Checkpointed", "Pod checkpointed to %q before it is evicted", location)
+	return true
+}
+
+// checkpointByCondition adds the CheckpointRequested condition to the pod, and waits for the pod to
+// acknowledge the checkpoint by the checkpoint annotation.
+func (c *checkpointer) checkpointByCondition(ctx context.Context, pod *v1.Pod, reason string) (string, error) {
+	requested := pod.DeepCopy()
+	podutil.UpdatePodCondition(&requested.Status, &v1.PodCondition{
+		Type:    PodCheckpointRequested,
+		Status:  v1.ConditionTrue,
+		Reason:  "Evict",
+		Message: fmt.Sprintf("Pod is going to be evicted, because of %v", reason),
+	})
+	if _, err := c.kubeclient.CoreV1().Pods(pod.Namespace).UpdateStatus(ctx, requested, metav1.UpdateOptions{}); err != nil {
+		return "", fmt.Errorf("failed to request checkpoint: %v", err)
+	}
+
+	var location string
+	err := wait.PollUntilContextCancel(ctx, c.pollInterval, false, func(ctx context.Context) (bool, error) {
+		current, err := c.kubeclient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.V(4).Infof("Failed to get pod <%s/%s> waiting for its checkpoint: %v", pod.Namespace, pod.Name, err)
+			return false, nil
+		}
+		if current.UID != pod.UID {
+			return false, fmt.Errorf("pod is replaced")
+		}
+		var found bool
+		location, found = current.Annotations[schedulingapi.CheckpointAnnotation]
+		return found, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("checkpoint is not acknowledged: %v", err)
+	}
+	return location, nil
+}
+
+// checkpointHookURL returns the url of the hook of the pod, the hook only gives the port and the path,
+// e.g. "8080/checkpoint", and the url is always on the pod ip, since the hook is set by the users.
+func checkpointHookURL(pod *v1.Pod, hook string) (*url.URL, error) {
+	port, path, _ := strings.Cut(hook, "/")
+	if number, err := strconv.Atoi(port); err != nil || number <= 0 || number > 65535 {
+		return nil, fmt.Errorf("invalid checkpoint hook %q, it must be a port and a path", hook)
+	}
+	if pod.Status.PodIP == "" {
+		return nil, fmt.Errorf("pod has no ip for checkpoint hook %q", hook)
+	}
+	return &url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort(pod.Status.PodIP, port),
+		Path:   "/" + path,
+	}, nil
+}
+
+// checkpointByHTTP posts the checkpoint request to the hook of the pod, a successful response acknowledges
+// the checkpoint and its body is the location of the checkpoint, which is recorded on the pod.
+func (c *checkpointer) checkpointByHTTP(ctx context.Context, pod *v1.Pod, hook string, reason string) (string, error) {
+	hookURL, err := checkpointHookURL(pod, hook)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]string{"namespace": pod.Namespace, "name": pod.Name, "reason": reason})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hookURL.String(), strings.NewReader(string(body)))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call checkpoint hook: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("checkpoint hook returned %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the response of checkpoint hook: %v", err)
+	}
+
+	location := strings.TrimSpace(string(data))
+	if location == "" {
+		return "", nil
+	}
+	// record the location on the pod, the job controller resumes the restarted pods from it
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{schedulingapi.CheckpointAnnotation: location},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := c.kubeclient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return "", fmt.Errorf("failed to record checkpoint %q: %v", location, err)
+	}
+	return location, nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func buildCheckpointPod(hook, gracePeriod string) *v1.Pod {
+	pod := util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+	pod.Annotations[api.CheckpointHookAnnotation] = hook
+	pod.Annotations[api.CheckpointGracePeriodAnnotation] = gracePeriod
+	pod.Status.PodIP = "127.0.0.1"
+	return pod
+}
+
+func TestEvictWithCheckpointCondition(t *testing.T) {
+	tests := []struct {
+		name        string
+		acknowledge bool
+		expectEvent string
+	}{
+		{
+			name:        "pod is deleted after the checkpoint is acknowledged",
+			acknowledge: true,
+			expectEvent: "Checkpointed",
+		},
+		{
+			name:        "pod is deleted when the grace period is over",
+			expectEvent: "CheckpointFailed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := buildCheckpointPod(CheckpointHookCondition, "500ms")
+			kubeclient := fake.NewSimpleClientset(pod)
+			recorder := record.NewFakeRecorder(10)
+			evictor := &defaultEvictor{kubeclient: kubeclient, recorder: recorder, checkpointer: newCheckpointer(kubeclient, recorder)}
+			evictor.checkpointer.pollInterval = 10 * time.Millisecond
+
+			if test.acknowledge {
+				// the workload acknowledges the checkpoint when it sees the condition
+				go func() {
+					for {
+						current, err := kubeclient.CoreV1().Pods("c1").Get(context.TODO(), "p1", metav1.GetOptions{})
+						if err == nil {
+							if _, condition := podutil.GetPodCondition(&current.Status, PodCheckpointRequested); condition != nil {
+								current.Annotations[api.CheckpointAnnotation] = "s3://ckpt/1"
+								_, _ = kubeclient.CoreV1().Pods("c1").Update(context.TODO(), current, metav1.UpdateOptions{})
+								return
+							}
+						}
+						time.Sleep(10 * time.Millisecond)
+					}
+				}()
+			}
+
+			assert.NoError(t, evictor.Evict(pod, "preempt"))
+			_, err := kubeclient.CoreV1().Pods("c1").Get(context.TODO(), "p1", metav1.GetOptions{})
+			assert.True(t, apierrors.IsNotFound(err))
+
+			events := []string{}
+			for len(recorder.Events) > 0 {
+				events = append(events, <-recorder.Events)
+			}
+			assert.Len(t, events, 3)
+			assert.Contains(t, events[2], test.expectEvent)
+		})
+	}
+}
+
+func TestCheckpointByHTTP(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		body             string
+		expectAck        bool
+		expectCheckpoint string
+	}{
+		{
+			name:             "location in the response is recorded on the pod",
+			status:           http.StatusOK,
+			body:             "s3://ckpt/2\n",
+			expectAck:        true,
+			expectCheckpoint: "s3://ckpt/2",
+		},
+		{
+			name:      "error response is not an acknowledgement",
+			status:    http.StatusInternalServerError,
+			expectAck: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/checkpoint", r.URL.Path)
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			serverURL, err := url.Parse(server.URL)
+			assert.NoError(t, err)
+			pod := buildCheckpointPod(serverURL.Port()+"/checkpoint", "")
+			kubeclient := fake.NewSimpleClientset(pod)
+			c := newCheckpointer(kubeclient, record.NewFakeRecorder(10))
+
+			assert.Equal(t, test.expectAck, c.Checkpoint(pod, "reclaim"))
+			current, err := kubeclient.CoreV1().Pods("c1").Get(context.TODO(), "p1", metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectCheckpoint, current.Annotations[api.CheckpointAnnotation])
+		})
+	}
+}
+
+func TestCheckpointHookURL(t *testing.T) {
+	pod := buildCheckpointPod("", "")
+	hookURL, err := checkpointHookURL(pod, "8080/checkpoint")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:8080/checkpoint", hookURL.String())
+
+	// the hook can not name another host than the pod
+	for _, hook := range []string{"http://10.0.0.1:8080/checkpoint", "http://:8080/checkpoint", "10.0.0.1:8080/checkpoint", "0/checkpoint"} {
+		_, err := checkpointHookURL(pod, hook)
+		assert.Error(t, err, hook)
+	}
+
+	pod.Status.PodIP = ""
+	_, err = checkpointHookURL(pod, "8080/checkpoint")
+	assert.Error(t, err)
+}
+
+func TestCheckpointGracePeriod(t *testing.T) {
+	assert.Equal(t, DefaultCheckpointGracePeriod, checkpointGracePeriod(buildCheckpointPod(CheckpointHookCondition, "invalid")))
+	assert.Equal(t, time.Minute, checkpointGracePeriod(buildCheckpointPod(CheckpointHookCondition, "1m")))
+	assert.Equal(t, maxCheckpointGracePeriod, checkpointGracePeriod(buildCheckpointPod(CheckpointHookCondition, "24h")))
+}
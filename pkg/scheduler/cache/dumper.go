@@ -17,8 +17,8 @@
 package cache
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"path"
@@ -34,15 +34,22 @@ import (
 
 // Dumper writes some information from the scheduler cache to the scheduler logs
 // for debugging purposes. Usage: run `kill -s USR2 <pid>` in the shell, where <pid>
-// is the process id of the scheduler process.
+// is the process id of the scheduler process. `kill -s USR1 <pid>` dumps a snapshot of
+// the cache to a json file under RootDir, the same snapshot is also served over http by
+// ServeHTTP.
 type Dumper struct {
 	Cache   Cache
 	RootDir string // target directory for the dumped json file
 }
 
-// dumpToJSONFile marsh scheduler cache snapshot to json file
+// dumpToJSONFile marsh scheduler cache snapshot to json file, the file can be loaded
+// back by LoadSnapshotFile.
 func (d *Dumper) dumpToJSONFile() {
-	snapshot := d.Cache.Snapshot()
+	snapshot, err := NewSnapshot(d.Cache.Snapshot())
+	if err != nil {
+		klog.Errorf("error creating snapshot: %v", err)
+		return
+	}
 	name := fmt.Sprintf("snapshot-%d.json", time.Now().Unix())
 	fName := path.Join(d.RootDir, name)
 	file, err := os.OpenFile(fName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
@@ -52,7 +59,7 @@ func (d *Dumper) dumpToJSONFile() {
 	}
 	defer file.Close()
 	klog.Infoln("Starting to dump info in scheduler cache to file", fName)
-	if err = json.NewEncoder(file).Encode(snapshot.Nodes); err != nil {
+	if err = snapshot.Encode(file); err != nil {
 		klog.Errorf("Failed to dump info in scheduler cache, json encode error: %v", err)
 		return
 	}
@@ -60,6 +67,40 @@ func (d *Dumper) dumpToJSONFile() {
 	klog.Infoln("Successfully dump info in scheduler cache to file", fName)
 }
 
+// ServeHTTP streams a snapshot of the scheduler cache as json. The snapshot is gzip compressed
+// when the client accepts gzip encoding, or as a gzip file when the gzip query parameter is true.
+func (d *Dumper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	snapshot, err := NewSnapshot(d.Cache.Snapshot())
+	if err != nil {
+		klog.Errorf("Failed to create snapshot of scheduler cache: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	name := fmt.Sprintf("snapshot-%d.json", snapshot.Timestamp.Unix())
+	switch {
+	case r.URL.Query().Get("gzip") == "true":
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.gz", name))
+		err = snapshot.EncodeGzip(w)
+	case strings.Contains(r.Header.Get("Accept-Encoding"), "gzip"):
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		err = snapshot.EncodeGzip(w)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		err = snapshot.Encode(w)
+	}
+	if err != nil {
+		klog.Errorf("Failed to write snapshot of scheduler cache: %v", err)
+	}
+}
+
 // dumpAll prints all information to log
 func (d *Dumper) dumpAll() {
 	snapshot := d.Cache.Snapshot()
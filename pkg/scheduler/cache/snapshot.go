@@ -0,0 +1,293 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	nodeinfov1alpha1 "volcano.sh/apis/pkg/apis/nodeinfo/v1alpha1"
+	schedulingscheme "volcano.sh/apis/pkg/apis/scheduling/scheme"
+	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// SnapshotVersion is the version of the snapshot format, it must be bumped when a change of
+// the format can not be read by older versions of the simulator.
+const SnapshotVersion = "v1"
+
+// defaultPriorityClassName is the name of the synthesized global default PriorityClass, it is
+// used when jobs without a PriorityClassName have a non-zero priority in the dumped cache.
+const defaultPriorityClassName = "volcano-snapshot-default"
+
+// Snapshot is a reloadable dump of the scheduler cache. Instead of the derived scheduling
+// structures it records the objects the cache was built from, so that feeding them back
+// through the cache event handlers rebuilds an equivalent api.ClusterInfo.
+type Snapshot struct {
+	// Version is the version of the snapshot format
+	Version string `json:"version"`
+	// Timestamp is the time the snapshot was taken
+	Timestamp metav1.Time `json:"timestamp"`
+
+	Nodes           []*v1.Node                       `json:"nodes,omitempty"`
+	Pods            []*v1.Pod                        `json:"pods,omitempty"`
+	PodGroups       []*vcv1beta1.PodGroup            `json:"podGroups,omitempty"`
+	Queues          []*vcv1beta1.Queue               `json:"queues,omitempty"`
+	PriorityClasses []*schedulingv1.PriorityClass    `json:"priorityClasses,omitempty"`
+	ResourceQuotas  []*v1.ResourceQuota              `json:"resourceQuotas,omitempty"`
+	Numatopologies  []*nodeinfov1alpha1.Numatopology `json:"numatopologies,omitempty"`
+	// RevocableNodes lists the nodes in a revocable zone, it is derived from the node labels
+	// and only recorded for inspection.
+	RevocableNodes []string `json:"revocableNodes,omitempty"`
+}
+
+// NewSnapshot builds a reloadable Snapshot from the cluster info returned by Cache.Snapshot.
+func NewSnapshot(ci *schedulingapi.ClusterInfo) (*Snapshot, error) {
+	s := &Snapshot{
+		Version:   SnapshotVersion,
+		Timestamp: metav1.Now(),
+	}
+
+	for _, name := range sortedKeys(ci.Nodes) {
+		node := ci.Nodes[name]
+		if node.Node != nil {
+			s.Nodes = append(s.Nodes, node.Node)
+		}
+		if node.NumaInfo != nil {
+			s.Numatopologies = append(s.Numatopologies, newNumatopology(node.NumaInfo))
+		}
+	}
+	s.RevocableNodes = sortedKeys(ci.RevocableNodes)
+
+	for _, name := range sortedKeys(ci.Queues) {
+		queue := ci.Queues[name]
+		if queue.Queue == nil {
+			continue
+		}
+		q := &vcv1beta1.Queue{}
+		if err := schedulingscheme.Scheme.Convert(queue.Queue, q, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert queue <%s>: %v", queue.Name, err)
+		}
+		s.Queues = append(s.Queues, q)
+	}
+
+	pods := map[types.UID]*v1.Pod{}
+	priorities := map[string]int32{}
+	for _, uid := range sortedKeys(ci.Jobs) {
+		job := ci.Jobs[uid]
+		if job.PodGroup != nil {
+			pg := &vcv1beta1.PodGroup{}
+			if err := schedulingscheme.Scheme.Convert(&job.PodGroup.PodGroup, pg, nil); err != nil {
+				return nil, fmt.Errorf("failed to convert podgroup of job <%s/%s>: %v", job.Namespace, job.Name, err)
+			}
+			s.PodGroups = append(s.PodGroups, pg)
+
+			name := pg.Spec.PriorityClassName
+			if name == "" && job.Priority != 0 {
+				name = defaultPriorityClassName
+			}
+			if name != "" {
+				priorities[name] = job.Priority
+			}
+		}
+		for _, task := range job.Tasks {
+			addTaskPod(pods, task)
+		}
+	}
+	for _, node := range ci.Nodes {
+		for _, task := range node.Tasks {
+			addTaskPod(pods, task)
+		}
+	}
+	for _, uid := range sortedKeys(pods) {
+		s.Pods = append(s.Pods, pods[uid])
+	}
+
+	for _, name := range sortedKeys(priorities) {
+		s.PriorityClasses = append(s.PriorityClasses, &schedulingv1.PriorityClass{
+			ObjectMeta:    metav1.ObjectMeta{Name: name},
+			Value:         priorities[name],
+			GlobalDefault: name == defaultPriorityClassName,
+		})
+	}
+
+	for _, name := range sortedKeys(ci.NamespaceInfo) {
+		ns := ci.NamespaceInfo[name]
+		for _, quotaName := range sortedKeys(ns.QuotaStatus) {
+			s.ResourceQuotas = append(s.ResourceQuotas, &v1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: quotaName, Namespace: string(ns.Name)},
+				Status:     ns.QuotaStatus[quotaName],
+			})
+		}
+	}
+
+	return s, nil
+}
+
+// addTaskPod records the pod of the task, a task which is allocated in the cache but whose
+// pod has not been bound yet is recorded as if it were already running on the chosen node.
+func addTaskPod(pods map[types.UID]*v1.Pod, task *schedulingapi.TaskInfo) {
+	if task.Pod == nil {
+		return
+	}
+	if _, found := pods[task.Pod.UID]; found {
+		return
+	}
+	pod := task.Pod
+	if pod.Spec.NodeName == "" && task.NodeName != "" && schedulingapi.AllocatedStatus(task.Status) {
+		pod = pod.DeepCopy()
+		pod.Spec.NodeName = task.NodeName
+	}
+	pods[pod.UID] = pod
+}
+
+// newNumatopology converts the numa info of a node back to the Numatopology it was built from.
+func newNumatopology(info *schedulingapi.NumatopoInfo) *nodeinfov1alpha1.Numatopology {
+	numatopo := &nodeinfov1alpha1.Numatopology{
+		ObjectMeta: metav1.ObjectMeta{Name: info.Name, Namespace: info.Namespace},
+		Spec: nodeinfov1alpha1.NumatopoSpec{
+			Policies:    make(map[nodeinfov1alpha1.PolicyName]string, len(info.Policies)),
+			ResReserved: make(map[string]string, len(info.ResReserved)),
+			NumaResMap:  make(map[string]nodeinfov1alpha1.ResourceInfo, len(info.NumaResMap)),
+			CPUDetail:   make(map[string]nodeinfov1alpha1.CPUInfo, len(info.CPUDetail)),
+		},
+	}
+	for name, policy := range info.Policies {
+		numatopo.Spec.Policies[name] = policy
+	}
+	for name, quantity := range info.ResReserved {
+		numatopo.Spec.ResReserved[string(name)] = quantity.String()
+	}
+	for name, res := range info.NumaResMap {
+		numatopo.Spec.NumaResMap[name] = nodeinfov1alpha1.ResourceInfo{
+			Allocatable: res.Allocatable.String(),
+			Capacity:    res.Capacity,
+		}
+	}
+	for cpuID, detail := range info.CPUDetail {
+		numatopo.Spec.CPUDetail[strconv.Itoa(cpuID)] = nodeinfov1alpha1.CPUInfo{
+			NUMANodeID: detail.NUMANodeID,
+			SocketID:   detail.SocketID,
+			CoreID:     detail.CoreID,
+		}
+	}
+	return numatopo
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// Encode writes the snapshot as json to w.
+func (s *Snapshot) Encode(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// EncodeGzip writes the snapshot as gzip compressed json to w.
+func (s *Snapshot) EncodeGzip(w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	if err := s.Encode(gw); err != nil {
+		gw.Close()
+		return err
+	}
+	return gw.Close()
+}
+
+// DecodeSnapshot reads a json encoded snapshot from r, which may be gzip compressed.
+func DecodeSnapshot(r io.Reader) (*Snapshot, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	} else {
+		r = br
+	}
+
+	s := &Snapshot{}
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, err
+	}
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %q, want %q", s.Version, SnapshotVersion)
+	}
+	return s, nil
+}
+
+// LoadSnapshotFile reads a snapshot written by the Dumper from the given file.
+func LoadSnapshotFile(fileName string) (*Snapshot, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	s, err := DecodeSnapshot(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %v", fileName, err)
+	}
+	return s, nil
+}
+
+// LoadSnapshot adds all objects recorded in the snapshot to the scheduler cache through the same
+// handlers used by the informers.
+func (sc *SchedulerCache) LoadSnapshot(s *Snapshot) {
+	for _, pc := range s.PriorityClasses {
+		sc.AddPriorityClass(pc)
+	}
+	for _, queue := range s.Queues {
+		sc.AddQueueV1beta1(queue)
+	}
+	for _, node := range s.Nodes {
+		if err := sc.AddOrUpdateNode(node); err != nil {
+			klog.Errorf("Failed to add node <%s> from snapshot: %v", node.Name, err)
+		}
+	}
+	for _, numatopo := range s.Numatopologies {
+		sc.AddNumaInfoV1alpha1(numatopo)
+	}
+	for _, pg := range s.PodGroups {
+		sc.AddPodGroupV1beta1(pg)
+	}
+	for _, pod := range s.Pods {
+		sc.AddPod(pod)
+	}
+	for _, quota := range s.ResourceQuotas {
+		sc.AddResourceQuota(quota)
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodeinfov1alpha1 "volcano.sh/apis/pkg/apis/nodeinfo/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.AddPriorityClass(util.BuildPriorityClass("high", 100))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	sc.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroupWithPrio("pg1", "ns1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning, "high"))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPod(util.BuildPod("ns1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	sc.AddPod(util.BuildPod("ns1", "p2", "n2", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	sc.AddPod(util.BuildPod("ns1", "p3", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil))
+	// a pod which is not scheduled by volcano is only accounted on its node
+	sc.AddPod(util.BuildPod("ns2", "other", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "", nil, nil))
+	sc.AddResourceQuota(util.BuildResourceQuota("rq1", "ns1", api.BuildResourceList("10", "10Gi")))
+	sc.AddNumaInfoV1alpha1(&nodeinfov1alpha1.Numatopology{
+		ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+		Spec: nodeinfov1alpha1.NumatopoSpec{
+			Policies:    map[nodeinfov1alpha1.PolicyName]string{nodeinfov1alpha1.CPUManagerPolicy: "static"},
+			ResReserved: map[string]string{"cpu": "1"},
+			NumaResMap:  map[string]nodeinfov1alpha1.ResourceInfo{"cpu": {Allocatable: "0-3", Capacity: 4}},
+			CPUDetail: map[string]nodeinfov1alpha1.CPUInfo{
+				"0": {NUMANodeID: 0}, "1": {NUMANodeID: 0, CoreID: 1}, "2": {NUMANodeID: 1, CoreID: 2}, "3": {NUMANodeID: 1, CoreID: 3},
+			},
+		},
+	})
+
+	// task p3 is allocated in the cache but not bound yet
+	job := sc.Jobs["ns1/pg2"]
+	for _, task := range job.Tasks {
+		task.NodeName = "n2"
+		if err := sc.AddBindTask(task); err != nil {
+			t.Fatalf("failed to bind task: %v", err)
+		}
+	}
+
+	snapshot, err := NewSnapshot(sc.Snapshot())
+	if err != nil {
+		t.Fatalf("failed to create snapshot: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := snapshot.EncodeGzip(&buf); err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+	decoded, err := DecodeSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+
+	restored := NewDefaultMockSchedulerCache("volcano")
+	restored.LoadSnapshot(decoded)
+
+	want, got := sc.Snapshot(), restored.Snapshot()
+	if len(want.Jobs) != len(got.Jobs) || len(want.Nodes) != len(got.Nodes) || len(want.Queues) != len(got.Queues) {
+		t.Fatalf("restored cluster differs, want %d jobs %d nodes %d queues, got %d jobs %d nodes %d queues",
+			len(want.Jobs), len(want.Nodes), len(want.Queues), len(got.Jobs), len(got.Nodes), len(got.Queues))
+	}
+	for uid, job := range want.Jobs {
+		restoredJob, found := got.Jobs[uid]
+		if !found {
+			t.Fatalf("job %s is missing in restored cluster", uid)
+		}
+		if job.Priority != restoredJob.Priority {
+			t.Errorf("job %s: want priority %d, got %d", uid, job.Priority, restoredJob.Priority)
+		}
+		if len(job.Tasks) != len(restoredJob.Tasks) || job.ReadyTaskNum() != restoredJob.ReadyTaskNum() {
+			t.Errorf("job %s: want %d tasks %d ready, got %d tasks %d ready", uid,
+				len(job.Tasks), job.ReadyTaskNum(), len(restoredJob.Tasks), restoredJob.ReadyTaskNum())
+		}
+	}
+	for name, node := range want.Nodes {
+		if !equality.Semantic.DeepEqual(node.Idle, got.Nodes[name].Idle) {
+			t.Errorf("node %s: want idle %v, got %v", name, node.Idle, got.Nodes[name].Idle)
+		}
+		if !equality.Semantic.DeepEqual(node.NumaInfo, got.Nodes[name].NumaInfo) {
+			t.Errorf("node %s: want numa info %v, got %v", name, node.NumaInfo, got.Nodes[name].NumaInfo)
+		}
+	}
+	if !equality.Semantic.DeepEqual(want.NamespaceInfo, got.NamespaceInfo) {
+		t.Errorf("want namespaces %v, got %v", want.NamespaceInfo, got.NamespaceInfo)
+	}
+}
+
+func TestDecodeSnapshotVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "current version",
+			data: `{"version":"` + SnapshotVersion + `"}`,
+		},
+		{
+			name:    "unknown version",
+			data:    `{"version":"v0"}`,
+			wantErr: true,
+		},
+		{
+			name:    "legacy node list",
+			data:    `{"n1":{"Name":"n1"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := DecodeSnapshot(bytes.NewBufferString(test.data))
+		if (err != nil) != test.wantErr {
+			t.Errorf("case %s: want error %v, got %v", test.name, test.wantErr, err)
+		}
+	}
+}
+
+func TestDumperServeHTTP(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), map[string]string{schedulingv1beta1.RevocableZone: "rz1"}))
+	dumper := &Dumper{Cache: sc}
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		acceptEncoding string
+		wantCode       int
+		wantEncoding   string
+	}{
+		{
+			name:     "plain json",
+			method:   http.MethodGet,
+			url:      "/debug/snapshot",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:           "gzip encoding",
+			method:         http.MethodGet,
+			url:            "/debug/snapshot",
+			acceptEncoding: "gzip, deflate",
+			wantCode:       http.StatusOK,
+			wantEncoding:   "gzip",
+		},
+		{
+			name:     "gzip file",
+			method:   http.MethodGet,
+			url:      "/debug/snapshot?gzip=true",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "method not allowed",
+			method:   http.MethodPost,
+			url:      "/debug/snapshot",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.url, nil)
+		if test.acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", test.acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		dumper.ServeHTTP(rec, req)
+
+		if rec.Code != test.wantCode {
+			t.Fatalf("case %s: want code %d, got %d", test.name, test.wantCode, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+		if got := rec.Header().Get("Content-Encoding"); got != test.wantEncoding {
+			t.Errorf("case %s: want content encoding %q, got %q", test.name, test.wantEncoding, got)
+		}
+		snapshot, err := DecodeSnapshot(rec.Body)
+		if err != nil {
+			t.Fatalf("case %s: failed to decode snapshot: %v", test.name, err)
+		}
+		if len(snapshot.Nodes) != 1 || len(snapshot.Queues) != 1 || !equality.Semantic.DeepEqual(snapshot.RevocableNodes, []string{"n1"}) {
+			t.Errorf("case %s: unexpected snapshot %+v", test.name, snapshot)
+		}
+	}
+}
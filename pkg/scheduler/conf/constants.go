@@ -3,4 +3,13 @@ package conf
 const (
 	// EnablePredicateErrCacheKey is the key whether predicate error cache is enabled
 	EnablePredicateErrCacheKey = "predicateErrorCacheEnable"
+	// DryRunKey is the key whether the victims are only reported instead of being evicted
+	DryRunKey = "dryRun"
+	// BackfillPolicyKey is the key of the policy the backfill action reserves resources for the blocked jobs by,
+	// "easy" or "conservative"; the allocate action leaves the jobs after a blocked job to backfill if it is set
+	BackfillPolicyKey = "backfillPolicy"
+	// BackfillPolicyEasy reserves the earliest start of the first blocked job only
+	BackfillPolicyEasy = "easy"
+	// BackfillPolicyConservative reserves the earliest start of every blocked job
+	BackfillPolicyConservative = "conservative"
 )
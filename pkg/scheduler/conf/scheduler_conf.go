@@ -73,6 +73,8 @@ type PluginOption struct {
 	EnabledBestNode *bool `yaml:"enableBestNode"`
 	// EnabledNodeOrder defines whether NodeOrderFn is enabled
 	EnabledNodeOrder *bool `yaml:"enableNodeOrder"`
+	// EnabledHyperNodeOrder defines whether hyperNodeOrderFn is enabled
+	EnabledHyperNodeOrder *bool `yaml:"enableHyperNodeOrder"`
 	// EnabledTargetJob defines whether targetJobFn is enabled
 	EnabledTargetJob *bool `yaml:"enableTargetJob"`
 	// EnabledReservedNodes defines whether reservedNodesFn is enabled
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+// DryRunEvictionReason is the reason of the events of the victims which are not evicted in dry-run mode.
+const DryRunEvictionReason = "DryRunEviction"
+
+// DryRunVictim is a task which would have been evicted.
+type DryRunVictim struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Job       api.JobID `json:"job"`
+	Queue     string    `json:"queue,omitempty"`
+	Node      string    `json:"node,omitempty"`
+	// Reasons are why the task is a victim, e.g. the plugins or strategies which selected it
+	Reasons []string `json:"reasons"`
+}
+
+// DryRunReport is the victims which would have been evicted by an action in a session.
+type DryRunReport struct {
+	Session types.UID      `json:"session"`
+	Action  string         `json:"action"`
+	Time    time.Time      `json:"time"`
+	Victims []DryRunVictim `json:"victims"`
+}
+
+// DryRunRecorder keeps the dry-run reports of the last sessions.
+type DryRunRecorder struct {
+	mutex    sync.Mutex
+	capacity int
+	next     int
+	reports  []*DryRunReport
+}
+
+// NewDryRunRecorder returns a recorder which keeps the last capacity dry-run reports.
+func NewDryRunRecorder(capacity int) *DryRunRecorder {
+	return &DryRunRecorder{
+		capacity: capacity,
+		reports:  make([]*DryRunReport, 0, capacity),
+	}
+}
+
+// Add records a dry-run report, dropping the oldest one when the recorder is full.
+func (r *DryRunRecorder) Add(report *DryRunReport) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.reports) < r.capacity {
+		r.reports = append(r.reports, report)
+		return
+	}
+	r.reports[r.next] = report
+	r.next = (r.next + 1) % r.capacity
+}
+
+// Reports returns the recorded reports, the latest one first.
+func (r *DryRunRecorder) Reports() []*DryRunReport {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	reports := make([]*DryRunReport, 0, len(r.reports))
+	for i := 1; i <= len(r.reports); i++ {
+		reports = append(reports, r.reports[(r.next-i+len(r.reports))%len(r.reports)])
+	}
+	return reports
+}
+
+// ServeHTTP writes the recorded reports as json.
+func (r *DryRunRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(r.Reports()); err != nil {
+		klog.Errorf("Failed to encode dry-run reports: %v", err)
+	}
+}
+
+// SetDryRunRecorder sets the recorder of the victims reported in dry-run mode.
+func (ssn *Session) SetDryRunRecorder(recorder *DryRunRecorder) {
+	ssn.dryRunRecorder = recorder
+}
+
+// ReportDryRunVictims records the victims which would have been evicted by the action: an event
+// is recorded for their pods, they are counted by reason in the metrics and added to the dry-run
+// report of the session.
+func (ssn *Session) ReportDryRunVictims(action string, victims []*api.TaskInfo) {
+	report := &DryRunReport{
+		Session: ssn.UID,
+		Action:  action,
+		Time:    time.Now(),
+		Victims: make([]DryRunVictim, 0, len(victims)),
+	}
+	sort.Slice(victims, func(i, j int) bool {
+		if victims[i].Namespace != victims[j].Namespace {
+			return victims[i].Namespace < victims[j].Namespace
+		}
+		return victims[i].Name < victims[j].Name
+	})
+	for _, victim := range victims {
+		reasons := ssn.VictimReasons(victim)
+		if len(reasons) == 0 {
+			reasons = []string{"unknown"}
+		}
+		record := DryRunVictim{
+			Namespace: victim.Namespace,
+			Name:      victim.Name,
+			Job:       victim.Job,
+			Node:      victim.NodeName,
+			Reasons:   reasons,
+		}
+		if job, found := ssn.Jobs[victim.Job]; found {
+			record.Queue = string(job.Queue)
+		}
+		report.Victims = append(report.Victims, record)
+
+		klog.V(3).Infof("Dry-run: task <%s/%s> on node %s would be evicted by %s, reasons: %v",
+			victim.Namespace, victim.Name, victim.NodeName, action, reasons)
+		if ssn.recorder != nil && victim.Pod != nil {
+			ssn.recorder.Eventf(victim.Pod, v1.EventTypeNormal, DryRunEvictionReason,
+				"Pod would be evicted by %s in dry-run mode: %s", action, strings.Join(reasons, "; "))
+		}
+		for _, reason := range reasons {
+			metrics.RegisterDryRunVictim(action, reason)
+		}
+	}
+
+	if ssn.dryRunRecorder != nil {
+		ssn.dryRunRecorder.Add(report)
+	}
+}
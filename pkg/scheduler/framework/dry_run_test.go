@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestReportDryRunVictims(t *testing.T) {
+	p1 := api.NewTaskInfo(util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	p2 := api.NewTaskInfo(util.BuildPod("c1", "p2", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	job := api.NewJobInfo("c1/pg1", p1, p2)
+	job.Queue = "q1"
+
+	eventRecorder := record.NewFakeRecorder(10)
+	recorder := NewDryRunRecorder(2)
+	ssn := &Session{
+		UID:           "s1",
+		Jobs:          map[api.JobID]*api.JobInfo{job.UID: job},
+		recorder:      eventRecorder,
+		victimReasons: map[api.TaskID][]string{},
+	}
+	ssn.SetDryRunRecorder(recorder)
+	ssn.AddVictimReason(p1, "rescheduling/podLifeTime")
+	ssn.AddVictimReason(p1, "rescheduling/podLifeTime")
+	ssn.AddVictimReason(p1, "rescheduling/removeDuplicates")
+
+	ssn.ReportDryRunVictims("shuffle", []*api.TaskInfo{p2, p1})
+
+	reports := recorder.Reports()
+	assert.Len(t, reports, 1)
+	assert.Equal(t, []DryRunVictim{
+		{Namespace: "c1", Name: "p1", Job: "c1/pg1", Queue: "q1", Node: "n1", Reasons: []string{"rescheduling/podLifeTime", "rescheduling/removeDuplicates"}},
+		{Namespace: "c1", Name: "p2", Job: "c1/pg1", Queue: "q1", Node: "n1", Reasons: []string{"unknown"}},
+	}, reports[0].Victims)
+
+	assert.Len(t, eventRecorder.Events, 2)
+	assert.Equal(t, "Normal DryRunEviction Pod would be evicted by shuffle in dry-run mode: rescheduling/podLifeTime; rescheduling/removeDuplicates",
+		<-eventRecorder.Events)
+}
+
+func TestDryRunRecorder(t *testing.T) {
+	recorder := NewDryRunRecorder(2)
+	for _, action := range []string{"a1", "a2", "a3"} {
+		recorder.Add(&DryRunReport{Action: action})
+	}
+
+	rec := httptest.NewRecorder()
+	recorder.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/dryrun", strings.NewReader("")))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var reports []DryRunReport
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reports))
+	var actions []string
+	for _, report := range reports {
+		actions = append(actions, report.Action)
+	}
+	assert.Equal(t, []string{"a3", "a2"}, actions)
+
+	rec = httptest.NewRecorder()
+	recorder.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/dryrun", strings.NewReader("")))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
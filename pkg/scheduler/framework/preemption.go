@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// preemptionBudgetWindow is the window the evictions of a queue are counted in for its preemption budget
+const preemptionBudgetWindow = time.Hour
+
+// timeNow is the clock of the preemption protection periods and budgets, it is replaced in tests
+var timeNow = time.Now
+
+// evictionHistory keeps when the tasks of the queues were evicted in the last window, across the sessions.
+type evictionHistory struct {
+	sync.Mutex
+	queues map[api.QueueID]map[api.TaskID]time.Time
+}
+
+var evictions = &evictionHistory{queues: map[api.QueueID]map[api.TaskID]time.Time{}}
+
+func (h *evictionHistory) record(queue api.QueueID, task api.TaskID) {
+	h.Lock()
+	defer h.Unlock()
+	if h.queues[queue] == nil {
+		h.queues[queue] = map[api.TaskID]time.Time{}
+	}
+	h.queues[queue][task] = timeNow()
+}
+
+// forget drops the eviction of the task which was discarded or failed.
+func (h *evictionHistory) forget(queue api.QueueID, task api.TaskID) {
+	h.Lock()
+	defer h.Unlock()
+	delete(h.queues[queue], task)
+}
+
+// count returns the evictions of the queue in the last window.
+func (h *evictionHistory) count(queue api.QueueID, now time.Time) int {
+	h.Lock()
+	defer h.Unlock()
+	for task, evictedAt := range h.queues[queue] {
+		if now.Sub(evictedAt) >= preemptionBudgetWindow {
+			delete(h.queues[queue], task)
+		}
+	}
+	return len(h.queues[queue])
+}
+
+// recordEviction counts the eviction of the task in the preemption budget of its queue.
+func (ssn *Session) recordEviction(task *api.TaskInfo) {
+	if job, found := ssn.Jobs[task.Job]; found {
+		evictions.record(job.Queue, task.UID)
+	}
+}
+
+// forgetEviction drops the eviction of the task from the preemption budget of its queue.
+func (ssn *Session) forgetEviction(task *api.TaskInfo) {
+	if job, found := ssn.Jobs[task.Job]; found {
+		evictions.forget(job.Queue, task.UID)
+	}
+}
+
+// FilterVictimsByQueuePolicy returns the victims the preemptor may evict by the preemption policy of its queue, and
+// by the preemption protection period and the preemption budget of the queues of the victims. The victims are
+// returned in the order they are evicted, so the budget is spent on the first ones.
+func (ssn *Session) FilterVictimsByQueuePolicy(preemptor *api.TaskInfo, victims []*api.TaskInfo) []*api.TaskInfo {
+	preemptorJob, found := ssn.Jobs[preemptor.Job]
+	if !found {
+		return victims
+	}
+	policy := ssn.Queues[preemptorJob.Queue].PreemptionPolicy()
+	if policy == api.PreemptNever {
+		klog.V(4).Infof("Task <%s/%s> can not evict others for preemption policy %s of queue <%s>",
+			preemptor.Namespace, preemptor.Name, policy, preemptorJob.Queue)
+		return nil
+	}
+
+	now := timeNow()
+	remaining := map[api.QueueID]int{}
+	var filtered []*api.TaskInfo
+	victimsQueue := ssn.BuildVictimsPriorityQueue(victims, preemptor)
+	for !victimsQueue.Empty() {
+		victim := victimsQueue.Pop().(*api.TaskInfo)
+		victimJob, found := ssn.Jobs[victim.Job]
+		if !found {
+			continue
+		}
+		if !preemptionAllowed(policy, preemptor, preemptorJob, victim, victimJob) {
+			klog.V(4).Infof("Task <%s/%s> can not evict task <%s/%s> for preemption policy %s of queue <%s>",
+				preemptor.Namespace, preemptor.Name, victim.Namespace, victim.Name, policy, preemptorJob.Queue)
+			continue
+		}
+
+		victimQueue := ssn.Queues[victimJob.Queue]
+		if period := victimQueue.PreemptionProtectionPeriod(); period > 0 && victim.Pod != nil && victim.Pod.Status.StartTime != nil &&
+			now.Sub(victim.Pod.Status.StartTime.Time) < period {
+			klog.V(4).Infof("Task <%s/%s> is protected from eviction for %v after it started by queue <%s>",
+				victim.Namespace, victim.Name, period, victimJob.Queue)
+			continue
+		}
+		if budget, found := victimQueue.PreemptionBudget(); found {
+			if _, counted := remaining[victimJob.Queue]; !counted {
+				remaining[victimJob.Queue] = budget - evictions.count(victimJob.Queue, now)
+			}
+			if remaining[victimJob.Queue] <= 0 {
+				klog.V(4).Infof("Task <%s/%s> can not be evicted, preemption budget %d of queue <%s> is spent",
+					victim.Namespace, victim.Name, budget, victimJob.Queue)
+				continue
+			}
+			remaining[victimJob.Queue]--
+		}
+		filtered = append(filtered, victim)
+	}
+	return filtered
+}
+
+// preemptionAllowed checks the victim by the preemption policy of the queue of the preemptor: the tasks of the same
+// job are compared by their priority, those of the other jobs by the priority and the age of their jobs.
+func preemptionAllowed(policy api.PreemptionPolicy, preemptor *api.TaskInfo, preemptorJob *api.JobInfo, victim *api.TaskInfo, victimJob *api.JobInfo) bool {
+	if policy != api.PreemptLowerPriority && policy != api.PreemptLowerPriorityAndOlder {
+		return true
+	}
+	if victimJob.UID == preemptorJob.UID {
+		return victim.Priority < preemptor.Priority
+	}
+	if victimJob.Priority >= preemptorJob.Priority {
+		return false
+	}
+	return policy == api.PreemptLowerPriority || preemptorJob.CreationTimestamp.Before(&victimJob.CreationTimestamp)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestFilterVictimsByQueuePolicy(t *testing.T) {
+	defer func() { timeNow = time.Now }()
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+
+	buildJob := func(name, queue string, priority int32, createdAt time.Time, startedAt time.Time) (*api.JobInfo, *api.TaskInfo) {
+		pod := util.BuildPod("c1", name, "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), name, nil, nil)
+		pod.Status.StartTime = &metav1.Time{Time: startedAt}
+		task := api.NewTaskInfo(pod)
+		job := api.NewJobInfo(api.JobID("c1/"+name), task)
+		job.Name = name
+		job.Queue = api.QueueID(queue)
+		job.Priority = priority
+		job.CreationTimestamp = metav1.Time{Time: createdAt}
+		return job, task
+	}
+	buildQueue := func(name string, annotations map[string]string) *api.QueueInfo {
+		return api.NewQueueInfo(&scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}})
+	}
+
+	preemptorJob, preemptor := buildJob("preemptor", "q1", 10, now.Add(-time.Hour), now)
+	newerJob, newer := buildJob("newer", "q2", 5, now.Add(-time.Minute), now.Add(-time.Hour))
+	olderJob, older := buildJob("older", "q2", 5, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	higherJob, higher := buildJob("higher", "q2", 20, now.Add(-time.Minute), now.Add(-time.Hour))
+	startedJob, started := buildJob("started", "q3", 5, now.Add(-time.Minute), now.Add(-5*time.Minute))
+
+	tests := []struct {
+		name          string
+		policy        string
+		q2Annotations map[string]string
+		evicted       int
+		victims       []*api.TaskInfo
+		expected      []string
+	}{
+		{
+			name:     "all victims are allowed without the annotations",
+			victims:  []*api.TaskInfo{newer, older, higher},
+			expected: []string{"newer", "older", "higher"},
+		},
+		{
+			name:     "preemptor never evicts",
+			policy:   string(api.PreemptNever),
+			victims:  []*api.TaskInfo{newer, older, higher},
+			expected: nil,
+		},
+		{
+			name:     "preemptor evicts the tasks of lower priority",
+			policy:   string(api.PreemptLowerPriority),
+			victims:  []*api.TaskInfo{newer, older, higher},
+			expected: []string{"newer", "older"},
+		},
+		{
+			name:     "preemptor evicts the tasks of lower priority of the newer jobs",
+			policy:   string(api.PreemptLowerPriorityAndOlder),
+			victims:  []*api.TaskInfo{newer, older, higher},
+			expected: []string{"newer"},
+		},
+		{
+			name:     "tasks are protected after they started",
+			victims:  []*api.TaskInfo{newer, started},
+			expected: []string{"newer"},
+		},
+		{
+			name:          "evictions are limited by the budget of the queue",
+			q2Annotations: map[string]string{api.QueuePreemptionBudgetAnnotation: "2"},
+			evicted:       1,
+			victims:       []*api.TaskInfo{newer, older},
+			expected:      []string{"newer"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			evictions = &evictionHistory{queues: map[api.QueueID]map[api.TaskID]time.Time{}}
+			for i := 0; i < test.evicted; i++ {
+				evictions.record("q2", api.TaskID(rune('a'+i)))
+			}
+
+			q1Annotations := map[string]string{}
+			if test.policy != "" {
+				q1Annotations[api.QueuePreemptionPolicyAnnotation] = test.policy
+			}
+			ssn := &Session{
+				Jobs: map[api.JobID]*api.JobInfo{},
+				Queues: map[api.QueueID]*api.QueueInfo{
+					"q1": buildQueue("q1", q1Annotations),
+					"q2": buildQueue("q2", test.q2Annotations),
+					"q3": buildQueue("q3", map[string]string{api.QueuePreemptionProtectionAnnotation: "10m"}),
+				},
+			}
+			for _, job := range []*api.JobInfo{preemptorJob, newerJob, olderJob, higherJob, startedJob} {
+				ssn.Jobs[job.UID] = job
+			}
+
+			var names []string
+			for _, victim := range ssn.FilterVictimsByQueuePolicy(preemptor, test.victims) {
+				names = append(names, victim.Name)
+			}
+			assert.ElementsMatch(t, test.expected, names)
+		})
+	}
+}
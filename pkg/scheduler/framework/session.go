@@ -76,6 +76,8 @@ type Session struct {
 	Tiers          []conf.Tier
 	Configurations []conf.Configuration
 	NodeList       []*api.NodeInfo
+	// HyperNodes is the network topology of the cluster, it is nil unless a plugin discovers it
+	HyperNodes *api.HyperNodesInfo
 
 	plugins             map[string]Plugin
 	eventHandlers       []*EventHandler
@@ -91,6 +93,7 @@ type Session struct {
 	batchNodeOrderFns   map[string]api.BatchNodeOrderFn
 	nodeMapFns          map[string]api.NodeMapFn
 	nodeReduceFns       map[string]api.NodeReduceFn
+	hyperNodeOrderFns   map[string]api.HyperNodeOrderFn
 	preemptableFns      map[string]api.EvictableFn
 	reclaimableFns      map[string]api.EvictableFn
 	overusedFns         map[string]api.ValidateFn
@@ -107,6 +110,14 @@ type Session struct {
 	reservedNodesFns  map[string]api.ReservedNodesFn
 	victimTasksFns    map[string][]api.VictimTasksFn
 	jobStarvingFns    map[string]api.ValidateFn
+
+	// trace records the decisions made for each task, it is nil if tracing is disabled
+	trace         *SessionTrace
+	traceRecorder *TraceRecorder
+
+	// victimReasons records why the tasks are selected as victims
+	victimReasons  map[api.TaskID][]string
+	dryRunRecorder *DryRunRecorder
 }
 
 func openSession(cache cache.Cache) *Session {
@@ -142,6 +153,7 @@ func openSession(cache cache.Cache) *Session {
 		batchNodeOrderFns:   map[string]api.BatchNodeOrderFn{},
 		nodeMapFns:          map[string]api.NodeMapFn{},
 		nodeReduceFns:       map[string]api.NodeReduceFn{},
+		hyperNodeOrderFns:   map[string]api.HyperNodeOrderFn{},
 		preemptableFns:      map[string]api.EvictableFn{},
 		reclaimableFns:      map[string]api.EvictableFn{},
 		overusedFns:         map[string]api.ValidateFn{},
@@ -156,6 +168,7 @@ func openSession(cache cache.Cache) *Session {
 		reservedNodesFns:    map[string]api.ReservedNodesFn{},
 		victimTasksFns:      map[string][]api.VictimTasksFn{},
 		jobStarvingFns:      map[string]api.ValidateFn{},
+		victimReasons:       map[api.TaskID][]string{},
 	}
 
 	snapshot := cache.Snapshot()
@@ -281,7 +294,19 @@ func updateRootQueueResources(ssn *Session, allocated v1.ResourceList) {
 	}
 }
 
+// EnableTrace records the decisions made for each task in this session and adds the trace
+// to the recorder when the session is closed.
+func (ssn *Session) EnableTrace(recorder *TraceRecorder) {
+	ssn.trace = newSessionTrace(ssn.UID)
+	ssn.traceRecorder = recorder
+}
+
 func closeSession(ssn *Session) {
+	if ssn.trace != nil {
+		summarizeTrace(ssn)
+		ssn.traceRecorder.Add(ssn.trace)
+	}
+
 	ju := newJobUpdater(ssn)
 	ju.UpdateAll()
 
@@ -567,6 +592,7 @@ func (ssn *Session) Evict(reclaimee *api.TaskInfo, reason string) error {
 	if err := ssn.cache.Evict(reclaimee, reason); err != nil {
 		return err
 	}
+	ssn.recordEviction(reclaimee)
 
 	// Update status in session
 	job, found := ssn.Jobs[reclaimee.Job]
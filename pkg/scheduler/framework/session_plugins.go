@@ -105,6 +105,11 @@ func (ssn *Session) AddNodeReduceFn(name string, pf api.NodeReduceFn) {
 	ssn.nodeReduceFns[name] = pf
 }
 
+// AddHyperNodeOrderFn add hyperNode order function
+func (ssn *Session) AddHyperNodeOrderFn(name string, fn api.HyperNodeOrderFn) {
+	ssn.hyperNodeOrderFns[name] = fn
+}
+
 // AddOverusedFn add overused function
 func (ssn *Session) AddOverusedFn(name string, fn api.ValidateFn) {
 	ssn.overusedFns[name] = fn
@@ -495,6 +500,10 @@ func (ssn *Session) VictimTasks(tasks []*api.TaskInfo) map[*api.TaskInfo]bool {
 				victimTasks := fn(tasks)
 				for _, victim := range victimTasks {
 					victimSet[victim] = true
+					// the plugin is the reason unless it gave a more precise one
+					if len(ssn.victimReasons[victim.UID]) == 0 {
+						ssn.AddVictimReason(victim, plugin.Name)
+					}
 				}
 			}
 		}
@@ -505,6 +514,21 @@ func (ssn *Session) VictimTasks(tasks []*api.TaskInfo) map[*api.TaskInfo]bool {
 	return victimSet
 }
 
+// AddVictimReason records why the task is selected as a victim, e.g. the strategy which selected it.
+func (ssn *Session) AddVictimReason(task *api.TaskInfo, reason string) {
+	for _, r := range ssn.victimReasons[task.UID] {
+		if r == reason {
+			return
+		}
+	}
+	ssn.victimReasons[task.UID] = append(ssn.victimReasons[task.UID], reason)
+}
+
+// VictimReasons returns why the task is selected as a victim.
+func (ssn *Session) VictimReasons(task *api.TaskInfo) []string {
+	return ssn.victimReasons[task.UID]
+}
+
 // ReservedNodes invoke ReservedNodes function of the plugins
 func (ssn *Session) ReservedNodes() {
 	for _, tier := range ssn.Tiers {
@@ -658,6 +682,9 @@ func (ssn *Session) PredicateFn(task *api.TaskInfo, node *api.NodeInfo) error {
 			}
 			err := pfn(task, node)
 			if err != nil {
+				if ssn.trace != nil {
+					ssn.trace.predicateFailed(task, node.Name, plugin.Name, err)
+				}
 				return err
 			}
 		}
@@ -679,6 +706,9 @@ func (ssn *Session) PrePredicateFn(task *api.TaskInfo) error {
 			}
 			err := pfn(task)
 			if err != nil {
+				if ssn.trace != nil {
+					ssn.trace.prePredicateFailed(task, plugin.Name, err)
+				}
 				return err
 			}
 		}
@@ -699,6 +729,9 @@ func (ssn *Session) BestNodeFn(task *api.TaskInfo, nodeScores map[float64][]*api
 			}
 			// Only the first plugin that enables and realizes bestNodeFn is allowed to choose best node for task
 			if bestNode := pfn(task, nodeScores); bestNode != nil {
+				if ssn.trace != nil {
+					ssn.trace.bestNodeChosen(task, plugin.Name, bestNode)
+				}
 				return bestNode
 			}
 		}
@@ -722,6 +755,9 @@ func (ssn *Session) NodeOrderFn(task *api.TaskInfo, node *api.NodeInfo) (float64
 			if err != nil {
 				return 0, err
 			}
+			if ssn.trace != nil {
+				ssn.trace.nodeScored(task, node.Name, plugin.Name, score)
+			}
 			priorityScore += score
 		}
 	}
@@ -745,6 +781,9 @@ func (ssn *Session) BatchNodeOrderFn(task *api.TaskInfo, nodes []*api.NodeInfo)
 				return nil, err
 			}
 			for nodeName, score := range score {
+				if ssn.trace != nil {
+					ssn.trace.nodeScored(task, nodeName, plugin.Name, score)
+				}
 				priorityScore[nodeName] += score
 			}
 		}
@@ -752,6 +791,30 @@ func (ssn *Session) BatchNodeOrderFn(task *api.TaskInfo, nodes []*api.NodeInfo)
 	return priorityScore, nil
 }
 
+// HyperNodeOrderFn invoke hyperNode order function of the plugins, the scores are indexed by hypernode name
+func (ssn *Session) HyperNodeOrderFn(job *api.JobInfo, hyperNodes []*api.HyperNodeInfo) (map[string]float64, error) {
+	hyperNodeScore := make(map[string]float64, len(hyperNodes))
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if !isEnabled(plugin.EnabledHyperNodeOrder) {
+				continue
+			}
+			pfn, found := ssn.hyperNodeOrderFns[plugin.Name]
+			if !found {
+				continue
+			}
+			scores, err := pfn(job, hyperNodes)
+			if err != nil {
+				return nil, err
+			}
+			for name, score := range scores {
+				hyperNodeScore[name] += score
+			}
+		}
+	}
+	return hyperNodeScore, nil
+}
+
 func isEnabled(enabled *bool) bool {
 	return enabled != nil && *enabled
 }
@@ -770,6 +833,9 @@ func (ssn *Session) NodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[
 				if err != nil {
 					return nodeScoreMap, priorityScore, err
 				}
+				if ssn.trace != nil {
+					ssn.trace.nodeScored(task, node.Name, plugin.Name, score)
+				}
 				priorityScore += score
 			}
 			if pfn, found := ssn.nodeMapFns[plugin.Name]; found {
@@ -777,6 +843,9 @@ func (ssn *Session) NodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[
 				if err != nil {
 					return nodeScoreMap, priorityScore, err
 				}
+				if ssn.trace != nil {
+					ssn.trace.nodeScored(task, node.Name, plugin.Name, score)
+				}
 				nodeScoreMap[plugin.Name] = score
 			}
 		}
@@ -800,6 +869,10 @@ func (ssn *Session) NodeOrderReduceFn(task *api.TaskInfo, pluginNodeScoreMap map
 				return nodeScoreMap, err
 			}
 			for _, hp := range pluginNodeScoreMap[plugin.Name] {
+				// the normalized score replaces the raw score recorded by NodeOrderMapFn
+				if ssn.trace != nil {
+					ssn.trace.nodeScored(task, hp.Name, plugin.Name, float64(hp.Score))
+				}
 				nodeScoreMap[hp.Name] += float64(hp.Score)
 			}
 		}
@@ -92,6 +92,7 @@ func (s *Statement) Evict(reclaimee *api.TaskInfo, reason string) error {
 		task:   reclaimee,
 		reason: reason,
 	})
+	s.ssn.recordEviction(reclaimee)
 
 	return nil
 }
@@ -108,6 +109,8 @@ func (s *Statement) evict(reclaimee *api.TaskInfo, reason string) error {
 }
 
 func (s *Statement) unevict(reclaimee *api.TaskInfo) error {
+	s.ssn.forgetEviction(reclaimee)
+
 	// Update status in session
 	job, found := s.ssn.Jobs[reclaimee.Job]
 	if found {
@@ -395,6 +398,9 @@ func (s *Statement) Discard() {
 	klog.V(3).Info("Discarding operations ...")
 	for i := len(s.operations) - 1; i >= 0; i-- {
 		op := s.operations[i]
+		if s.ssn.trace != nil {
+			s.ssn.trace.operationDone(op, TraceDiscarded)
+		}
 		op.task.GenerateLastTxContext()
 		switch op.name {
 		case Evict:
@@ -420,6 +426,9 @@ func (s *Statement) Discard() {
 func (s *Statement) Commit() {
 	klog.V(3).Info("Committing operations ...")
 	for _, op := range s.operations {
+		if s.ssn.trace != nil {
+			s.ssn.trace.operationDone(op, TraceCommitted)
+		}
 		op.task.ClearLastTxContext()
 		switch op.name {
 		case Evict:
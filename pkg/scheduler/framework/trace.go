@@ -0,0 +1,313 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// TraceCommitted is the outcome of an operation which was committed by its statement
+	TraceCommitted = "Committed"
+	// TraceDiscarded is the outcome of an operation which was discarded by its statement
+	TraceDiscarded = "Discarded"
+)
+
+var operationNames = map[Operation]string{
+	Evict:    "Evict",
+	Pipeline: "Pipeline",
+	Allocate: "Allocate",
+}
+
+// PredicateFailure is the error returned by the first plugin which filtered out a node for a task.
+type PredicateFailure struct {
+	Plugin string `json:"plugin"`
+	Reason string `json:"reason"`
+}
+
+// BestNode is the node chosen by the BestNodeFn of a plugin.
+type BestNode struct {
+	Plugin string `json:"plugin"`
+	Node   string `json:"node"`
+}
+
+// TraceOutcome is the outcome of a statement operation on a task.
+type TraceOutcome struct {
+	Operation string `json:"operation"`
+	Node      string `json:"node,omitempty"`
+	Result    string `json:"result"`
+}
+
+// TaskTrace records the decisions made for a task in a session.
+type TaskTrace struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Job       api.JobID `json:"job"`
+	// PrePredicateFailure is set if a plugin rejected the task before any node was checked
+	PrePredicateFailure *PredicateFailure `json:"prePredicateFailure,omitempty"`
+	// PredicateFailures are the predicate failures indexed by node name
+	PredicateFailures map[string]PredicateFailure `json:"predicateFailures,omitempty"`
+	// NodeScores are the scores given by each plugin, indexed by node name then plugin name
+	NodeScores map[string]map[string]float64 `json:"nodeScores,omitempty"`
+	BestNode   *BestNode                     `json:"bestNode,omitempty"`
+	Outcomes   []TraceOutcome                `json:"outcomes,omitempty"`
+}
+
+// SessionTrace records the decisions made for every task considered in a session.
+type SessionTrace struct {
+	UID       types.UID                 `json:"uid"`
+	StartTime metav1.Time               `json:"startTime"`
+	Tasks     map[api.TaskID]*TaskTrace `json:"tasks"`
+
+	// predicates and scoring run in parallel on nodes
+	mutex sync.Mutex
+}
+
+func newSessionTrace(uid types.UID) *SessionTrace {
+	return &SessionTrace{
+		UID:       uid,
+		StartTime: metav1.Now(),
+		Tasks:     map[api.TaskID]*TaskTrace{},
+	}
+}
+
+// task returns the trace of the task, the caller must hold the mutex.
+func (st *SessionTrace) task(task *api.TaskInfo) *TaskTrace {
+	tt, found := st.Tasks[task.UID]
+	if !found {
+		tt = &TaskTrace{
+			Namespace: task.Namespace,
+			Name:      task.Name,
+			Job:       task.Job,
+		}
+		st.Tasks[task.UID] = tt
+	}
+	return tt
+}
+
+func (st *SessionTrace) prePredicateFailed(task *api.TaskInfo, plugin string, err error) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.task(task).PrePredicateFailure = &PredicateFailure{Plugin: plugin, Reason: err.Error()}
+}
+
+func (st *SessionTrace) predicateFailed(task *api.TaskInfo, node, plugin string, err error) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	tt := st.task(task)
+	if tt.PredicateFailures == nil {
+		tt.PredicateFailures = map[string]PredicateFailure{}
+	}
+	tt.PredicateFailures[node] = PredicateFailure{Plugin: plugin, Reason: err.Error()}
+}
+
+func (st *SessionTrace) nodeScored(task *api.TaskInfo, node, plugin string, score float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	tt := st.task(task)
+	if tt.NodeScores == nil {
+		tt.NodeScores = map[string]map[string]float64{}
+	}
+	if tt.NodeScores[node] == nil {
+		tt.NodeScores[node] = map[string]float64{}
+	}
+	tt.NodeScores[node][plugin] = score
+}
+
+func (st *SessionTrace) bestNodeChosen(task *api.TaskInfo, plugin string, node *api.NodeInfo) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.task(task).BestNode = &BestNode{Plugin: plugin, Node: node.Name}
+}
+
+func (st *SessionTrace) operationDone(op operation, result string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	tt := st.task(op.task)
+	tt.Outcomes = append(tt.Outcomes, TraceOutcome{
+		Operation: operationNames[op.name],
+		Node:      op.task.NodeName,
+		Result:    result,
+	})
+}
+
+// summary returns a one line summary of the decisions made for the tasks of the job which
+// are still pending, e.g. "predicates filtered out 3 node(s) for 2 pending task(s) (predicates: 4, numaaware: 2)".
+func (st *SessionTrace) summary(job *api.JobInfo) string {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+
+	traced := 0
+	failures := map[string]int{}
+	filtered := map[string]struct{}{}
+	discarded := 0
+	for uid := range job.TaskStatusIndex[api.Pending] {
+		tt, found := st.Tasks[uid]
+		if !found {
+			continue
+		}
+		traced++
+		if tt.PrePredicateFailure != nil {
+			failures[tt.PrePredicateFailure.Plugin]++
+		}
+		for node, failure := range tt.PredicateFailures {
+			failures[failure.Plugin]++
+			filtered[node] = struct{}{}
+		}
+		for _, outcome := range tt.Outcomes {
+			if outcome.Result == TraceDiscarded {
+				discarded++
+			}
+		}
+	}
+	if traced == 0 {
+		return ""
+	}
+
+	plugins := make([]string, 0, len(failures))
+	for plugin := range failures {
+		plugins = append(plugins, plugin)
+	}
+	sort.Strings(plugins)
+	counts := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		counts = append(counts, fmt.Sprintf("%s: %d", plugin, failures[plugin]))
+	}
+
+	msg := fmt.Sprintf("predicates filtered out %d node(s) for %d pending task(s)", len(filtered), traced)
+	if len(counts) != 0 {
+		msg += fmt.Sprintf(" (%s)", strings.Join(counts, ", "))
+	}
+	if discarded != 0 {
+		msg += fmt.Sprintf(", %d operation(s) discarded", discarded)
+	}
+	return msg
+}
+
+// summarizeTrace appends the trace summary to the Unschedulable condition set on the podgroups
+// in this session, so that users can see why a job is pending without the trace api.
+func summarizeTrace(ssn *Session) {
+	for _, job := range ssn.Jobs {
+		if job.PodGroup == nil {
+			continue
+		}
+		for i, c := range job.PodGroup.Status.Conditions {
+			if c.Type != scheduling.PodGroupUnschedulableType || c.Status != v1.ConditionTrue ||
+				c.TransitionID != string(ssn.UID) {
+				continue
+			}
+			if summary := ssn.trace.summary(job); summary != "" {
+				job.PodGroup.Status.Conditions[i].Message = fmt.Sprintf("%s; %s", c.Message, summary)
+			}
+		}
+	}
+}
+
+// TraceRecorder keeps the traces of the last sessions.
+type TraceRecorder struct {
+	mutex    sync.Mutex
+	capacity int
+	next     int
+	traces   []*SessionTrace
+}
+
+// NewTraceRecorder returns a recorder which keeps the traces of the last capacity sessions.
+func NewTraceRecorder(capacity int) *TraceRecorder {
+	return &TraceRecorder{
+		capacity: capacity,
+		traces:   make([]*SessionTrace, 0, capacity),
+	}
+}
+
+// Add records the trace of a session, dropping the oldest one when the recorder is full.
+func (r *TraceRecorder) Add(trace *SessionTrace) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.traces) < r.capacity {
+		r.traces = append(r.traces, trace)
+		return
+	}
+	r.traces[r.next] = trace
+	r.next = (r.next + 1) % r.capacity
+}
+
+// Traces returns the recorded traces, the latest session first.
+func (r *TraceRecorder) Traces() []*SessionTrace {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	traces := make([]*SessionTrace, 0, len(r.traces))
+	for i := 1; i <= len(r.traces); i++ {
+		traces = append(traces, r.traces[(r.next-i+len(r.traces))%len(r.traces)])
+	}
+	return traces
+}
+
+// ServeHTTP writes the recorded traces as json. The traces can be filtered with the session
+// query parameter, which is a session uid, and the job query parameter, which is namespace/name.
+func (r *TraceRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session := req.URL.Query().Get("session")
+	job := api.JobID(req.URL.Query().Get("job"))
+
+	traces := []*SessionTrace{}
+	for _, trace := range r.Traces() {
+		if session != "" && string(trace.UID) != session {
+			continue
+		}
+		if job != "" {
+			trace = trace.filter(job)
+		}
+		traces = append(traces, trace)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(traces); err != nil {
+		klog.Errorf("Failed to encode scheduling traces: %v", err)
+	}
+}
+
+// filter returns a copy of the trace which only contains the tasks of the job.
+func (st *SessionTrace) filter(job api.JobID) *SessionTrace {
+	filtered := newSessionTrace(st.UID)
+	filtered.StartTime = st.StartTime
+	for uid, tt := range st.Tasks {
+		if tt.Job == job {
+			filtered.Tasks[uid] = tt
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestSessionTrace(t *testing.T) {
+	scherCache := cache.NewDefaultMockSchedulerCache("test-scheduler")
+	for _, name := range []string{"n1", "n2"} {
+		scherCache.AddOrUpdateNode(util.BuildNode(name, api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	}
+	scherCache.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "c1", 1, nil, schedulingv1.PodGroupInqueue))
+	scherCache.AddQueueV1beta1(util.BuildQueue("c1", 1, nil))
+
+	enabled := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{
+		Name:             "fake",
+		EnabledPredicate: &enabled,
+		EnabledNodeOrder: &enabled,
+		EnabledBestNode:  &enabled,
+	}}}}
+	recorder := NewTraceRecorder(2)
+	ssn := OpenSession(scherCache, tiers, nil)
+	ssn.EnableTrace(recorder)
+
+	ssn.AddPredicateFn("fake", func(task *api.TaskInfo, node *api.NodeInfo) error {
+		if node.Name == "n2" {
+			return fmt.Errorf("node n2 is tainted")
+		}
+		return nil
+	})
+	ssn.AddNodeOrderFn("fake", func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
+		return 10, nil
+	})
+	ssn.AddBestNodeFn("fake", func(task *api.TaskInfo, nodeScores map[float64][]*api.NodeInfo) *api.NodeInfo {
+		return nodeScores[10][0]
+	})
+
+	job := ssn.Jobs["c1/pg1"]
+	var task *api.TaskInfo
+	for _, t := range job.TaskStatusIndex[api.Pending] {
+		task = t
+	}
+
+	var nodes []*api.NodeInfo
+	for _, name := range []string{"n1", "n2"} {
+		if err := ssn.PredicateFn(task, ssn.Nodes[name]); err == nil {
+			nodes = append(nodes, ssn.Nodes[name])
+		}
+	}
+	nodeScores := map[float64][]*api.NodeInfo{}
+	for _, node := range nodes {
+		score, err := ssn.NodeOrderFn(task, node)
+		assert.NoError(t, err)
+		nodeScores[score] = append(nodeScores[score], node)
+	}
+	bestNode := ssn.BestNodeFn(task, nodeScores)
+	stmt := NewStatement(ssn)
+	assert.NoError(t, stmt.Allocate(task, bestNode))
+	stmt.Discard()
+
+	assert.NoError(t, ssn.UpdatePodGroupCondition(job, &scheduling.PodGroupCondition{
+		Type:         scheduling.PodGroupUnschedulableType,
+		Status:       v1.ConditionTrue,
+		TransitionID: string(ssn.UID),
+		Message:      "1/1 tasks in gang unschedulable",
+	}))
+	CloseSession(ssn)
+
+	traces := recorder.Traces()
+	assert.Len(t, traces, 1)
+	assert.Equal(t, &TaskTrace{
+		Namespace:         "c1",
+		Name:              "p1",
+		Job:               "c1/pg1",
+		PredicateFailures: map[string]PredicateFailure{"n2": {Plugin: "fake", Reason: "node n2 is tainted"}},
+		NodeScores:        map[string]map[string]float64{"n1": {"fake": 10}},
+		BestNode:          &BestNode{Plugin: "fake", Node: "n1"},
+		Outcomes:          []TraceOutcome{{Operation: "Allocate", Node: "n1", Result: TraceDiscarded}},
+	}, traces[0].Tasks[task.UID])
+
+	assert.Equal(t, "1/1 tasks in gang unschedulable; predicates filtered out 1 node(s) for 1 pending task(s) (fake: 1), 1 operation(s) discarded",
+		job.PodGroup.Status.Conditions[0].Message)
+}
+
+func TestTraceRecorder(t *testing.T) {
+	recorder := NewTraceRecorder(2)
+	for i := 1; i <= 3; i++ {
+		trace := newSessionTrace(types.UID(fmt.Sprintf("s%d", i)))
+		trace.Tasks["t1"] = &TaskTrace{Namespace: "c1", Name: "p1", Job: "c1/pg1"}
+		trace.Tasks["t2"] = &TaskTrace{Namespace: "c1", Name: "p2", Job: "c1/pg2"}
+		recorder.Add(trace)
+	}
+
+	var uids []types.UID
+	for _, trace := range recorder.Traces() {
+		uids = append(uids, trace.UID)
+	}
+	assert.Equal(t, []types.UID{"s3", "s2"}, uids)
+
+	tests := []struct {
+		name   string
+		method string
+		query  string
+		code   int
+		want   map[types.UID][]string
+	}{
+		{
+			name:   "all traces",
+			method: http.MethodGet,
+			code:   http.StatusOK,
+			want:   map[types.UID][]string{"s3": {"p1", "p2"}, "s2": {"p1", "p2"}},
+		},
+		{
+			name:   "filter by session and job",
+			method: http.MethodGet,
+			query:  "?session=s2&job=c1/pg2",
+			code:   http.StatusOK,
+			want:   map[types.UID][]string{"s2": {"p2"}},
+		},
+		{
+			name:   "post is not allowed",
+			method: http.MethodPost,
+			code:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			recorder.ServeHTTP(rec, httptest.NewRequest(test.method, "/debug/traces"+test.query, strings.NewReader("")))
+			assert.Equal(t, test.code, rec.Code)
+			if test.code != http.StatusOK {
+				return
+			}
+
+			var traces []struct {
+				UID       types.UID             `json:"uid"`
+				StartTime metav1.Time           `json:"startTime"`
+				Tasks     map[string]*TaskTrace `json:"tasks"`
+			}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &traces))
+			got := map[types.UID][]string{}
+			for _, trace := range traces {
+				names := []string{}
+				for _, tt := range trace.Tasks {
+					names = append(names, tt.Name)
+				}
+				assert.ElementsMatch(t, test.want[trace.UID], names)
+				got[trace.UID] = names
+			}
+			assert.Len(t, got, len(test.want))
+		})
+	}
+}
@@ -139,6 +139,14 @@ var (
 		}, []string{"job_id"},
 	)
 
+	dryRunVictims = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "dry_run_victims_total",
+			Help:      "Number of victims which would have been evicted in dry-run mode, by action and reason",
+		}, []string{"action", "reason"},
+	)
+
 	unscheduleJobCount = promauto.NewGauge(
 		prometheus.GaugeOpts{
 			Subsystem: VolcanoSubSystemName,
@@ -199,6 +207,11 @@ func RegisterPreemptionAttempts() {
 	preemptionAttempts.Inc()
 }
 
+// RegisterDryRunVictim records a victim which would have been evicted by the action in dry-run mode
+func RegisterDryRunVictim(action, reason string) {
+	dryRunVictims.WithLabelValues(action, reason).Inc()
+}
+
 // UpdateUnscheduleTaskCount records total number of unscheduleable tasks
 func UpdateUnscheduleTaskCount(jobID string, taskCount int) {
 	unscheduleTaskCount.WithLabelValues(jobID).Set(float64(taskCount))
@@ -119,6 +119,30 @@ var (
 		}, []string{"queue_name"},
 	)
 
+	queueFairShareUsage = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_fairshare_usage",
+			Help:      "Effective historical usage for one queue, normalized by the usage of all queues",
+		}, []string{"queue_name"},
+	)
+
+	queueFairShareShare = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_fairshare_share",
+			Help:      "Fair-share for one queue, its weight in the total weight of the queues",
+		}, []string{"queue_name"},
+	)
+
+	queueFairShareFactor = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_fairshare_factor",
+			Help:      "Fair-share factor for one queue",
+		}, []string{"queue_name"},
+	)
+
 	queueCapacityMilliCPU = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Subsystem: VolcanoSubSystemName,
@@ -216,6 +240,13 @@ func UpdateQueueOverused(queueName string, overused bool) {
 	queueOverused.WithLabelValues(queueName).Set(value)
 }
 
+// UpdateQueueFairShare records the effective historical usage, fair-share and fair-share factor for one queue
+func UpdateQueueFairShare(queueName string, usage, share, factor float64) {
+	queueFairShareUsage.WithLabelValues(queueName).Set(usage)
+	queueFairShareShare.WithLabelValues(queueName).Set(share)
+	queueFairShareFactor.WithLabelValues(queueName).Set(factor)
+}
+
 func UpdateQueueCapacity(queueName string, milliCPU, memory float64, scalarResources map[v1.ResourceName]float64) {
 	queueCapacityMilliCPU.WithLabelValues(queueName).Set(milliCPU)
 	queueCapacityMemory.WithLabelValues(queueName).Set(memory)
@@ -243,6 +274,9 @@ func DeleteQueueMetrics(queueName string) {
 	queueShare.DeleteLabelValues(queueName)
 	queueWeight.DeleteLabelValues(queueName)
 	queueOverused.DeleteLabelValues(queueName)
+	queueFairShareUsage.DeleteLabelValues(queueName)
+	queueFairShareShare.DeleteLabelValues(queueName)
+	queueFairShareFactor.DeleteLabelValues(queueName)
 	queueCapacityMilliCPU.DeleteLabelValues(queueName)
 	queueCapacityMemory.DeleteLabelValues(queueName)
 	queueRealCapacityMilliCPU.DeleteLabelValues(queueName)
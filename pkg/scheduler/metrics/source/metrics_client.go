@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	listersv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 )
@@ -30,6 +31,13 @@ const (
 	Metrics_Type_Prometheus_Adaptor = "prometheus_adaptor"
 	Metrics_Tpye_Prometheus         = "prometheus"
 	Metrics_Type_Elasticsearch      = "elasticsearch"
+	Metrics_Type_Metrics_Server     = "metrics_server"
+	Metrics_Type_OTLP               = "otlp"
+	Metrics_Type_File               = "file"
+
+	// DefaultMetricsStaleness is how long the last metrics of a node are served when the
+	// metrics source fails to return newer ones.
+	DefaultMetricsStaleness = 5 * time.Minute
 )
 
 type NodeMetrics struct {
@@ -42,18 +50,42 @@ type MetricsClient interface {
 	NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error
 }
 
-func NewMetricsClient(restConfig *rest.Config, metricsConf map[string]string) (MetricsClient, error) {
+// NewMetricsClient returns the client of the metrics source of metricsConf["type"]. The client
+// keeps the last metrics of every node and serves them for metricsConf["staleness"], so it should
+// be reused as long as the configuration does not change.
+func NewMetricsClient(restConfig *rest.Config, nodeLister listersv1.NodeLister, metricsConf map[string]string) (MetricsClient, error) {
 	klog.V(3).Infof("New metrics client begin, metricsConf is %v", metricsConf)
+	var client MetricsClient
+	var err error
 	metricsType := metricsConf["type"]
-	if metricsType == Metrics_Type_Elasticsearch {
-		return NewElasticsearchMetricsClient(metricsConf)
-	} else if metricsType == Metrics_Tpye_Prometheus {
-		return NewPrometheusMetricsClient(metricsConf)
-	} else if metricsType == Metrics_Type_Prometheus_Adaptor {
-		return NewCustomMetricsClient(restConfig)
-	} else {
+	switch metricsType {
+	case Metrics_Type_Elasticsearch:
+		client, err = NewElasticsearchMetricsClient(metricsConf)
+	case Metrics_Tpye_Prometheus:
+		client, err = NewPrometheusMetricsClient(metricsConf)
+	case Metrics_Type_Prometheus_Adaptor:
+		client, err = NewCustomMetricsClient(restConfig)
+	case Metrics_Type_Metrics_Server:
+		client, err = NewMetricsServerClient(restConfig, nodeLister)
+	case Metrics_Type_OTLP:
+		client, err = NewOTLPMetricsClient(metricsConf)
+	case Metrics_Type_File:
+		client, err = NewFileMetricsClient(metricsConf)
+	default:
 		return nil, fmt.Errorf("data cannot be collected from the %s monitoring system. "+
-			"The supported monitoring systems are %s, %s, and %s",
-			metricsType, Metrics_Type_Elasticsearch, Metrics_Tpye_Prometheus, Metrics_Type_Prometheus_Adaptor)
+			"The supported monitoring systems are %s, %s, %s, %s, %s and %s",
+			metricsType, Metrics_Type_Elasticsearch, Metrics_Tpye_Prometheus, Metrics_Type_Prometheus_Adaptor,
+			Metrics_Type_Metrics_Server, Metrics_Type_OTLP, Metrics_Type_File)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	staleness := DefaultMetricsStaleness
+	if value, found := metricsConf["staleness"]; found {
+		if staleness, err = time.ParseDuration(value); err != nil || staleness < 0 {
+			return nil, fmt.Errorf("invalid metrics staleness %q", value)
+		}
 	}
+	return NewCachedMetricsClient(client, staleness), nil
 }
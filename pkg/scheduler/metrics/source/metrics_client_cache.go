@@ -0,0 +1,184 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// CachedMetricsClient keeps the last metrics of every node returned by the underlying client.
+// When the client fails, or returns nothing for a node, the last metrics of the node are served
+// until they are older than the staleness, then the node gets empty metrics so that the plugins
+// ignore it instead of using stale data.
+type CachedMetricsClient struct {
+	client    MetricsClient
+	staleness time.Duration
+
+	lock sync.Mutex
+	last map[string]NodeMetrics
+	now  func() time.Time
+}
+
+// NewCachedMetricsClient wraps the client with a cache of the last metrics of every node.
+func NewCachedMetricsClient(client MetricsClient, staleness time.Duration) *CachedMetricsClient {
+	return &CachedMetricsClient{
+		client:    client,
+		staleness: staleness,
+		last:      map[string]NodeMetrics{},
+		now:       time.Now,
+	}
+}
+
+// NodesMetricsAvg fills nodeMetricsMap with the latest metrics which are not stale. The error of
+// the underlying client is returned, but nodeMetricsMap is filled from the cache anyway.
+func (c *CachedMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	fresh := make(map[string]*NodeMetrics, len(nodeMetricsMap))
+	for nodeName := range nodeMetricsMap {
+		fresh[nodeName] = &NodeMetrics{}
+	}
+	err := c.client.NodesMetricsAvg(ctx, fresh)
+	if err != nil {
+		err = fmt.Errorf("failed to get node metrics, serving cached metrics: %v", err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	now := c.now()
+	for nodeName := range nodeMetricsMap {
+		if metrics := fresh[nodeName]; err == nil && metrics != nil && !metrics.MetricsTime.IsZero() {
+			c.last[nodeName] = *metrics
+		}
+		last, found := c.last[nodeName]
+		if !found {
+			nodeMetricsMap[nodeName] = &NodeMetrics{}
+			continue
+		}
+		if now.Sub(last.MetricsTime) > c.staleness {
+			klog.V(3).Infof("The metrics of node %s collected at %v are stale, they are dropped", nodeName, last.MetricsTime)
+			delete(c.last, nodeName)
+			nodeMetricsMap[nodeName] = &NodeMetrics{}
+			continue
+		}
+		nodeMetricsMap[nodeName] = &last
+	}
+	// forget the nodes removed from the cluster
+	for nodeName := range c.last {
+		if _, found := nodeMetricsMap[nodeName]; !found {
+			delete(c.last, nodeName)
+		}
+	}
+	return err
+}
+
+// metricsWindow keeps the samples of the nodes in the last period, it is used by the sources which
+// only report the current usage to compute the average usage of the period.
+type metricsWindow struct {
+	lock    sync.Mutex
+	period  time.Duration
+	samples map[string]map[string][]metricsSample
+}
+
+type metricsSample struct {
+	time  time.Time
+	value float64
+}
+
+const (
+	resourceCPU    = "cpu"
+	resourceMemory = "memory"
+)
+
+func newMetricsWindow() *metricsWindow {
+	period, _ := time.ParseDuration(NODE_METRICS_PERIOD)
+	return &metricsWindow{
+		period:  period,
+		samples: map[string]map[string][]metricsSample{},
+	}
+}
+
+// add records the usage in percent of the resource of the node at the time.
+func (w *metricsWindow) add(nodeName, resource string, t time.Time, value float64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	resources, found := w.samples[nodeName]
+	if !found {
+		resources = map[string][]metricsSample{}
+		w.samples[nodeName] = resources
+	}
+	samples := resources[resource]
+	if n := len(samples); n > 0 && samples[n-1].time.Equal(t) {
+		// the source reported the same sample again
+		samples[n-1].value = value
+		return
+	}
+	samples = append(samples, metricsSample{time: t, value: value})
+	start := 0
+	for start < len(samples) && t.Sub(samples[start].time) > w.period {
+		start++
+	}
+	resources[resource] = samples[start:]
+}
+
+// avg returns the average usage of the node in the period ending at its latest sample.
+func (w *metricsWindow) avg(nodeName string) (*NodeMetrics, bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	resources, found := w.samples[nodeName]
+	if !found {
+		return nil, false
+	}
+	metrics := &NodeMetrics{}
+	for resource, samples := range resources {
+		if len(samples) == 0 {
+			continue
+		}
+		sum := 0.0
+		for _, s := range samples {
+			sum += s.value
+		}
+		switch resource {
+		case resourceCPU:
+			metrics.CPU = sum / float64(len(samples))
+		case resourceMemory:
+			metrics.Memory = sum / float64(len(samples))
+		}
+		if latest := samples[len(samples)-1].time; latest.After(metrics.MetricsTime) {
+			metrics.MetricsTime = latest
+		}
+	}
+	return metrics, !metrics.MetricsTime.IsZero()
+}
+
+// prune forgets the nodes which are not in nodeMetricsMap.
+func (w *metricsWindow) prune(nodeMetricsMap map[string]*NodeMetrics) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for nodeName := range w.samples {
+		if _, found := nodeMetricsMap[nodeName]; !found {
+			delete(w.samples, nodeName)
+		}
+	}
+}
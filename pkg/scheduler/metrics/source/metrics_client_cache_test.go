@@ -0,0 +1,135 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeMetricsFile(t *testing.T, path, content string) {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write metrics file: %v", err)
+	}
+}
+
+func TestFileMetricsClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.yaml")
+	writeMetricsFile(t, path, `
+nodes:
+  node-1:
+    cpu: 35.5
+    memory: 60
+    metricsTime: "2024-01-01T00:00:00Z"
+  node-2:
+    cpu: 10
+  removed:
+    cpu: 90
+`)
+
+	client, err := NewMetricsClient(nil, nil, map[string]string{"type": Metrics_Type_File, "file.path": path})
+	assert.NoError(t, err)
+
+	nodeMetricsMap := map[string]*NodeMetrics{"node-1": {}, "node-2": {}}
+	assert.NoError(t, client.(*CachedMetricsClient).client.NodesMetricsAvg(context.Background(), nodeMetricsMap))
+	assert.Equal(t, &NodeMetrics{MetricsTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), CPU: 35.5, Memory: 60}, nodeMetricsMap["node-1"])
+	assert.Equal(t, 10.0, nodeMetricsMap["node-2"].CPU)
+	assert.False(t, nodeMetricsMap["node-2"].MetricsTime.IsZero())
+	assert.NotContains(t, nodeMetricsMap, "removed")
+
+	_, err = NewMetricsClient(nil, nil, map[string]string{"type": Metrics_Type_File})
+	assert.Error(t, err)
+	_, err = NewMetricsClient(nil, nil, map[string]string{"type": Metrics_Type_File, "file.path": path, "staleness": "soon"})
+	assert.Error(t, err)
+	_, err = NewMetricsClient(nil, nil, map[string]string{"type": "graphite"})
+	assert.Error(t, err)
+}
+
+func TestCachedMetricsClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.yaml")
+	writeMetricsFile(t, path, `
+nodes:
+  node-1: {cpu: 20, memory: 30, metricsTime: "2024-01-01T00:00:00Z"}
+  node-2: {cpu: 40, memory: 50, metricsTime: "2024-01-01T00:00:00Z"}
+`)
+	inner, err := NewFileMetricsClient(map[string]string{"file.path": path})
+	assert.NoError(t, err)
+	client := NewCachedMetricsClient(inner, time.Minute)
+	now := time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	nodeMetricsMap := map[string]*NodeMetrics{"node-1": {}, "node-2": {}}
+	assert.NoError(t, client.NodesMetricsAvg(context.Background(), nodeMetricsMap))
+	assert.Equal(t, 20.0, nodeMetricsMap["node-1"].CPU)
+	assert.Equal(t, 50.0, nodeMetricsMap["node-2"].Memory)
+
+	// node-2 is no longer reported, its last metrics are served until they are stale
+	writeMetricsFile(t, path, `
+nodes:
+  node-1: {cpu: 25, memory: 35, metricsTime: "2024-01-01T00:01:00Z"}
+`)
+	now = now.Add(30 * time.Second)
+	nodeMetricsMap = map[string]*NodeMetrics{"node-1": {}, "node-2": {}}
+	assert.NoError(t, client.NodesMetricsAvg(context.Background(), nodeMetricsMap))
+	assert.Equal(t, 25.0, nodeMetricsMap["node-1"].CPU)
+	assert.Equal(t, 40.0, nodeMetricsMap["node-2"].CPU)
+
+	// the source is unreachable, the cached metrics are served and the error is returned
+	assert.NoError(t, os.Remove(path))
+	now = now.Add(30 * time.Second)
+	nodeMetricsMap = map[string]*NodeMetrics{"node-1": {}, "node-2": {}}
+	assert.Error(t, client.NodesMetricsAvg(context.Background(), nodeMetricsMap))
+	assert.Equal(t, 25.0, nodeMetricsMap["node-1"].CPU)
+	assert.True(t, nodeMetricsMap["node-2"].MetricsTime.IsZero())
+
+	// all the metrics are stale
+	now = now.Add(time.Minute)
+	nodeMetricsMap = map[string]*NodeMetrics{"node-1": {}, "node-2": {}}
+	assert.Error(t, client.NodesMetricsAvg(context.Background(), nodeMetricsMap))
+	assert.Equal(t, &NodeMetrics{}, nodeMetricsMap["node-1"])
+	assert.Empty(t, client.last)
+}
+
+func TestMetricsWindow(t *testing.T) {
+	window := newMetricsWindow()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, found := window.avg("node-1")
+	assert.False(t, found)
+
+	window.add("node-1", resourceCPU, start, 10)
+	window.add("node-1", resourceCPU, start.Add(5*time.Minute), 20)
+	window.add("node-1", resourceCPU, start.Add(5*time.Minute), 30)
+	window.add("node-1", resourceMemory, start.Add(time.Minute), 40)
+	metrics, found := window.avg("node-1")
+	assert.True(t, found)
+	assert.Equal(t, &NodeMetrics{MetricsTime: start.Add(5 * time.Minute), CPU: 20, Memory: 40}, metrics)
+
+	// the first sample is out of the period
+	window.add("node-1", resourceCPU, start.Add(11*time.Minute), 60)
+	metrics, _ = window.avg("node-1")
+	assert.Equal(t, 45.0, metrics.CPU)
+
+	window.prune(map[string]*NodeMetrics{"node-2": {}})
+	_, found = window.avg("node-1")
+	assert.False(t, found)
+}
@@ -0,0 +1,90 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FileMetricsClient reads the node usage from a local YAML or JSON file, it is meant for tests and
+// for the environments without a monitoring system, e.g.
+//
+//	nodes:
+//	  node-1:
+//	    cpu: 35.5          # usage in percent
+//	    memory: 60
+//	    metricsTime: "2024-01-01T00:00:00Z" # optional, the modification time of the file by default
+type FileMetricsClient struct {
+	path string
+}
+
+// NodeMetricsFile is the content of the file read by FileMetricsClient.
+type NodeMetricsFile struct {
+	Nodes map[string]NodeMetricsFileEntry `json:"nodes"`
+}
+
+// NodeMetricsFileEntry is the usage of a node in the file read by FileMetricsClient.
+type NodeMetricsFileEntry struct {
+	CPU         float64    `json:"cpu"`
+	Memory      float64    `json:"memory"`
+	MetricsTime *time.Time `json:"metricsTime,omitempty"`
+}
+
+func NewFileMetricsClient(conf map[string]string) (*FileMetricsClient, error) {
+	path := conf["file.path"]
+	if len(path) == 0 {
+		return nil, errors.New("metrics file path is empty")
+	}
+	return &FileMetricsClient{path: path}, nil
+}
+
+func (f *FileMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	content := &NodeMetricsFile{}
+	if err := yaml.Unmarshal(data, content); err != nil {
+		return fmt.Errorf("invalid metrics file %s: %v", f.path, err)
+	}
+
+	for nodeName, entry := range content.Nodes {
+		if _, found := nodeMetricsMap[nodeName]; !found {
+			continue
+		}
+		metricsTime := info.ModTime()
+		if entry.MetricsTime != nil {
+			metricsTime = *entry.MetricsTime
+		}
+		nodeMetricsMap[nodeName] = &NodeMetrics{
+			MetricsTime: metricsTime,
+			CPU:         entry.CPU,
+			Memory:      entry.Memory,
+		}
+	}
+	return nil
+}
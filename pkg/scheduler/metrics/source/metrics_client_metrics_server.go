@@ -0,0 +1,96 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// MetricsServerClient gets the node usage from the metrics.k8s.io API served by metrics-server.
+// metrics-server only reports the current usage, so the client keeps the samples of the last
+// NODE_METRICS_PERIOD to compute the average usage. The allocatable of the nodes is read from
+// the node lister of the scheduler cache.
+type MetricsServerClient struct {
+	metricsClient metricsclientset.Interface
+	nodeLister    listersv1.NodeLister
+	window        *metricsWindow
+}
+
+func NewMetricsServerClient(restConfig *rest.Config, nodeLister listersv1.NodeLister) (*MetricsServerClient, error) {
+	if restConfig == nil {
+		return nil, fmt.Errorf("no client config for metrics server")
+	}
+	if nodeLister == nil {
+		return nil, fmt.Errorf("no node lister for metrics server")
+	}
+	metricsClient, err := metricsclientset.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newMetricsServerClient(metricsClient, nodeLister), nil
+}
+
+func newMetricsServerClient(metricsClient metricsclientset.Interface, nodeLister listersv1.NodeLister) *MetricsServerClient {
+	return &MetricsServerClient{
+		metricsClient: metricsClient,
+		nodeLister:    nodeLister,
+		window:        newMetricsWindow(),
+	}
+}
+
+func (m *MetricsServerClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	klog.V(5).Infof("Get node metrics from metrics server")
+
+	nodeMetricsList, err := m.metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list node metrics: %v", err)
+	}
+	for _, nodeMetrics := range nodeMetricsList.Items {
+		nodeName := nodeMetrics.Name
+		if _, found := nodeMetricsMap[nodeName]; !found {
+			klog.V(5).Infof("The node %s metrics are obtained through the metrics server, but the volcano cache does not contain the node information.", nodeName)
+			continue
+		}
+		node, err := m.nodeLister.Get(nodeName)
+		if err != nil {
+			klog.V(5).Infof("Failed to get node %s for its metrics: %v", nodeName, err)
+			continue
+		}
+		capacity := node.Status.Allocatable
+		if cpu, usage := capacity.Cpu().MilliValue(), nodeMetrics.Usage.Cpu().MilliValue(); cpu > 0 {
+			m.window.add(nodeName, resourceCPU, nodeMetrics.Timestamp.Time, float64(usage)*100/float64(cpu))
+		}
+		if memory, usage := capacity.Memory().Value(), nodeMetrics.Usage.Memory().Value(); memory > 0 {
+			m.window.add(nodeName, resourceMemory, nodeMetrics.Timestamp.Time, float64(usage)*100/float64(memory))
+		}
+	}
+
+	m.window.prune(nodeMetricsMap)
+	for nodeName := range nodeMetricsMap {
+		if metrics, found := m.window.avg(nodeName); found {
+			nodeMetricsMap[nodeName] = metrics
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+func TestMetricsServerClient(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	buildNode := func(name string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+			}},
+		}
+	}
+	buildNodeMetrics := func(name, cpu, memory string) *metricsv1beta1.NodeMetrics {
+		return &metricsv1beta1.NodeMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Timestamp:  metav1.NewTime(now),
+			Usage: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(memory),
+			},
+		}
+	}
+
+	// the tracker of the fake clientset does not map NodeMetrics to the "nodes" resource
+	metricsClient := &metricsfake.Clientset{}
+	metricsClient.AddReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1.NodeMetricsList{Items: []metricsv1beta1.NodeMetrics{
+			*buildNodeMetrics("node-1", "1", "2Gi"),
+			*buildNodeMetrics("unknown", "1", "1Gi"),
+		}}, nil
+	})
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, name := range []string{"node-1", "node-2"} {
+		assert.NoError(t, indexer.Add(buildNode(name)))
+	}
+	client := newMetricsServerClient(metricsClient, listersv1.NewNodeLister(indexer))
+
+	nodeMetricsMap := map[string]*NodeMetrics{"node-1": {}, "node-2": {}}
+	assert.NoError(t, client.NodesMetricsAvg(context.Background(), nodeMetricsMap))
+	assert.Equal(t, &NodeMetrics{MetricsTime: now, CPU: 25, Memory: 25}, nodeMetricsMap["node-1"])
+	assert.Equal(t, &NodeMetrics{}, nodeMetricsMap["node-2"])
+}
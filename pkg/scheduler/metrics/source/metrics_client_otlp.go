@@ -0,0 +1,312 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	collectormetricsv1 "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// otlpDefaultAddress is the default address of the OTLP/HTTP receiver, it is only reachable
+	// from the pod of the scheduler, e.g. by a collector running as a sidecar
+	otlpDefaultAddress = "127.0.0.1:4318"
+	// otlpMetricsPath is the path of the metrics of the OTLP/HTTP protocol
+	otlpMetricsPath = "/v1/metrics"
+	// otlpDefaultCPUMetric is the default gauge of the cpu utilization, in the range [0, 1]
+	otlpDefaultCPUMetric = "system.cpu.utilization"
+	// otlpDefaultMemoryMetric is the default gauge of the memory utilization, in the range [0, 1]
+	otlpDefaultMemoryMetric = "system.memory.utilization"
+	// otlpDefaultNodeNameAttribute is the default resource attribute holding the node name
+	otlpDefaultNodeNameAttribute = "host.name"
+
+	// 16MB
+	otlpMaxBodySize = 16 << 20
+)
+
+var (
+	otlpLock sync.Mutex
+	// otlpReceivers are the receivers listening by address, a receiver keeps listening when the
+	// configuration is reloaded so that the samples received are not lost.
+	otlpReceivers = map[string]*OTLPMetricsClient{}
+)
+
+// OTLPMetricsClient is an OTLP/HTTP receiver, the OpenTelemetry collectors push the node metrics
+// to it, in protobuf or JSON encoding. The data points of the utilization gauges are read from the
+// resources with the node name attribute; a data point with a "state" attribute, as reported by
+// the hostmetrics receiver, counts for its value if the state is "used" and for one minus its
+// value if the state is "idle", the other states are ignored.
+// The receiver listens on the loopback interface by default, it requires TLS and a bearer token
+// to listen on an address reachable from other hosts, since the node metrics drive evictions.
+type OTLPMetricsClient struct {
+	lock              sync.RWMutex
+	cpuMetric         string
+	memoryMetric      string
+	nodeNameAttribute string
+	// token is the bearer token required from the collectors, no token is required if empty
+	token string
+
+	window *metricsWindow
+}
+
+func NewOTLPMetricsClient(conf map[string]string) (*OTLPMetricsClient, error) {
+	address := conf["address"]
+	if len(address) == 0 {
+		address = otlpDefaultAddress
+	}
+
+	otlpLock.Lock()
+	defer otlpLock.Unlock()
+
+	certFile, keyFile := conf["tls.certFile"], conf["tls.keyFile"]
+	if !isLoopbackAddress(address) && (certFile == "" || keyFile == "" || conf["otlp.tokenFile"] == "") {
+		return nil, fmt.Errorf("the OTLP metrics receiver on %s is reachable from other hosts, "+
+			"it requires tls.certFile, tls.keyFile and otlp.tokenFile", address)
+	}
+
+	if receiver, found := otlpReceivers[address]; found {
+		if err := receiver.configure(conf); err != nil {
+			return nil, err
+		}
+		return receiver, nil
+	}
+
+	receiver, err := newOTLPMetricsClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s for OTLP metrics: %v", address, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(otlpMetricsPath, receiver)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		var err error
+		if certFile != "" && keyFile != "" {
+			err = server.ServeTLS(listener, certFile, keyFile)
+		} else {
+			err = server.Serve(listener)
+		}
+		klog.Errorf("OTLP metrics receiver on %s stopped: %v", address, err)
+	}()
+	klog.V(3).Infof("OTLP metrics receiver is listening on %s", address)
+	otlpReceivers[address] = receiver
+	return receiver, nil
+}
+
+func newOTLPMetricsClient(conf map[string]string) (*OTLPMetricsClient, error) {
+	receiver := &OTLPMetricsClient{window: newMetricsWindow()}
+	if err := receiver.configure(conf); err != nil {
+		return nil, err
+	}
+	return receiver, nil
+}
+
+func (o *OTLPMetricsClient) configure(conf map[string]string) error {
+	token := ""
+	if tokenFile := conf["otlp.tokenFile"]; tokenFile != "" {
+		data, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the OTLP token file %s: %v", tokenFile, err)
+		}
+		if token = strings.TrimSpace(string(data)); token == "" {
+			return fmt.Errorf("the OTLP token file %s is empty", tokenFile)
+		}
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.cpuMetric = valueOrDefault(conf["otlp.cpuMetric"], otlpDefaultCPUMetric)
+	o.memoryMetric = valueOrDefault(conf["otlp.memoryMetric"], otlpDefaultMemoryMetric)
+	o.nodeNameAttribute = valueOrDefault(conf["otlp.nodeNameAttribute"], otlpDefaultNodeNameAttribute)
+	o.token = token
+	return nil
+}
+
+// isLoopbackAddress checks whether the address only listens on the loopback interface.
+func isLoopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (o *OTLPMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	o.window.prune(nodeMetricsMap)
+	for nodeName := range nodeMetricsMap {
+		if metrics, found := o.window.avg(nodeName); found {
+			nodeMetricsMap[nodeName] = metrics
+		}
+	}
+	return nil
+}
+
+// ServeHTTP handles the export requests of the OTLP/HTTP protocol.
+func (o *OTLPMetricsClient) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	o.lock.RLock()
+	token := o.token
+	o.lock.RUnlock()
+	if token != "" {
+		got, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	var body io.Reader = http.MaxBytesReader(w, r.Body, otlpMaxBodySize)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer gz.Close()
+		body = io.LimitReader(gz, otlpMaxBodySize)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	isJSON := strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+	request := &collectormetricsv1.ExportMetricsServiceRequest{}
+	if isJSON {
+		err = protojson.Unmarshal(data, request)
+	} else {
+		err = proto.Unmarshal(data, request)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid OTLP metrics: %v", err), http.StatusBadRequest)
+		return
+	}
+	o.consume(request)
+
+	var response []byte
+	if isJSON {
+		w.Header().Set("Content-Type", "application/json")
+		response, err = protojson.Marshal(&collectormetricsv1.ExportMetricsServiceResponse{})
+	} else {
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		response, err = proto.Marshal(&collectormetricsv1.ExportMetricsServiceResponse{})
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(response)
+}
+
+// consume records the utilization gauges of the nodes in the request.
+func (o *OTLPMetricsClient) consume(request *collectormetricsv1.ExportMetricsServiceRequest) {
+	o.lock.RLock()
+	resources := map[string]string{o.cpuMetric: resourceCPU, o.memoryMetric: resourceMemory}
+	nodeNameAttribute := o.nodeNameAttribute
+	o.lock.RUnlock()
+
+	for _, rm := range request.GetResourceMetrics() {
+		nodeName := stringAttribute(rm.GetResource().GetAttributes(), nodeNameAttribute)
+		if nodeName == "" {
+			continue
+		}
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, metric := range sm.GetMetrics() {
+				resource, found := resources[metric.GetName()]
+				if !found || metric.GetGauge() == nil {
+					continue
+				}
+				for t, value := range utilizationByTime(metric.GetGauge().GetDataPoints()) {
+					o.window.add(nodeName, resource, time.Unix(0, int64(t)), value*100)
+				}
+			}
+		}
+	}
+}
+
+// utilizationByTime returns the average utilization of the data points of every timestamp.
+func utilizationByTime(points []*metricsv1.NumberDataPoint) map[uint64]float64 {
+	sums := map[uint64]float64{}
+	counts := map[uint64]int{}
+	for _, point := range points {
+		value := point.GetAsDouble()
+		if _, ok := point.GetValue().(*metricsv1.NumberDataPoint_AsInt); ok {
+			value = float64(point.GetAsInt())
+		}
+		switch stringAttribute(point.GetAttributes(), "state") {
+		case "":
+		case "used":
+		case "idle":
+			value = 1 - value
+		default:
+			continue
+		}
+		sums[point.GetTimeUnixNano()] += value
+		counts[point.GetTimeUnixNano()]++
+	}
+	for t := range sums {
+		sums[t] /= float64(counts[t])
+	}
+	return sums
+}
+
+func stringAttribute(attributes []*commonv1.KeyValue, key string) string {
+	for _, attribute := range attributes {
+		if attribute.GetKey() == key {
+			return attribute.GetValue().GetStringValue()
+		}
+	}
+	return ""
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if len(value) == 0 {
+		return defaultValue
+	}
+	return value
+}
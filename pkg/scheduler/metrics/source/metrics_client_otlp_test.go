@@ -0,0 +1,140 @@
+/*
+ Copyright 2024 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	collectormetricsv1 "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func stringKeyValue(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}}}
+}
+
+func gaugeMetric(name string, t time.Time, points map[string]float64) *metricsv1.Metric {
+	gauge := &metricsv1.Gauge{}
+	for state, value := range points {
+		point := &metricsv1.NumberDataPoint{
+			TimeUnixNano: uint64(t.UnixNano()),
+			Value:        &metricsv1.NumberDataPoint_AsDouble{AsDouble: value},
+		}
+		if state != "" {
+			point.Attributes = []*commonv1.KeyValue{stringKeyValue("state", state)}
+		}
+		gauge.DataPoints = append(gauge.DataPoints, point)
+	}
+	return &metricsv1.Metric{Name: name, Data: &metricsv1.Metric_Gauge{Gauge: gauge}}
+}
+
+func TestOTLPMetricsClient(t *testing.T) {
+	receiver, err := newOTLPMetricsClient(map[string]string{"otlp.memoryMetric": "k8s.node.memory.utilization"})
+	assert.NoError(t, err)
+	now := time.Now().Truncate(time.Second)
+	request := &collectormetricsv1.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricsv1.ResourceMetrics{
+			{
+				Resource: &resourcev1.Resource{Attributes: []*commonv1.KeyValue{stringKeyValue("host.name", "node-1")}},
+				ScopeMetrics: []*metricsv1.ScopeMetrics{{Metrics: []*metricsv1.Metric{
+					gaugeMetric(otlpDefaultCPUMetric, now, map[string]float64{"idle": 0.7, "user": 0.2, "system": 0.1}),
+					gaugeMetric("k8s.node.memory.utilization", now, map[string]float64{"": 0.5}),
+					gaugeMetric(otlpDefaultMemoryMetric, now, map[string]float64{"used": 0.9}),
+				}}},
+			},
+			{
+				// no node name
+				ScopeMetrics: []*metricsv1.ScopeMetrics{{Metrics: []*metricsv1.Metric{
+					gaugeMetric(otlpDefaultCPUMetric, now, map[string]float64{"": 1}),
+				}}},
+			},
+		},
+	}
+	body, err := proto.Marshal(request)
+	assert.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, otlpMetricsPath, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	receiver.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	jsonBody := `{"resourceMetrics":[{"resource":{"attributes":[{"key":"host.name","value":{"stringValue":"node-2"}}]},` +
+		`"scopeMetrics":[{"metrics":[{"name":"system.cpu.utilization","gauge":{"dataPoints":[{"timeUnixNano":"` +
+		strconv.FormatInt(now.UnixNano(), 10) + `","asDouble":0.25}]}}]}]}]}`
+	recorder = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, otlpMetricsPath, bytes.NewBufferString(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	receiver.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, otlpMetricsPath, bytes.NewBufferString("{"))
+	req.Header.Set("Content-Type", "application/json")
+	receiver.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	nodeMetricsMap := map[string]*NodeMetrics{"node-1": {}, "node-2": {}, "node-3": {}}
+	assert.NoError(t, receiver.NodesMetricsAvg(context.Background(), nodeMetricsMap))
+	assert.InDelta(t, 30.0, nodeMetricsMap["node-1"].CPU, 1e-9)
+	assert.InDelta(t, 50.0, nodeMetricsMap["node-1"].Memory, 1e-9)
+	assert.True(t, now.Equal(nodeMetricsMap["node-1"].MetricsTime))
+	assert.InDelta(t, 25.0, nodeMetricsMap["node-2"].CPU, 1e-9)
+	assert.True(t, nodeMetricsMap["node-3"].MetricsTime.IsZero())
+}
+
+func TestOTLPMetricsClientAuthentication(t *testing.T) {
+	_, err := NewOTLPMetricsClient(map[string]string{"address": ":0"})
+	assert.Error(t, err, "the receiver reachable from other hosts requires TLS and a token")
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0600))
+	receiver, err := newOTLPMetricsClient(map[string]string{"otlp.tokenFile": tokenFile})
+	assert.NoError(t, err)
+
+	body, err := proto.Marshal(&collectormetricsv1.ExportMetricsServiceRequest{})
+	assert.NoError(t, err)
+	for _, test := range []struct {
+		authorization string
+		wantCode      int
+	}{
+		{authorization: "", wantCode: http.StatusUnauthorized},
+		{authorization: "Bearer wrong", wantCode: http.StatusUnauthorized},
+		{authorization: "Bearer secret", wantCode: http.StatusOK},
+	} {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, otlpMetricsPath, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+		receiver.ServeHTTP(recorder, req)
+		assert.Equal(t, test.wantCode, recorder.Code, test.authorization)
+	}
+}
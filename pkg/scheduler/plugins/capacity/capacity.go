@@ -19,6 +19,7 @@ package capacity
 import (
 	"fmt"
 	"math"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -30,6 +31,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/metrics"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+	"volcano.sh/volcano/pkg/util/quotaschedule"
 )
 
 const (
@@ -43,6 +45,10 @@ type capacityPlugin struct {
 	totalGuarantee *api.Resource
 
 	queueOpts map[api.QueueID]*queueAttr
+	// quotas is the deserved and capability of the queues switched by their quota schedules
+	quotas map[api.QueueID]quotaschedule.Quota
+	// quotaTransitionPeriod is how long the quota of a queue takes to switch at the boundaries of its windows
+	quotaTransitionPeriod time.Duration
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
 }
@@ -74,6 +80,8 @@ func New(arguments framework.Arguments) framework.Plugin {
 		totalGuarantee:  api.EmptyResource(),
 		queueOpts:       map[api.QueueID]*queueAttr{},
 		pluginArguments: arguments,
+
+		quotaTransitionPeriod: parseQuotaTransitionPeriod(arguments),
 	}
 }
 
@@ -101,6 +109,8 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 	klog.V(4).Infof("The total resource is <%v>", cp.totalResource)
 
 	hierarchyEnabled := cp.HierarchyEnabled(ssn)
+	cp.buildQueueQuotas(ssn, hierarchyEnabled)
+
 	readyToSchedule := true
 	if hierarchyEnabled {
 		readyToSchedule = cp.buildHierarchicalQueueAttrs(ssn)
@@ -270,6 +280,7 @@ func (cp *capacityPlugin) OnSessionClose(ssn *framework.Session) {
 	cp.totalResource = nil
 	cp.totalGuarantee = nil
 	cp.queueOpts = nil
+	cp.quotas = nil
 }
 
 func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
@@ -290,15 +301,15 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 				queueID: queue.UID,
 				name:    queue.Name,
 
-				deserved:  api.NewResource(queue.Queue.Spec.Deserved),
+				deserved:  api.NewResource(cp.queueDeserved(queue)),
 				allocated: api.EmptyResource(),
 				request:   api.EmptyResource(),
 				elastic:   api.EmptyResource(),
 				inqueue:   api.EmptyResource(),
 				guarantee: api.EmptyResource(),
 			}
-			if len(queue.Queue.Spec.Capability) != 0 {
-				attr.capability = api.NewResource(queue.Queue.Spec.Capability)
+			if capability := cp.queueCapability(queue); len(capability) != 0 {
+				attr.capability = api.NewResource(capability)
 				if attr.capability.MilliCPU <= 0 {
 					attr.capability.MilliCPU = math.MaxFloat64
 				}
@@ -379,8 +390,8 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 			continue
 		}
 		deservedCPU, deservedMem, scalarResources := 0.0, 0.0, map[v1.ResourceName]float64{}
-		if queue.Queue.Spec.Deserved != nil {
-			attr := api.NewResource(queue.Queue.Spec.Deserved)
+		if deserved := cp.queueDeserved(queue); deserved != nil {
+			attr := api.NewResource(deserved)
 			deservedCPU = attr.MilliCPU
 			deservedMem = attr.Memory
 			scalarResources = attr.ScalarResources
@@ -393,8 +404,8 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 			guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
 		}
 		realCapacity := api.ExceededPart(cp.totalResource, cp.totalGuarantee).Add(guarantee)
-		if len(queue.Queue.Spec.Capability) > 0 {
-			capacity := api.NewResource(queue.Queue.Spec.Capability)
+		if capability := cp.queueCapability(queue); len(capability) > 0 {
+			capacity := api.NewResource(capability)
 			realCapacity.MinDimensionResource(capacity, api.Infinity)
 			metrics.UpdateQueueCapacity(queueInfo.Name, capacity.MilliCPU, capacity.Memory, capacity.ScalarResources)
 		}
@@ -605,7 +616,7 @@ func (cp *capacityPlugin) newQueueAttr(queue *api.QueueInfo) *queueAttr {
 		ancestors: make([]api.QueueID, 0),
 		children:  make(map[api.QueueID]*queueAttr),
 
-		deserved:   api.NewResource(queue.Queue.Spec.Deserved),
+		deserved:   api.NewResource(cp.queueDeserved(queue)),
 		allocated:  api.EmptyResource(),
 		request:    api.EmptyResource(),
 		elastic:    api.EmptyResource(),
@@ -613,8 +624,8 @@ func (cp *capacityPlugin) newQueueAttr(queue *api.QueueInfo) *queueAttr {
 		guarantee:  api.EmptyResource(),
 		capability: api.EmptyResource(),
 	}
-	if len(queue.Queue.Spec.Capability) != 0 {
-		attr.capability = api.NewResource(queue.Queue.Spec.Capability)
+	if capability := cp.queueCapability(queue); len(capability) != 0 {
+		attr.capability = api.NewResource(capability)
 	}
 
 	if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
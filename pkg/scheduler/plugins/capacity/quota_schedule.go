@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/util/quotaschedule"
+)
+
+const (
+	// QuotaScheduleAnnotation is the key of the quota schedule of a queue, a json list of the windows in which the
+	// deserved and capability of the queue are overridden, e.g.
+	// [{"name":"night","schedule":"0 20 * * 1-5","duration":"12h","timeZone":"Europe/Berlin","deserved":{"cpu":"200"}}]
+	QuotaScheduleAnnotation = quotaschedule.Annotation
+	// QuotaTransitionPeriodKey is the argument of how long the quota of a queue takes to switch linearly to the
+	// quota of the new window at the boundaries of the windows, so that the queues are reclaimed gradually.
+	QuotaTransitionPeriodKey = "quotaTransitionPeriod"
+
+	defaultQuotaTransitionPeriod = 10 * time.Minute
+)
+
+// timeNow is the clock of the quota schedules, it is replaced in tests
+var timeNow = time.Now
+
+// quotaWarnings is the last warning about the quota schedule of each queue, so that a queue is warned once instead
+// of every session.
+var quotaWarnings sync.Map
+
+func warnQuotaOnce(queue, message string) {
+	if last, found := quotaWarnings.Load(queue); found && last == message {
+		return
+	}
+	quotaWarnings.Store(queue, message)
+	klog.Warning(message)
+}
+
+// specQuota returns the quota of the queue in its spec.
+func specQuota(queue *api.QueueInfo) quotaschedule.Quota {
+	return quotaschedule.Quota{Deserved: queue.Queue.Spec.Deserved, Capability: queue.Queue.Spec.Capability}
+}
+
+// queueQuotaAt returns the quota of the queue at now by its quota schedule, and whether it has a valid schedule.
+// Within the transition period after a boundary of the windows, the quota moves linearly from the quota before the
+// boundary to the new one, so that the resources over the new deserved are reclaimed a few at a time instead of
+// all at once.
+func queueQuotaAt(queue *api.QueueInfo, now time.Time, transition time.Duration) (quotaschedule.Quota, bool) {
+	spec := queue.Queue.Spec
+	value, found := queue.Queue.Annotations[QuotaScheduleAnnotation]
+	if !found {
+		return specQuota(queue), false
+	}
+	windows, err := quotaschedule.Parse(value)
+	if err != nil {
+		warnQuotaOnce(queue.Name, fmt.Sprintf("Invalid quota schedule of queue <%s>, the spec is used: %v", queue.Name, err))
+		return specQuota(queue), false
+	}
+
+	current := quotaschedule.QuotaAt(spec.Deserved, spec.Capability, windows, now)
+	boundary, found := quotaschedule.LastBoundary(windows, now)
+	if transition <= 0 || !found || now.Sub(boundary) >= transition {
+		return current, true
+	}
+	previous := quotaschedule.QuotaAt(spec.Deserved, spec.Capability, windows, boundary.Add(-time.Nanosecond))
+	fraction := float64(now.Sub(boundary)) / float64(transition)
+	klog.V(4).Infof("Quota of queue <%s> is switching from window %q to %q, %.0f%% done",
+		queue.Name, previous.Window, current.Window, fraction*100)
+	return quotaschedule.Quota{
+		Window:     current.Window,
+		Deserved:   quotaschedule.Interpolate(previous.Deserved, current.Deserved, fraction),
+		Capability: quotaschedule.Interpolate(previous.Capability, current.Capability, fraction),
+	}, true
+}
+
+func parseQuotaTransitionPeriod(arguments framework.Arguments) time.Duration {
+	value, found := arguments[QuotaTransitionPeriodKey]
+	if !found {
+		return defaultQuotaTransitionPeriod
+	}
+	period, err := time.ParseDuration(fmt.Sprint(value))
+	if err != nil || period < 0 {
+		klog.Warningf("Invalid %s %v of capacity plugin, use %v", QuotaTransitionPeriodKey, value, defaultQuotaTransitionPeriod)
+		return defaultQuotaTransitionPeriod
+	}
+	return period
+}
+
+// buildQueueQuotas switches the quota of the queues by their quota schedules at the session open. With the
+// hierarchy enabled, the queues whose scheduled quota breaks the hierarchy fall back to their spec.
+func (cp *capacityPlugin) buildQueueQuotas(ssn *framework.Session, hierarchyEnabled bool) {
+	now := timeNow()
+	cp.quotas = make(map[api.QueueID]quotaschedule.Quota, len(ssn.Queues))
+	overridden := map[api.QueueID]bool{}
+	for _, queue := range ssn.Queues {
+		quota, scheduled := queueQuotaAt(queue, now, cp.quotaTransitionPeriod)
+		if quota.Window != "" {
+			klog.V(3).Infof("Queue <%s> is in quota window %q, deserved <%v>, capability <%v>",
+				queue.Name, quota.Window, quota.Deserved, quota.Capability)
+		}
+		cp.quotas[queue.UID] = quota
+		overridden[queue.UID] = scheduled && !equality.Semantic.DeepEqual(quota, specQuota(queue))
+	}
+	if hierarchyEnabled {
+		cp.fallBackBrokenQuotas(ssn, overridden)
+	}
+}
+
+// fallBackBrokenQuotas uses the spec for the queues overridden by their schedule and involved in a break of the
+// hierarchy, so that a window breaking the hierarchy only affects its queue instead of failing the check of all
+// the queues.
+func (cp *capacityPlugin) fallBackBrokenQuotas(ssn *framework.Session, overridden map[api.QueueID]bool) {
+	parents := make(map[string]string, len(ssn.Queues))
+	queues := make(map[string]*api.QueueInfo, len(ssn.Queues))
+	for _, queue := range ssn.Queues {
+		queues[queue.Name] = queue
+		if queue.Name == cp.rootQueue {
+			continue
+		}
+		parents[queue.Name] = cp.rootQueue
+		if queue.Queue.Spec.Parent != "" {
+			parents[queue.Name] = queue.Queue.Spec.Parent
+		}
+	}
+
+	for {
+		quotas := make(map[string]quotaschedule.Quota, len(ssn.Queues))
+		for _, queue := range ssn.Queues {
+			quotas[queue.Name] = cp.quotas[queue.UID]
+		}
+		// the root queue deserves and is capable of the whole cluster
+		total := resourceList(cp.totalResource)
+		quotas[cp.rootQueue] = quotaschedule.Quota{Deserved: total, Capability: total}
+
+		fallback := false
+		for _, violation := range quotaschedule.CheckHierarchy(quotas, parents) {
+			for _, name := range append([]string{violation.Queue}, violation.Children...) {
+				queue, found := queues[name]
+				if !found || !overridden[queue.UID] {
+					continue
+				}
+				warnQuotaOnce(queue.Name, fmt.Sprintf("Quota window %q of queue <%s> breaks the queue hierarchy, the spec is used: %v",
+					cp.quotas[queue.UID].Window, queue.Name, violation))
+				cp.quotas[queue.UID] = specQuota(queue)
+				overridden[queue.UID] = false
+				fallback = true
+			}
+		}
+		if !fallback {
+			return
+		}
+	}
+}
+
+// resourceList converts the resource to a resource list, the scalar resources are in milli units in the resource.
+func resourceList(r *api.Resource) v1.ResourceList {
+	list := v1.ResourceList{
+		v1.ResourceCPU:    *resource.NewMilliQuantity(int64(r.MilliCPU), resource.DecimalSI),
+		v1.ResourceMemory: *resource.NewQuantity(int64(r.Memory), resource.BinarySI),
+	}
+	for name, quantity := range r.ScalarResources {
+		list[name] = *resource.NewMilliQuantity(int64(quantity), resource.DecimalSI)
+	}
+	return list
+}
+
+// queueDeserved returns the deserved of the queue in the session.
+func (cp *capacityPlugin) queueDeserved(queue *api.QueueInfo) v1.ResourceList {
+	if quota, found := cp.quotas[queue.UID]; found {
+		return quota.Deserved
+	}
+	return queue.Queue.Spec.Deserved
+}
+
+// queueCapability returns the capability of the queue in the session.
+func (cp *capacityPlugin) queueCapability(queue *api.QueueInfo) v1.ResourceList {
+	if quota, found := cp.quotas[queue.UID]; found {
+		return quota.Capability
+	}
+	return queue.Queue.Spec.Capability
+}
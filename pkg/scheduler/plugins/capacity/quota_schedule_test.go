@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/actions/reclaim"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func buildScheduledQueue(schedule string) *api.QueueInfo {
+	return &api.QueueInfo{
+		UID:  "q1",
+		Name: "q1",
+		Queue: &scheduling.Queue{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "q1",
+				Annotations: map[string]string{QuotaScheduleAnnotation: schedule},
+			},
+			Spec: scheduling.QueueSpec{
+				Deserved:   api.BuildResourceList("10", "10Gi"),
+				Capability: api.BuildResourceList("20", "20Gi"),
+			},
+		},
+	}
+}
+
+func TestQueueQuotaAt(t *testing.T) {
+	queue := buildScheduledQueue(`[
+		{"name": "weekend", "schedule": "0 0 * * 6", "duration": "48h", "deserved": {"cpu": "40", "memory": "40Gi"}, "capability": {"cpu": "40", "memory": "40Gi"}},
+		{"name": "night", "schedule": "0 20 * * 1-5", "duration": "12h", "deserved": {"cpu": "30", "memory": "30Gi"}}
+	]`)
+	tests := []struct {
+		name       string
+		now        time.Time
+		transition time.Duration
+		window     string
+		deserved   corev1.ResourceList
+		capability corev1.ResourceList
+	}{
+		{
+			name:       "business hours use the spec",
+			now:        time.Date(2024, 6, 5, 10, 0, 0, 0, time.UTC),
+			transition: 10 * time.Minute,
+			deserved:   api.BuildResourceList("10", "10Gi"),
+			capability: api.BuildResourceList("20", "20Gi"),
+		},
+		{
+			name:       "night overrides deserved only",
+			now:        time.Date(2024, 6, 5, 23, 0, 0, 0, time.UTC),
+			transition: 10 * time.Minute,
+			window:     "night",
+			deserved:   api.BuildResourceList("30", "30Gi"),
+			capability: api.BuildResourceList("20", "20Gi"),
+		},
+		{
+			name:       "weekend wins over the night of friday",
+			now:        time.Date(2024, 6, 8, 3, 0, 0, 0, time.UTC),
+			transition: 10 * time.Minute,
+			window:     "weekend",
+			deserved:   api.BuildResourceList("40", "40Gi"),
+			capability: api.BuildResourceList("40", "40Gi"),
+		},
+		{
+			name:       "quota switches linearly after the night ends",
+			now:        time.Date(2024, 6, 6, 8, 5, 0, 0, time.UTC),
+			transition: 10 * time.Minute,
+			deserved:   api.BuildResourceList("20", "20Gi"),
+			capability: api.BuildResourceList("20", "20Gi"),
+		},
+		{
+			name:       "quota switches at once without transition",
+			now:        time.Date(2024, 6, 6, 8, 5, 0, 0, time.UTC),
+			transition: 0,
+			deserved:   api.BuildResourceList("10", "10Gi"),
+			capability: api.BuildResourceList("20", "20Gi"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			quota, scheduled := queueQuotaAt(queue, test.now, test.transition)
+			assert.True(t, scheduled)
+			assert.Equal(t, test.window, quota.Window)
+			assert.True(t, api.NewResource(test.deserved).Equal(api.NewResource(quota.Deserved), api.Zero), "deserved %v", quota.Deserved)
+			assert.True(t, api.NewResource(test.capability).Equal(api.NewResource(quota.Capability), api.Zero), "capability %v", quota.Capability)
+		})
+	}
+
+	invalid, scheduled := queueQuotaAt(buildScheduledQueue(`[{"schedule": "0 20 * * *", "duration": "forever"}]`), time.Now(), 0)
+	assert.False(t, scheduled)
+	assert.Equal(t, queue.Queue.Spec.Deserved, invalid.Deserved)
+}
+
+func TestReclaimByQuotaSchedule(t *testing.T) {
+	defer func() { timeNow = time.Now }()
+	// 2024-06-05 10:00 is in business hours
+	timeNow = func() time.Time { return time.Date(2024, 6, 5, 10, 0, 0, 0, time.UTC) }
+
+	plugins := map[string]framework.PluginBuilder{PluginName: New, predicates.PluginName: predicates.New, gang.PluginName: gang.New}
+	trueValue := true
+	businessHours := `[{"name": "business", "schedule": "0 9 * * 1-5", "duration": "9h", "deserved": {"cpu": "2", "memory": "4Gi"}}]`
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{})
+	n2 := util.BuildNode("n2", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{})
+	p1 := util.BuildPod("ns1", "p1", "n1", corev1.PodRunning, api.BuildResourceList("2", "4Gi"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "false"}, nil)
+	p2 := util.BuildPod("ns1", "p2", "n2", corev1.PodRunning, api.BuildResourceList("2", "4Gi"), "pg1", map[string]string{}, nil)
+	p3 := util.BuildPod("ns1", "p3", "", corev1.PodPending, api.BuildResourceList("2", "4Gi"), "pg2", map[string]string{}, nil)
+	pg1 := util.BuildPodGroup("pg1", "ns1", "research", 1, nil, schedulingv1beta1.PodGroupRunning)
+	pg2 := util.BuildPodGroup("pg2", "ns1", "product", 1, nil, schedulingv1beta1.PodGroupInqueue)
+
+	research := util.BuildQueueWithResourcesQuantity("research", api.BuildResourceList("4", "8Gi"), nil)
+	research.Annotations = map[string]string{QuotaScheduleAnnotation: businessHours}
+	product := util.BuildQueueWithResourcesQuantity("product", api.BuildResourceList("0", "0Gi"), nil)
+	productInBusinessHours := product.DeepCopy()
+	productInBusinessHours.Annotations = map[string]string{QuotaScheduleAnnotation: businessHours}
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:           "product queue deserves nothing without schedule",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p1, p2, p3},
+			Nodes:          []*corev1.Node{n1, n2},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2},
+			Queues:         []*schedulingv1beta1.Queue{research, product},
+			ExpectEvicted:  []string{},
+			ExpectEvictNum: 0,
+		},
+		{
+			Name:           "product queue reclaims from research queue in business hours",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p1, p2, p3},
+			Nodes:          []*corev1.Node{n1, n2},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2},
+			Queues:         []*schedulingv1beta1.Queue{research, productInBusinessHours},
+			ExpectEvicted:  []string{"ns1/p2"},
+			ExpectEvictNum: 1,
+		},
+	}
+
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledAllocatable: &trueValue,
+					EnablePreemptive:   &trueValue,
+					EnabledReclaimable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+					Arguments:          framework.Arguments{QuotaTransitionPeriodKey: "0s"},
+				},
+				{
+					Name:             predicates.PluginName,
+					EnabledPredicate: &trueValue,
+				},
+				{
+					Name:               gang.PluginName,
+					EnabledJobStarving: &trueValue,
+				},
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{reclaim.New()})
+			if err := test.CheckEvict(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestFallBackBrokenQuotas(t *testing.T) {
+	defer func() { timeNow = time.Now }()
+	// 2024-06-05 10:00 is in business hours
+	timeNow = func() time.Time { return time.Date(2024, 6, 5, 10, 0, 0, 0, time.UTC) }
+
+	buildQueue := func(name, parent, deserved, schedule string) *api.QueueInfo {
+		queue := &scheduling.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       scheduling.QueueSpec{Parent: parent, Deserved: api.BuildResourceList(deserved, "0")},
+		}
+		if schedule != "" {
+			queue.Annotations = map[string]string{QuotaScheduleAnnotation: schedule}
+		}
+		return api.NewQueueInfo(queue)
+	}
+	businessHours := func(deserved string) string {
+		return `[{"name": "business", "schedule": "0 9 * * 1-5", "duration": "9h", "deserved": {"cpu": "` + deserved + `", "memory": "0"}}]`
+	}
+	queues := []*api.QueueInfo{
+		buildQueue("root", "", "0", ""),
+		buildQueue("research", "", "4", businessHours("8")),
+		buildQueue("product", "", "4", ""),
+		buildQueue("training", "research", "2", businessHours("3")),
+		buildQueue("broken", "research", "1", `[{"schedule": "0 9 * * 1-5", "duration": "forever"}]`),
+	}
+	ssn := &framework.Session{Queues: map[api.QueueID]*api.QueueInfo{}}
+	for _, queue := range queues {
+		ssn.Queues[queue.UID] = queue
+	}
+	cp := &capacityPlugin{rootQueue: rootQueueID, totalResource: api.NewResource(api.BuildResourceList("10", "10Gi"))}
+	cp.buildQueueQuotas(ssn, true)
+
+	expect := map[string]string{"root": "0", "research": "4", "product": "4", "training": "3", "broken": "1"}
+	for _, queue := range queues {
+		assert.True(t, api.NewResource(api.BuildResourceList(expect[queue.Name], "0")).Equal(api.NewResource(cp.queueDeserved(queue)), api.Zero),
+			"deserved of %s: %v", queue.Name, cp.queueDeserved(queue))
+	}
+}
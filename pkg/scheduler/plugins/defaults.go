@@ -34,6 +34,7 @@ func ApplyPluginConfDefaults(option *conf.PluginOption) {
 	setDefaultIfNil(&option.EnabledPredicate)
 	setDefaultIfNil(&option.EnabledBestNode)
 	setDefaultIfNil(&option.EnabledNodeOrder)
+	setDefaultIfNil(&option.EnabledHyperNodeOrder)
 	setDefaultIfNil(&option.EnabledTargetJob)
 	setDefaultIfNil(&option.EnabledReservedNodes)
 	setDefaultIfNil(&option.EnabledVictim)
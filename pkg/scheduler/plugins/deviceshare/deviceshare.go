@@ -18,6 +18,7 @@ package deviceshare
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"reflect"
 
@@ -44,6 +45,9 @@ const (
 
 	SchedulePolicyArgument = "deviceshare.SchedulePolicy"
 	ScheduleWeight         = "deviceshare.ScheduleWeight"
+	// DeviceSchedulePolicyArgumentFmt is the key of the schedule policy of a device family, e.g.
+	// "deviceshare.hamivgpu.SchedulePolicy", which overrides deviceshare.SchedulePolicy for the family
+	DeviceSchedulePolicyArgumentFmt = "deviceshare.%s.SchedulePolicy"
 )
 
 type deviceSharePlugin struct {
@@ -51,11 +55,13 @@ type deviceSharePlugin struct {
 	pluginArguments framework.Arguments
 	schedulePolicy  string
 	scheduleWeight  int
+	// devicePolicies is the schedule policies of the device families which override schedulePolicy
+	devicePolicies map[string]string
 }
 
 // New return priority plugin
 func New(arguments framework.Arguments) framework.Plugin {
-	dsp := &deviceSharePlugin{pluginArguments: arguments, schedulePolicy: "", scheduleWeight: 0}
+	dsp := &deviceSharePlugin{pluginArguments: arguments, schedulePolicy: "", scheduleWeight: 0, devicePolicies: map[string]string{}}
 	enablePredicate(dsp)
 	return dsp
 }
@@ -81,6 +87,12 @@ func enablePredicate(dsp *deviceSharePlugin) {
 		dsp.schedulePolicy = args[SchedulePolicyArgument].(string)
 	}
 	args.GetInt(&dsp.scheduleWeight, ScheduleWeight)
+	for _, name := range api.RegisteredDevices {
+		if policy, ok := args[fmt.Sprintf(DeviceSchedulePolicyArgumentFmt, name)].(string); ok {
+			dsp.devicePolicies[name] = policy
+		}
+	}
+	api.ConfigureDevices(args)
 
 	if gpushare.GpuSharingEnable && gpushare.GpuNumberEnable {
 		klog.Fatal("can not define true in both gpu sharing and gpu number")
@@ -98,11 +110,19 @@ func createStatus(code int, reason string) *api.Status {
 	return &status
 }
 
-func getDeviceScore(ctx context.Context, pod *v1.Pod, node *api.NodeInfo, schedulePolicy string) (int64, *k8sframework.Status) {
+// policyOf returns the schedule policy of the device family.
+func (dp *deviceSharePlugin) policyOf(name string) string {
+	if policy, found := dp.devicePolicies[name]; found {
+		return policy
+	}
+	return dp.schedulePolicy
+}
+
+func getDeviceScore(ctx context.Context, pod *v1.Pod, node *api.NodeInfo, policyOf func(string) string) (int64, *k8sframework.Status) {
 	s := float64(0)
-	for _, devices := range node.Others {
-		if devices.(api.Devices).HasDeviceRequest(pod) {
-			ns := devices.(api.Devices).ScoreNode(pod, schedulePolicy)
+	for _, name := range api.RegisteredDevices {
+		if devices, ok := node.Others[name].(api.Devices); ok && devices.HasDeviceRequest(pod) {
+			ns := devices.ScoreNode(pod, policyOf(name))
 			s += ns
 		}
 	}
@@ -130,7 +150,7 @@ func (dp *deviceSharePlugin) OnSessionOpen(ssn *framework.Session) {
 					klog.V(4).Infof("pod %s/%s did not request device %s on %s, skipping it", task.Pod.Namespace, task.Pod.Name, val, node.Name)
 					continue
 				}
-				code, msg, err := dev.FilterNode(task.Pod, dp.schedulePolicy)
+				code, msg, err := dev.FilterNode(task.Pod, dp.policyOf(val))
 				if err != nil {
 					predicateStatus = append(predicateStatus, createStatus(code, msg))
 					return api.NewFitErrWithStatus(task, node, predicateStatus...)
@@ -153,8 +173,8 @@ func (dp *deviceSharePlugin) OnSessionOpen(ssn *framework.Session) {
 
 	ssn.AddNodeOrderFn(dp.Name(), func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
 		// DeviceScore
-		if len(dp.schedulePolicy) > 0 {
-			score, status := getDeviceScore(context.TODO(), task.Pod, node, dp.schedulePolicy)
+		if len(dp.schedulePolicy) > 0 || len(dp.devicePolicies) > 0 {
+			score, status := getDeviceScore(context.TODO(), task.Pod, node, dp.policyOf)
 			if !status.IsSuccess() {
 				klog.Warningf("Node: %s, Calculate Device Score Failed because of Error: %v", node.Name, status.AsError())
 				return 0, status.AsError()
@@ -17,12 +17,14 @@ limitations under the License.
 package deviceshare
 
 import (
+	"context"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/devices/fake"
 	"volcano.sh/volcano/pkg/scheduler/api/devices/nvidia/vgpu"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/util"
@@ -128,3 +130,38 @@ func TestVgpuScore(t *testing.T) {
 	}
 
 }
+
+func TestRegisteredDeviceScore(t *testing.T) {
+	api.RegisterDevice(fake.DeviceName, func(nodeName string, node *v1.Node) api.Devices {
+		return fake.NewDevices(nodeName, node)
+	}, fake.Configure)
+	defer api.UnregisterDevice(fake.DeviceName)
+	defer func() { fake.Enable = false }()
+
+	plugin := New(framework.Arguments{
+		"deviceshare.SchedulePolicy":      "binpack",
+		"deviceshare.fake.SchedulePolicy": "spread",
+		fake.EnableArgument:               true,
+	}).(*deviceSharePlugin)
+	if !fake.Enable {
+		t.Fatalf("fake devices should be enabled by the arguments of deviceshare")
+	}
+	if policy := plugin.policyOf(fake.DeviceName); policy != "spread" {
+		t.Errorf("policy of fake devices should be spread, but not %s", policy)
+	}
+	if policy := plugin.policyOf(vgpu.DeviceName); policy != "binpack" {
+		t.Errorf("policy of vgpu should be binpack, but not %s", policy)
+	}
+
+	node := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", api.ScalarResource{Name: string(fake.ResourceName), Value: "4"}), nil)
+	nodeInfo := api.NewNodeInfo(node)
+	pod := util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", make(map[string]string), make(map[string]string))
+	pod.Spec.Containers[0].Resources.Limits = v1.ResourceList{}
+	addResource(pod.Spec.Containers[0].Resources.Limits, fake.ResourceName, "1")
+
+	// 1 of 4 devices used after the pod is placed, spread scores 75
+	score, status := getDeviceScore(context.TODO(), pod, nodeInfo, plugin.policyOf)
+	if !status.IsSuccess() || score != 75 {
+		t.Errorf("score of fake devices should be 75, but not %d", score)
+	}
+}
@@ -16,21 +16,11 @@ type OnSessionOpenResponse struct{}
 type OnSessionCloseRequest struct{}
 type OnSessionCloseResponse struct{}
 
-type PredicateRequest struct {
-	Task *api.TaskInfo `json:"task"`
-	Node *api.NodeInfo `json:"node"`
-}
-
 type PredicateResponse struct {
 	ErrorMessage string `json:"status"`
 	Code         int    `json:"code"`
 }
 
-type PrioritizeRequest struct {
-	Task  *api.TaskInfo   `json:"task"`
-	Nodes []*api.NodeInfo `json:"nodes"`
-}
-
 type PrioritizeResponse struct {
 	NodeScore    map[string]float64 `json:"nodeScore"`
 	ErrorMessage string             `json:"errorMessage"`
@@ -71,3 +61,58 @@ type JobReadyRequest struct {
 type JobReadyResponse struct {
 	Status bool `json:"status"`
 }
+
+// OrderResponse lists the uids of the requested objects from the first to the last, the
+// extender has no opinion on the order of the objects which are not listed.
+type OrderResponse struct {
+	Order []string `json:"order"`
+}
+
+type JobOrderRequest struct {
+	Jobs []*api.JobInfo `json:"jobs"`
+}
+
+type QueueOrderRequest struct {
+	Queues []*api.QueueInfo `json:"queues"`
+}
+
+type TaskOrderRequest struct {
+	Tasks []*api.TaskInfo `json:"tasks"`
+}
+
+type AllocatableRequest struct {
+	Queue     *api.QueueInfo `json:"queue"`
+	Candidate *api.TaskInfo  `json:"candidate"`
+}
+
+type AllocatableResponse struct {
+	Allocatable bool `json:"allocatable"`
+}
+
+type JobStarvingRequest struct {
+	Job *api.JobInfo `json:"job"`
+}
+
+type JobStarvingResponse struct {
+	Starving bool `json:"starving"`
+}
+
+type VictimTasksRequest struct {
+	Tasks []*api.TaskInfo `json:"tasks"`
+}
+
+// VictimTasksResponse lists the uids of the victims among the requested tasks.
+type VictimTasksResponse struct {
+	Victims []string `json:"victims"`
+}
+
+type BestNodeRequest struct {
+	Task *api.TaskInfo `json:"task"`
+	// NodeScores are the scores of the candidate nodes indexed by node name
+	NodeScores map[string]float64 `json:"nodeScores"`
+}
+
+// BestNodeResponse is the node chosen by the extender, an empty node lets the scheduler choose.
+type BestNodeResponse struct {
+	Node string `json:"node"`
+}
@@ -21,14 +21,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/extender/extenderpb"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
 )
 
@@ -38,14 +43,20 @@ const (
 
 	// ExtenderURLPrefix is the key for providing extender endpoint address
 	ExtenderURLPrefix = "extender.urlPrefix"
-	// ExtenderHTTPTimeout is the timeout for extender http calls
+	// ExtenderHTTPTimeout is the timeout for extender http and gRPC calls
 	ExtenderHTTPTimeout = "extender.httpTimeout"
+	// ExtenderGRPCAddress is the address of the extender gRPC server, the predicate, batch predicate, prioritize,
+	// preemptable and reclaimable methods are called over gRPC when it is set, their verbs only enable them
+	ExtenderGRPCAddress = "extender.grpcAddress"
 	// ExtenderOnSessionOpenVerb is the verb of OnSessionOpen method
 	ExtenderOnSessionOpenVerb = "extender.onSessionOpenVerb"
 	// ExtenderOnSessionCloseVerb is the verb of OnSessionClose method
 	ExtenderOnSessionCloseVerb = "extender.onSessionCloseVerb"
 	// ExtenderPredicateVerb is the verb of Predicate method
 	ExtenderPredicateVerb = "extender.predicateVerb"
+	// ExtenderBatchPredicateVerb is the verb of BatchPredicate method, which filters all nodes for a task
+	// in one call before the predicates of the task are checked
+	ExtenderBatchPredicateVerb = "extender.batchPredicateVerb"
 	// ExtenderPrioritizeVerb is the verb of Prioritize method
 	ExtenderPrioritizeVerb = "extender.prioritizeVerb"
 	// ExtenderPreemptableVerb is the verb of Preemptable method
@@ -58,9 +69,38 @@ const (
 	ExtenderJobEnqueueableVerb = "extender.jobEnqueueableVerb"
 	// ExtenderJobReadyVerb is the verb of JobReady method
 	ExtenderJobReadyVerb = "extender.jobReadyVerb"
+	// ExtenderJobOrderVerb is the verb of JobOrder method
+	ExtenderJobOrderVerb = "extender.jobOrderVerb"
+	// ExtenderQueueOrderVerb is the verb of QueueOrder method
+	ExtenderQueueOrderVerb = "extender.queueOrderVerb"
+	// ExtenderTaskOrderVerb is the verb of TaskOrder method
+	ExtenderTaskOrderVerb = "extender.taskOrderVerb"
+	// ExtenderAllocatableVerb is the verb of Allocatable method
+	ExtenderAllocatableVerb = "extender.allocatableVerb"
+	// ExtenderJobStarvingVerb is the verb of JobStarving method
+	ExtenderJobStarvingVerb = "extender.jobStarvingVerb"
+	// ExtenderVictimTasksVerb is the verb of VictimTasks method
+	ExtenderVictimTasksVerb = "extender.victimTasksVerb"
+	// ExtenderBestNodeVerb is the verb of BestNode method
+	ExtenderBestNodeVerb = "extender.bestNodeVerb"
 	// ExtenderIgnorable indicates whether the extender can ignore unexpected errors
 	ExtenderIgnorable = "extender.ignorable"
 
+	// hookTimeoutSuffix is appended to the name of a hook, e.g. extender.jobOrderTimeout, to set
+	// the timeout of its calls instead of extender.httpTimeout
+	hookTimeoutSuffix = "Timeout"
+	// hookFailurePolicySuffix is appended to the name of a hook, e.g. extender.jobOrderFailurePolicy,
+	// to set how its failures are handled instead of extender.ignorable
+	hookFailurePolicySuffix = "FailurePolicy"
+
+	// FailurePolicyIgnore lets the scheduler go on as if the hook was not registered when it fails
+	FailurePolicyIgnore = "Ignore"
+	// FailurePolicyFail rejects the task, job or queue checked by the hook when it fails
+	FailurePolicyFail = "Fail"
+	// FailurePolicyNone is the policy of the hooks which never change the scheduling decisions
+	// when they fail, the failure policy can not be set for them
+	FailurePolicyNone = ""
+
 	// 10MB
 	maxBodySize = 10 << 20
 )
@@ -68,21 +108,54 @@ const (
 type extenderConfig struct {
 	urlPrefix          string
 	httpTimeout        time.Duration
+	grpcAddress        string
 	onSessionOpenVerb  string
 	onSessionCloseVerb string
-	predicateVerb      string
-	prioritizeVerb     string
-	preemptableVerb    string
-	reclaimableVerb    string
 	queueOverusedVerb  string
 	jobEnqueueableVerb string
 	jobReadyVerb       string
 	ignorable          bool
+
+	// the calls of the predicate, batch predicate, prioritize, preemptable and reclaimable hooks
+	// are sent over gRPC when grpcAddress is set
+	predicate      hookConfig
+	batchPredicate hookConfig
+	prioritize     hookConfig
+	preemptable    hookConfig
+	reclaimable    hookConfig
+
+	jobOrder    hookConfig
+	queueOrder  hookConfig
+	taskOrder   hookConfig
+	allocatable hookConfig
+	jobStarving hookConfig
+	victimTasks hookConfig
+	bestNode    hookConfig
+}
+
+// hookConfig is the configuration of a session hook with its own timeout and failure policy.
+type hookConfig struct {
+	verb      string
+	timeout   time.Duration
+	ignorable bool
 }
 
 type extenderPlugin struct {
-	client http.Client
-	config *extenderConfig
+	client     http.Client
+	grpcClient extenderpb.ExtenderClient
+	grpcErr    error
+	config     *extenderConfig
+
+	// batchResults are the nodes failing the batch predicate of the tasks indexed by task,
+	// they are read by the predicates running in parallel on nodes and reset when the node
+	// state changes
+	batchLock    sync.RWMutex
+	batchResults map[api.TaskID]map[string]*extenderpb.PredicateResponse
+
+	// orderRanks are the ranks of the objects ordered by each ordering verb in this session, they
+	// are fetched in one call the first time the ordering function is called
+	orderLock  sync.Mutex
+	orderRanks map[string]map[string]int
 }
 
 func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
@@ -100,26 +173,29 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 		       arguments:
 				   extender.urlPrefix: http://127.0.0.1
 				   extender.httpTimeout: 100ms
+				   extender.grpcAddress: 127.0.0.1:9090
 				   extender.onSessionOpenVerb: onSessionOpen
 				   extender.onSessionCloseVerb: onSessionClose
 				   extender.predicateVerb: predicate
+				   extender.batchPredicateVerb: batchPredicate
 				   extender.prioritizeVerb: prioritize
 				   extender.preemptableVerb: preemptable
 				   extender.reclaimableVerb: reclaimable
 				   extender.queueOverusedVerb: queueOverused
 				   extender.jobEnqueueableVerb: jobEnqueueable
+				   extender.jobOrderVerb: jobOrder
+				   extender.jobOrderTimeout: 50ms
+				   extender.allocatableVerb: allocatable
+				   extender.allocatableFailurePolicy: Fail
 				   extender.ignorable: true
 		     - name: proportion
 		     - name: nodeorder
 	*/
 	ec := &extenderConfig{}
 	ec.urlPrefix, _ = arguments[ExtenderURLPrefix].(string)
+	ec.grpcAddress, _ = arguments[ExtenderGRPCAddress].(string)
 	ec.onSessionOpenVerb, _ = arguments[ExtenderOnSessionOpenVerb].(string)
 	ec.onSessionCloseVerb, _ = arguments[ExtenderOnSessionCloseVerb].(string)
-	ec.predicateVerb, _ = arguments[ExtenderPredicateVerb].(string)
-	ec.prioritizeVerb, _ = arguments[ExtenderPrioritizeVerb].(string)
-	ec.preemptableVerb, _ = arguments[ExtenderPreemptableVerb].(string)
-	ec.reclaimableVerb, _ = arguments[ExtenderReclaimableVerb].(string)
 	ec.queueOverusedVerb, _ = arguments[ExtenderQueueOverusedVerb].(string)
 	ec.jobEnqueueableVerb, _ = arguments[ExtenderJobEnqueueableVerb].(string)
 	ec.jobReadyVerb, _ = arguments[ExtenderJobReadyVerb].(string)
@@ -133,13 +209,83 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 		}
 	}
 
+	for _, hook := range []struct {
+		config           *hookConfig
+		verbKey          string
+		hasFailurePolicy bool
+	}{
+		{&ec.predicate, ExtenderPredicateVerb, false},
+		{&ec.batchPredicate, ExtenderBatchPredicateVerb, false},
+		{&ec.prioritize, ExtenderPrioritizeVerb, false},
+		{&ec.preemptable, ExtenderPreemptableVerb, false},
+		{&ec.reclaimable, ExtenderReclaimableVerb, false},
+		{&ec.jobOrder, ExtenderJobOrderVerb, false},
+		{&ec.queueOrder, ExtenderQueueOrderVerb, false},
+		{&ec.taskOrder, ExtenderTaskOrderVerb, false},
+		{&ec.allocatable, ExtenderAllocatableVerb, true},
+		{&ec.jobStarving, ExtenderJobStarvingVerb, true},
+		{&ec.victimTasks, ExtenderVictimTasksVerb, false},
+		{&ec.bestNode, ExtenderBestNodeVerb, false},
+	} {
+		*hook.config = parseHookConfig(arguments, hook.verbKey, hook.hasFailurePolicy, ec)
+	}
+
 	return ec
 }
 
+// parseHookConfig parses the verb of a hook with its timeout and failure policy, which default
+// to extender.httpTimeout and extender.ignorable. The failure policy is rejected for the hooks
+// which have none.
+func parseHookConfig(arguments framework.Arguments, verbKey string, hasFailurePolicy bool, ec *extenderConfig) hookConfig {
+	hc := hookConfig{timeout: ec.httpTimeout, ignorable: ec.ignorable}
+	hc.verb, _ = arguments[verbKey].(string)
+
+	name := strings.TrimSuffix(verbKey, "Verb")
+	if timeout, _ := arguments[name+hookTimeoutSuffix].(string); timeout != "" {
+		if timeoutDuration, err := time.ParseDuration(timeout); err == nil {
+			hc.timeout = timeoutDuration
+		} else {
+			klog.Warningf("Invalid %s %q of extender: %v", name+hookTimeoutSuffix, timeout, err)
+		}
+	}
+	policy, _ := arguments[name+hookFailurePolicySuffix].(string)
+	if !hasFailurePolicy {
+		if policy != FailurePolicyNone {
+			klog.Errorf("Invalid %s %q of extender, the hook never changes the scheduling decisions when it fails "+
+				"and has no failure policy", name+hookFailurePolicySuffix, policy)
+		}
+		return hc
+	}
+	switch policy {
+	case FailurePolicyNone:
+	case FailurePolicyIgnore:
+		hc.ignorable = true
+	case FailurePolicyFail:
+		hc.ignorable = false
+	default:
+		klog.Warningf("Invalid %s %q of extender, it must be %s or %s",
+			name+hookFailurePolicySuffix, policy, FailurePolicyIgnore, FailurePolicyFail)
+	}
+	return hc
+}
+
 func New(arguments framework.Arguments) framework.Plugin {
 	cfg := parseExtenderConfig(arguments)
 	klog.V(4).Infof("Initialize extender plugin with endpoint address %s", cfg.urlPrefix)
-	return &extenderPlugin{client: http.Client{Timeout: cfg.httpTimeout}, config: cfg}
+	ep := &extenderPlugin{
+		client:       http.Client{Timeout: cfg.httpTimeout},
+		config:       cfg,
+		batchResults: map[api.TaskID]map[string]*extenderpb.PredicateResponse{},
+		orderRanks:   map[string]map[string]int{},
+	}
+	if cfg.grpcAddress != "" {
+		klog.V(4).Infof("Initialize extender plugin with gRPC address %s", cfg.grpcAddress)
+		ep.grpcClient, ep.grpcErr = newGRPCClient(cfg.grpcAddress)
+		if ep.grpcErr != nil {
+			klog.Errorf("Failed to create gRPC client of extender: %v", ep.grpcErr)
+		}
+	}
+	return ep
 }
 
 func (ep *extenderPlugin) Name() string {
@@ -163,10 +309,41 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		}
 	}
 
-	if ep.config.predicateVerb != "" {
+	if ep.config.batchPredicate.verb != "" {
+		ssn.AddPrePredicateFn(ep.Name(), func(task *api.TaskInfo) error {
+			failedNodes, err := ep.batchPredicate(task, ssn.NodeList)
+			if err != nil {
+				klog.Warningf("BatchPredicate failed with error %v", err)
+
+				if !ep.config.ignorable {
+					return err
+				}
+				// let all nodes pass instead of calling the extender again for each node
+				failedNodes = nil
+			}
+
+			ep.batchLock.Lock()
+			ep.batchResults[task.UID] = failedNodes
+			ep.batchLock.Unlock()
+			return nil
+		})
+
+		// the results of the batch predicate are only valid for the node state they were
+		// computed on, so they are dropped whenever a task is allocated or deallocated
+		resetBatchResults := func(event *framework.Event) {
+			ep.batchLock.Lock()
+			ep.batchResults = map[api.TaskID]map[string]*extenderpb.PredicateResponse{}
+			ep.batchLock.Unlock()
+		}
+		ssn.AddEventHandler(&framework.EventHandler{
+			AllocateFunc:   resetBatchResults,
+			DeallocateFunc: resetBatchResults,
+		})
+	}
+
+	if ep.config.predicate.verb != "" || ep.config.batchPredicate.verb != "" {
 		ssn.AddPredicateFn(ep.Name(), func(task *api.TaskInfo, node *api.NodeInfo) error {
-			resp := &PredicateResponse{}
-			err := ep.send(ep.config.predicateVerb, &PredicateRequest{Task: task, Node: node}, resp)
+			resp, err := ep.predicate(task, node)
 			if err != nil {
 				klog.Warningf("Predicate failed with error %v", err)
 
@@ -188,10 +365,9 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		})
 	}
 
-	if ep.config.prioritizeVerb != "" {
+	if ep.config.prioritize.verb != "" {
 		ssn.AddBatchNodeOrderFn(ep.Name(), func(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]float64, error) {
-			resp := &PrioritizeResponse{}
-			err := ep.send(ep.config.prioritizeVerb, &PrioritizeRequest{Task: task, Nodes: nodes}, resp)
+			resp, err := ep.prioritize(task, nodes)
 			if err != nil {
 				klog.Warningf("Prioritize failed with error %v", err)
 
@@ -208,10 +384,9 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		})
 	}
 
-	if ep.config.preemptableVerb != "" {
+	if ep.config.preemptable.verb != "" {
 		ssn.AddPreemptableFn(ep.Name(), func(evictor *api.TaskInfo, evictees []*api.TaskInfo) ([]*api.TaskInfo, int) {
-			resp := &PreemptableResponse{}
-			err := ep.send(ep.config.preemptableVerb, &PreemptableRequest{Evictor: evictor, Evictees: evictees}, resp)
+			resp, err := ep.evictable(false, evictor, evictees)
 			if err != nil {
 				klog.Warningf("Preemptable failed with error %v", err)
 
@@ -225,10 +400,9 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		})
 	}
 
-	if ep.config.reclaimableVerb != "" {
+	if ep.config.reclaimable.verb != "" {
 		ssn.AddReclaimableFn(ep.Name(), func(evictor *api.TaskInfo, evictees []*api.TaskInfo) ([]*api.TaskInfo, int) {
-			resp := &ReclaimableResponse{}
-			err := ep.send(ep.config.reclaimableVerb, &ReclaimableRequest{Evictor: evictor, Evictees: evictees}, resp)
+			resp, err := ep.evictable(true, evictor, evictees)
 			if err != nil {
 				klog.Warningf("Reclaimable failed with error %v", err)
 
@@ -289,6 +463,162 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 			return resp.Status
 		})
 	}
+
+	ep.addHookFns(ssn)
+}
+
+// addHookFns registers the ordering, allocatable, starving, victim and best node hooks served
+// by the extender.
+func (ep *extenderPlugin) addHookFns(ssn *framework.Session) {
+	if ep.config.jobOrder.verb != "" {
+		ssn.AddJobOrderFn(ep.Name(), func(l, r interface{}) int {
+			lv, rv := l.(*api.JobInfo), r.(*api.JobInfo)
+			ranks := ep.orderRanksOf(ep.config.jobOrder, func() interface{} {
+				req := &JobOrderRequest{}
+				for _, job := range ssn.Jobs {
+					req.Jobs = append(req.Jobs, job)
+				}
+				return req
+			})
+			return compareRanks(ranks, string(lv.UID), string(rv.UID))
+		})
+	}
+
+	if ep.config.queueOrder.verb != "" {
+		ssn.AddQueueOrderFn(ep.Name(), func(l, r interface{}) int {
+			lv, rv := l.(*api.QueueInfo), r.(*api.QueueInfo)
+			ranks := ep.orderRanksOf(ep.config.queueOrder, func() interface{} {
+				req := &QueueOrderRequest{}
+				for _, queue := range ssn.Queues {
+					req.Queues = append(req.Queues, queue)
+				}
+				return req
+			})
+			return compareRanks(ranks, string(lv.UID), string(rv.UID))
+		})
+	}
+
+	if ep.config.taskOrder.verb != "" {
+		ssn.AddTaskOrderFn(ep.Name(), func(l, r interface{}) int {
+			lv, rv := l.(*api.TaskInfo), r.(*api.TaskInfo)
+			ranks := ep.orderRanksOf(ep.config.taskOrder, func() interface{} {
+				req := &TaskOrderRequest{}
+				for _, job := range ssn.Jobs {
+					for _, task := range job.Tasks {
+						req.Tasks = append(req.Tasks, task)
+					}
+				}
+				return req
+			})
+			return compareRanks(ranks, string(lv.UID), string(rv.UID))
+		})
+	}
+
+	if ep.config.allocatable.verb != "" {
+		ssn.AddAllocatableFn(ep.Name(), func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
+			resp := &AllocatableResponse{}
+			err := ep.sendHook(ep.config.allocatable, &AllocatableRequest{Queue: queue, Candidate: candidate}, resp)
+			if err != nil {
+				klog.Warningf("Allocatable failed with error %v", err)
+
+				return ep.config.allocatable.ignorable
+			}
+
+			return resp.Allocatable
+		})
+	}
+
+	if ep.config.jobStarving.verb != "" {
+		ssn.AddJobStarvingFns(ep.Name(), func(obj interface{}) bool {
+			job := obj.(*api.JobInfo)
+			resp := &JobStarvingResponse{}
+			err := ep.sendHook(ep.config.jobStarving, &JobStarvingRequest{Job: job}, resp)
+			if err != nil {
+				klog.Warningf("JobStarving failed with error %v", err)
+
+				// the job is starving only if all plugins agree, so ignoring the failure must not veto it
+				return ep.config.jobStarving.ignorable
+			}
+
+			return resp.Starving
+		})
+	}
+
+	if ep.config.victimTasks.verb != "" {
+		ssn.AddVictimTasksFns(ep.Name(), []api.VictimTasksFn{func(tasks []*api.TaskInfo) []*api.TaskInfo {
+			resp := &VictimTasksResponse{}
+			err := ep.sendHook(ep.config.victimTasks, &VictimTasksRequest{Tasks: tasks}, resp)
+			if err != nil {
+				// no task is evicted when the call fails
+				klog.Warningf("VictimTasks failed with error %v", err)
+				return nil
+			}
+
+			return victimsOf(tasks, resp.Victims)
+		}})
+	}
+
+	if ep.config.bestNode.verb != "" {
+		ssn.AddBestNodeFn(ep.Name(), func(task *api.TaskInfo, nodeScores map[float64][]*api.NodeInfo) *api.NodeInfo {
+			req := &BestNodeRequest{Task: task, NodeScores: map[string]float64{}}
+			nodes := map[string]*api.NodeInfo{}
+			for score, scoredNodes := range nodeScores {
+				for _, node := range scoredNodes {
+					req.NodeScores[node.Name] = score
+					nodes[node.Name] = node
+				}
+			}
+
+			resp := &BestNodeResponse{}
+			if err := ep.sendHook(ep.config.bestNode, req, resp); err != nil {
+				// the scheduler chooses the node with the highest score when no plugin chooses one
+				klog.Warningf("BestNode failed with error %v", err)
+				return nil
+			}
+			if resp.Node == "" {
+				return nil
+			}
+			node, found := nodes[resp.Node]
+			if !found {
+				klog.Warningf("BestNode returned node %s which is not a candidate of task <%s/%s>", resp.Node, task.Namespace, task.Name)
+			}
+			return node
+		})
+	}
+}
+
+// orderRanksOf returns the ranks of the objects ordered by the hook, indexed by uid. The
+// extender orders all the objects of the session in one call the first time, and the ranks
+// are kept for the session. No object is ranked when the call fails.
+func (ep *extenderPlugin) orderRanksOf(hook hookConfig, newRequest func() interface{}) map[string]int {
+	ep.orderLock.Lock()
+	defer ep.orderLock.Unlock()
+	if ranks, found := ep.orderRanks[hook.verb]; found {
+		return ranks
+	}
+
+	ranks := map[string]int{}
+	resp := &OrderResponse{}
+	if err := ep.sendHook(hook, newRequest(), resp); err != nil {
+		klog.Warningf("Calling %s failed with error %v", hook.verb, err)
+	} else {
+		for rank, uid := range resp.Order {
+			ranks[uid] = rank
+		}
+	}
+	ep.orderRanks[hook.verb] = ranks
+	return ranks
+}
+
+// compareRanks compares l and r by their ranks, the extender has no opinion if one of them is
+// not ranked.
+func compareRanks(ranks map[string]int, l, r string) int {
+	lRank, lFound := ranks[l]
+	rRank, rFound := ranks[r]
+	if !lFound || !rFound {
+		return 0
+	}
+	return lRank - rRank
 }
 
 func (ep *extenderPlugin) OnSessionClose(ssn *framework.Session) {
@@ -299,8 +629,109 @@ func (ep *extenderPlugin) OnSessionClose(ssn *framework.Session) {
 	}
 }
 
+// predicate checks whether the node fits the task, using the result of the batch predicate of
+// the task if any.
+func (ep *extenderPlugin) predicate(task *api.TaskInfo, node *api.NodeInfo) (*PredicateResponse, error) {
+	if ep.config.batchPredicate.verb != "" {
+		ep.batchLock.RLock()
+		failedNodes, found := ep.batchResults[task.UID]
+		ep.batchLock.RUnlock()
+
+		// the pre-predicates are not checked by every caller of the predicates
+		if !found {
+			var err error
+			if failedNodes, err = ep.batchPredicate(task, []*api.NodeInfo{node}); err != nil {
+				return nil, err
+			}
+		}
+		if resp, failed := failedNodes[node.Name]; failed {
+			return &PredicateResponse{ErrorMessage: resp.ErrorMessage, Code: int(resp.Code)}, nil
+		}
+		return &PredicateResponse{}, nil
+	}
+
+	if ep.config.grpcAddress != "" {
+		if ep.grpcClient == nil {
+			return nil, ep.grpcErr
+		}
+		return ep.grpcPredicate(task, node)
+	}
+
+	resp := &PredicateResponse{}
+	err := ep.sendHook(ep.config.predicate, &extenderpb.PredicateRequest{Task: newWireTask(task), Node: newWireNode(node)}, resp)
+	return resp, err
+}
+
+// batchPredicate filters all the nodes for the task in one call and returns the failed nodes.
+func (ep *extenderPlugin) batchPredicate(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]*extenderpb.PredicateResponse, error) {
+	req := &extenderpb.BatchPredicateRequest{Task: newWireTask(task), Nodes: newWireNodes(nodes)}
+	resp := &extenderpb.BatchPredicateResponse{}
+	if ep.config.grpcAddress != "" {
+		if ep.grpcClient == nil {
+			return nil, ep.grpcErr
+		}
+		var err error
+		if resp, err = ep.grpcBatchPredicate(req); err != nil {
+			return nil, err
+		}
+	} else if err := ep.sendHook(ep.config.batchPredicate, req, resp); err != nil {
+		return nil, err
+	}
+	return resp.FailedNodes, nil
+}
+
+func (ep *extenderPlugin) prioritize(task *api.TaskInfo, nodes []*api.NodeInfo) (*PrioritizeResponse, error) {
+	if ep.config.grpcAddress != "" {
+		if ep.grpcClient == nil {
+			return nil, ep.grpcErr
+		}
+		return ep.grpcPrioritize(task, nodes)
+	}
+
+	resp := &PrioritizeResponse{}
+	err := ep.sendHook(ep.config.prioritize, &extenderpb.PrioritizeRequest{Task: newWireTask(task), Nodes: newWireNodes(nodes)}, resp)
+	return resp, err
+}
+
+func (ep *extenderPlugin) evictable(reclaim bool, evictor *api.TaskInfo, evictees []*api.TaskInfo) (*PreemptableResponse, error) {
+	if ep.config.grpcAddress != "" {
+		if ep.grpcClient == nil {
+			return nil, ep.grpcErr
+		}
+		return ep.grpcEvictable(reclaim, evictor, evictees)
+	}
+
+	if reclaim {
+		resp := &ReclaimableResponse{}
+		err := ep.sendHook(ep.config.reclaimable, &ReclaimableRequest{Evictor: evictor, Evictees: evictees}, resp)
+		return (*PreemptableResponse)(resp), err
+	}
+	resp := &PreemptableResponse{}
+	err := ep.sendHook(ep.config.preemptable, &PreemptableRequest{Evictor: evictor, Evictees: evictees}, resp)
+	return resp, err
+}
+
+// sendHook sends the args to the verb of the hook with the timeout of the hook.
+func (ep *extenderPlugin) sendHook(hook hookConfig, args interface{}, result interface{}) error {
+	client := ep.client
+	client.Timeout = hook.timeout
+	return ep.sendWith(&client, hook.verb, args, result)
+}
+
 func (ep *extenderPlugin) send(action string, args interface{}, result interface{}) error {
-	out, err := json.Marshal(args)
+	return ep.sendWith(&ep.client, action, args, result)
+}
+
+// sendWith posts the args to the extender as json, the protobuf messages of the trimmed wire
+// representation are encoded with their canonical json mapping.
+func (ep *extenderPlugin) sendWith(client *http.Client, action string, args interface{}, result interface{}) error {
+	var out []byte
+	var err error
+	if msg, ok := args.(proto.Message); ok {
+		out, err = protojson.Marshal(msg)
+	} else {
+		out, err = json.Marshal(args)
+	}
 	if err != nil {
 		return err
 	}
@@ -314,7 +745,7 @@ func (ep *extenderPlugin) send(action string, args interface{}, result interface
 
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := ep.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return err
 	}
@@ -326,6 +757,13 @@ func (ep *extenderPlugin) send(action string, args interface{}, result interface
 
 	if result != nil {
 		resp.Body = http.MaxBytesReader(nil, resp.Body, maxBodySize)
+		if msg, ok := result.(proto.Message); ok {
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return err
+			}
+			return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, msg)
+		}
 		return json.NewDecoder(resp.Body).Decode(result)
 	}
 	return nil
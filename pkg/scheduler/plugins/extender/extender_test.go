@@ -17,12 +17,32 @@ limitations under the License.
 package extender
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/extender/extenderpb"
+	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
 func TestMaxBodySizeLimit2(t *testing.T) {
@@ -41,7 +61,7 @@ func TestMaxBodySizeLimit2(t *testing.T) {
 	}
 
 	var result map[string]interface{}
-	err := plugin.send("test", &PredicateRequest{Task: &api.TaskInfo{}, Node: &api.NodeInfo{}}, &result)
+	err := plugin.send("test", &extenderpb.PredicateRequest{Task: &extenderpb.Task{}, Node: &extenderpb.Node{}}, &result)
 
 	if err == nil {
 		t.Error("Expected error due to request body size limit, but got nil")
@@ -49,3 +69,400 @@ func TestMaxBodySizeLimit2(t *testing.T) {
 		t.Errorf("Expected 'http: request body too large' error, got: %v", err)
 	}
 }
+
+// fakeExtender fails the nodes labeled with "fail" and scores the nodes by their idle cpu.
+type fakeExtender struct {
+	extenderpb.UnimplementedExtenderServer
+}
+
+func (fe *fakeExtender) BatchPredicate(ctx context.Context, req *extenderpb.BatchPredicateRequest) (*extenderpb.BatchPredicateResponse, error) {
+	resp := &extenderpb.BatchPredicateResponse{FailedNodes: map[string]*extenderpb.PredicateResponse{}}
+	for _, node := range req.Nodes {
+		if _, found := node.Labels["fail"]; found {
+			resp.FailedNodes[node.Name] = &extenderpb.PredicateResponse{
+				Code:         int32(api.UnschedulableAndUnresolvable),
+				ErrorMessage: "node is labeled with fail",
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (fe *fakeExtender) Prioritize(ctx context.Context, req *extenderpb.PrioritizeRequest) (*extenderpb.PrioritizeResponse, error) {
+	resp := &extenderpb.PrioritizeResponse{NodeScore: map[string]float64{}}
+	for _, node := range req.Nodes {
+		resp.NodeScore[node.Name] = node.Idle.MilliCpu / 1000
+	}
+	return resp, nil
+}
+
+func (fe *fakeExtender) Preemptable(ctx context.Context, req *extenderpb.PreemptableRequest) (*extenderpb.PreemptableResponse, error) {
+	resp := &extenderpb.PreemptableResponse{Status: 1}
+	for _, evictee := range req.Evictees {
+		if evictee.Priority < req.Evictor.Priority {
+			resp.Victims = append(resp.Victims, evictee.Uid)
+		}
+	}
+	return resp, nil
+}
+
+func buildNodes() []*api.NodeInfo {
+	return []*api.NodeInfo{
+		api.NewNodeInfo(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), nil)),
+		api.NewNodeInfo(util.BuildNode("n2", api.BuildResourceList("2", "8Gi"), map[string]string{"fail": ""})),
+	}
+}
+
+func buildTask(name string, priority int32) *api.TaskInfo {
+	task := api.NewTaskInfo(util.BuildPod("c1", name, "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	task.Priority = priority
+	return task
+}
+
+func TestGRPCExtender(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	extenderpb.RegisterExtenderServer(server, &fakeExtender{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	ep := New(framework.Arguments{
+		ExtenderGRPCAddress:        lis.Addr().String(),
+		ExtenderBatchPredicateVerb: "batchPredicate",
+		ExtenderPrioritizeVerb:     "prioritize",
+		ExtenderPreemptableVerb:    "preemptable",
+	}).(*extenderPlugin)
+
+	nodes := buildNodes()
+	task := buildTask("p1", 1)
+	failedNodes, err := ep.batchPredicate(task, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"n2"}, keys(failedNodes))
+
+	// the batch result is used by the predicates instead of calling the extender for each node
+	ep.batchResults[task.UID] = failedNodes
+	for _, node := range nodes {
+		resp, err := ep.predicate(task, node)
+		assert.NoError(t, err)
+		if node.Name == "n2" {
+			assert.Equal(t, &PredicateResponse{ErrorMessage: "node is labeled with fail", Code: api.UnschedulableAndUnresolvable}, resp)
+		} else {
+			assert.Equal(t, &PredicateResponse{}, resp)
+		}
+	}
+
+	prioritized, err := ep.prioritize(task, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"n1": 4, "n2": 2}, prioritized.NodeScore)
+
+	low, high := buildTask("low", 0), buildTask("high", 2)
+	preemptable, err := ep.evictable(false, task, []*api.TaskInfo{low, high})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, preemptable.Status)
+	assert.Equal(t, []*api.TaskInfo{low}, preemptable.Victims)
+
+	_, err = ep.evictable(true, task, []*api.TaskInfo{low, high})
+	assert.Error(t, err, "reclaimable is not implemented by the fake extender")
+}
+
+func TestHTTPBatchPredicate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/batchPredicate", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := &extenderpb.BatchPredicateRequest{}
+		assert.NoError(t, protojson.Unmarshal(body, req))
+
+		resp, err := (&fakeExtender{}).BatchPredicate(r.Context(), req)
+		assert.NoError(t, err)
+		out, err := protojson.Marshal(resp)
+		assert.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(out)
+	}))
+	defer server.Close()
+
+	ep := New(framework.Arguments{
+		ExtenderURLPrefix:          server.URL,
+		ExtenderBatchPredicateVerb: "batchPredicate",
+	}).(*extenderPlugin)
+
+	// without a batch result the predicate filters the node alone
+	task := buildTask("p1", 1)
+	for _, node := range buildNodes() {
+		resp, err := ep.predicate(task, node)
+		assert.NoError(t, err)
+		assert.Equal(t, node.Name == "n2", resp.ErrorMessage != "")
+	}
+}
+
+func TestHTTPPredicateAndPrioritize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var resp interface{}
+		// the requests carry the trimmed tasks and nodes, the whole scheduler objects fail to decode
+		switch r.URL.Path {
+		case "/predicate":
+			req := &extenderpb.PredicateRequest{}
+			assert.NoError(t, protojson.Unmarshal(body, req))
+			predicateResp := &PredicateResponse{}
+			if _, found := req.Node.Labels["fail"]; found {
+				predicateResp = &PredicateResponse{ErrorMessage: "node is labeled with fail", Code: api.UnschedulableAndUnresolvable}
+			}
+			resp = predicateResp
+		case "/prioritize":
+			req := &extenderpb.PrioritizeRequest{}
+			assert.NoError(t, protojson.Unmarshal(body, req))
+			pbResp, err := (&fakeExtender{}).Prioritize(r.Context(), req)
+			assert.NoError(t, err)
+			resp = &PrioritizeResponse{NodeScore: pbResp.NodeScore}
+		}
+		out, err := json.Marshal(resp)
+		assert.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(out)
+	}))
+	defer server.Close()
+
+	ep := New(framework.Arguments{
+		ExtenderURLPrefix:      server.URL,
+		ExtenderPredicateVerb:  "predicate",
+		ExtenderPrioritizeVerb: "prioritize",
+	}).(*extenderPlugin)
+
+	task := buildTask("p1", 1)
+	nodes := buildNodes()
+	for _, node := range nodes {
+		resp, err := ep.predicate(task, node)
+		assert.NoError(t, err)
+		assert.Equal(t, node.Name == "n2", resp.ErrorMessage != "")
+	}
+	prioritized, err := ep.prioritize(task, nodes)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"n1": 4, "n2": 2}, prioritized.NodeScore)
+}
+
+// slowExtender prioritizes the nodes once the call is canceled.
+type slowExtender struct {
+	fakeExtender
+}
+
+func (se *slowExtender) Prioritize(ctx context.Context, req *extenderpb.PrioritizeRequest) (*extenderpb.PrioritizeResponse, error) {
+	<-ctx.Done()
+	return se.fakeExtender.Prioritize(ctx, req)
+}
+
+func TestGRPCHookTimeout(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	extenderpb.RegisterExtenderServer(server, &slowExtender{})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	ep := New(framework.Arguments{
+		ExtenderGRPCAddress:          lis.Addr().String(),
+		ExtenderHTTPTimeout:          "10s",
+		ExtenderPrioritizeVerb:       "prioritize",
+		"extender.prioritizeTimeout": "100ms",
+	}).(*extenderPlugin)
+
+	start := time.Now()
+	_, err = ep.prioritize(buildTask("p1", 1), buildNodes())
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 5*time.Second, "the timeout of the prioritize hook is used instead of extender.httpTimeout")
+}
+
+func TestBatchPredicateResetOnAllocate(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		out, err := protojson.Marshal(&extenderpb.BatchPredicateResponse{})
+		assert.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(out)
+	}))
+	defer server.Close()
+
+	framework.RegisterPluginBuilder(PluginName, New)
+	defer framework.CleanupPluginBuilders()
+
+	schedulerCache := cache.NewDefaultMockSchedulerCache("volcano")
+	schedulerCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	schedulerCache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), nil))
+	schedulerCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue))
+	schedulerCache.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+
+	trueValue := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{
+		Name:             PluginName,
+		EnabledPredicate: &trueValue,
+		Arguments: framework.Arguments{
+			ExtenderURLPrefix:          server.URL,
+			ExtenderBatchPredicateVerb: "batchPredicate",
+		},
+	}}}}
+	ssn := framework.OpenSession(schedulerCache, tiers, nil)
+	defer framework.CloseSession(ssn)
+
+	var task *api.TaskInfo
+	for _, t := range ssn.Jobs["c1/pg1"].Tasks {
+		task = t
+	}
+	node := ssn.Nodes["n1"]
+	assert.NoError(t, ssn.PrePredicateFn(task))
+	assert.NoError(t, ssn.PredicateFn(task, node))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "the predicate uses the batch result")
+
+	// the allocation changes the node state, the batch result is dropped
+	assert.NoError(t, framework.NewStatement(ssn).Allocate(task, node))
+	assert.NoError(t, ssn.PredicateFn(task, node))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "the predicate calls the extender again")
+}
+
+func TestSessionHooks(t *testing.T) {
+	var jobOrderCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch r.URL.Path {
+		case "/jobOrder":
+			atomic.AddInt32(&jobOrderCalls, 1)
+			req := &JobOrderRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			// order the jobs by name in reverse
+			sort.Slice(req.Jobs, func(i, j int) bool { return req.Jobs[i].Name > req.Jobs[j].Name })
+			order := &OrderResponse{}
+			for _, job := range req.Jobs {
+				order.Order = append(order.Order, string(job.UID))
+			}
+			resp = order
+		case "/allocatable":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		case "/jobStarving":
+			time.Sleep(200 * time.Millisecond)
+			resp = &JobStarvingResponse{Starving: true}
+		case "/victimTasks":
+			req := &VictimTasksRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			resp = &VictimTasksResponse{Victims: []string{string(req.Tasks[0].UID)}}
+		case "/bestNode":
+			req := &BestNodeRequest{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			// choose the node with the lowest score
+			best := ""
+			for node, score := range req.NodeScores {
+				if best == "" || score < req.NodeScores[best] {
+					best = node
+				}
+			}
+			resp = &BestNodeResponse{Node: best}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name              string
+		allocatablePolicy string
+		wantAllocatable   bool
+	}{
+		{
+			name:              "allocatable fails closed",
+			allocatablePolicy: FailurePolicyFail,
+			wantAllocatable:   false,
+		},
+		{
+			name:              "allocatable failure is ignored",
+			allocatablePolicy: FailurePolicyIgnore,
+			wantAllocatable:   true,
+		},
+	}
+
+	framework.RegisterPluginBuilder(PluginName, New)
+	defer framework.CleanupPluginBuilders()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			atomic.StoreInt32(&jobOrderCalls, 0)
+			schedulerCache := cache.NewDefaultMockSchedulerCache("volcano")
+			schedulerCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+			for _, name := range []string{"pg1", "pg2"} {
+				schedulerCache.AddPodGroupV1beta1(util.BuildPodGroup(name, "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue))
+				schedulerCache.AddPod(util.BuildPod("c1", name+"-p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), name, nil, nil))
+			}
+
+			trueValue := true
+			tiers := []conf.Tier{{Plugins: []conf.PluginOption{{
+				Name:               PluginName,
+				EnabledJobOrder:    &trueValue,
+				EnabledAllocatable: &trueValue,
+				EnabledJobStarving: &trueValue,
+				EnabledVictim:      &trueValue,
+				EnabledBestNode:    &trueValue,
+				Arguments: framework.Arguments{
+					ExtenderURLPrefix:                   server.URL,
+					ExtenderJobOrderVerb:                "jobOrder",
+					ExtenderAllocatableVerb:             "allocatable",
+					"extender.allocatableFailurePolicy": test.allocatablePolicy,
+					ExtenderJobStarvingVerb:             "jobStarving",
+					"extender.jobStarvingTimeout":       "20ms",
+					"extender.jobStarvingFailurePolicy": FailurePolicyFail,
+					ExtenderVictimTasksVerb:             "victimTasks",
+					ExtenderBestNodeVerb:                "bestNode",
+				},
+			}}}}
+			ssn := framework.OpenSession(schedulerCache, tiers, nil)
+			defer framework.CloseSession(ssn)
+
+			pg1, pg2 := ssn.Jobs["c1/pg1"], ssn.Jobs["c1/pg2"]
+			assert.True(t, ssn.JobOrderFn(pg2, pg1))
+			assert.False(t, ssn.JobOrderFn(pg1, pg2))
+			assert.Equal(t, int32(1), atomic.LoadInt32(&jobOrderCalls), "the jobs are ordered in one call for the session")
+
+			var task *api.TaskInfo
+			for _, t := range pg1.Tasks {
+				task = t
+			}
+			assert.Equal(t, test.wantAllocatable, ssn.Allocatable(ssn.Queues["q1"], task))
+			assert.False(t, ssn.JobStarving(pg1), "the call times out and fails closed")
+
+			var tasks []*api.TaskInfo
+			for _, job := range []*api.JobInfo{pg1, pg2} {
+				for _, t := range job.Tasks {
+					tasks = append(tasks, t)
+				}
+			}
+			assert.Equal(t, map[*api.TaskInfo]bool{tasks[0]: true}, ssn.VictimTasks(tasks))
+
+			nodes := buildNodes()
+			assert.Equal(t, nodes[1], ssn.BestNodeFn(task, map[float64][]*api.NodeInfo{10: {nodes[0]}, 5: {nodes[1]}}))
+		})
+	}
+}
+
+func keys[V any](m map[string]V) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestParseHookFailurePolicy(t *testing.T) {
+	cfg := parseExtenderConfig(framework.Arguments{
+		ExtenderIgnorable:                   true,
+		ExtenderAllocatableVerb:             "allocatable",
+		"extender.allocatableFailurePolicy": FailurePolicyFail,
+		ExtenderJobOrderVerb:                "jobOrder",
+		"extender.jobOrderFailurePolicy":    FailurePolicyFail,
+	})
+	assert.False(t, cfg.allocatable.ignorable, "the failure policy of the allocatable hook is honored")
+	assert.True(t, cfg.jobOrder.ignorable, "the ordering hooks have no failure policy, it is rejected")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/extender/extenderpb"
+)
+
+var (
+	grpcConnsLock sync.Mutex
+	// grpcConns are the connections to the extenders indexed by address, they are shared by
+	// all sessions because the plugin is built again in every session.
+	grpcConns = map[string]*grpc.ClientConn{}
+)
+
+// newGRPCClient returns a client of the extender served at address, reusing the connection
+// opened by a previous session if any.
+func newGRPCClient(address string) (extenderpb.ExtenderClient, error) {
+	grpcConnsLock.Lock()
+	defer grpcConnsLock.Unlock()
+
+	conn, found := grpcConns[address]
+	if !found {
+		var err error
+		// the connection is established in the background, Dial only fails on an invalid address
+		conn, err = grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial extender at %s: %v", address, err)
+		}
+		grpcConns[address] = conn
+	}
+	return extenderpb.NewExtenderClient(conn), nil
+}
+
+func (ep *extenderPlugin) grpcPredicate(task *api.TaskInfo, node *api.NodeInfo) (*PredicateResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ep.config.predicate.timeout)
+	defer cancel()
+
+	resp, err := ep.grpcClient.Predicate(ctx, &extenderpb.PredicateRequest{Task: newWireTask(task), Node: newWireNode(node)})
+	if err != nil {
+		return nil, err
+	}
+	return &PredicateResponse{ErrorMessage: resp.ErrorMessage, Code: int(resp.Code)}, nil
+}
+
+func (ep *extenderPlugin) grpcBatchPredicate(req *extenderpb.BatchPredicateRequest) (*extenderpb.BatchPredicateResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ep.config.batchPredicate.timeout)
+	defer cancel()
+
+	return ep.grpcClient.BatchPredicate(ctx, req)
+}
+
+func (ep *extenderPlugin) grpcPrioritize(task *api.TaskInfo, nodes []*api.NodeInfo) (*PrioritizeResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), ep.config.prioritize.timeout)
+	defer cancel()
+
+	resp, err := ep.grpcClient.Prioritize(ctx, &extenderpb.PrioritizeRequest{Task: newWireTask(task), Nodes: newWireNodes(nodes)})
+	if err != nil {
+		return nil, err
+	}
+	return &PrioritizeResponse{NodeScore: resp.NodeScore, ErrorMessage: resp.ErrorMessage}, nil
+}
+
+func (ep *extenderPlugin) grpcEvictable(reclaim bool, evictor *api.TaskInfo, evictees []*api.TaskInfo) (*PreemptableResponse, error) {
+	call, timeout := ep.grpcClient.Preemptable, ep.config.preemptable.timeout
+	if reclaim {
+		call, timeout = ep.grpcClient.Reclaimable, ep.config.reclaimable.timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := call(ctx, &extenderpb.PreemptableRequest{Evictor: newWireTask(evictor), Evictees: newWireTasks(evictees)})
+	if err != nil {
+		return nil, err
+	}
+	return &PreemptableResponse{Status: int(resp.Status), Victims: victimsOf(evictees, resp.Victims)}, nil
+}
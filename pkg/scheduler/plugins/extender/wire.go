@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/extender/extenderpb"
+)
+
+// newWireResource trims a resource to what is sent to the extender.
+func newWireResource(r *api.Resource) *extenderpb.Resource {
+	if r == nil {
+		return nil
+	}
+	res := &extenderpb.Resource{
+		MilliCpu: r.MilliCPU,
+		Memory:   r.Memory,
+	}
+	if len(r.ScalarResources) != 0 {
+		res.ScalarResources = make(map[string]float64, len(r.ScalarResources))
+		for name, quantity := range r.ScalarResources {
+			res.ScalarResources[string(name)] = quantity
+		}
+	}
+	return res
+}
+
+// newWireTask trims a task to what is sent to the extender instead of the whole api.TaskInfo.
+func newWireTask(task *api.TaskInfo) *extenderpb.Task {
+	t := &extenderpb.Task{
+		Uid:       string(task.UID),
+		Namespace: task.Namespace,
+		Name:      task.Name,
+		Job:       string(task.Job),
+		NodeName:  task.NodeName,
+		Status:    task.Status.String(),
+		Priority:  task.Priority,
+		Resreq:    newWireResource(task.Resreq),
+	}
+	if task.Pod != nil {
+		t.Labels = task.Pod.Labels
+		t.Annotations = task.Pod.Annotations
+	}
+	return t
+}
+
+func newWireTasks(tasks []*api.TaskInfo) []*extenderpb.Task {
+	wireTasks := make([]*extenderpb.Task, 0, len(tasks))
+	for _, task := range tasks {
+		wireTasks = append(wireTasks, newWireTask(task))
+	}
+	return wireTasks
+}
+
+// newWireNode trims a node to what is sent to the extender instead of the whole api.NodeInfo,
+// the tasks on the node are only referenced by uid.
+func newWireNode(node *api.NodeInfo) *extenderpb.Node {
+	n := &extenderpb.Node{
+		Name:        node.Name,
+		Allocatable: newWireResource(node.Allocatable),
+		Idle:        newWireResource(node.Idle),
+		Used:        newWireResource(node.Used),
+		FutureIdle:  newWireResource(node.FutureIdle()),
+		TaskUids:    make([]string, 0, len(node.Tasks)),
+	}
+	if node.Node != nil {
+		n.Labels = node.Node.Labels
+	}
+	for uid := range node.Tasks {
+		n.TaskUids = append(n.TaskUids, string(uid))
+	}
+	return n
+}
+
+func newWireNodes(nodes []*api.NodeInfo) []*extenderpb.Node {
+	wireNodes := make([]*extenderpb.Node, 0, len(nodes))
+	for _, node := range nodes {
+		wireNodes = append(wireNodes, newWireNode(node))
+	}
+	return wireNodes
+}
+
+// victimsOf returns the evictees whose uid is in the victims returned by the extender.
+func victimsOf(evictees []*api.TaskInfo, victims []string) []*api.TaskInfo {
+	uids := make(map[string]struct{}, len(victims))
+	for _, uid := range victims {
+		uids[uid] = struct{}{}
+	}
+	var tasks []*api.TaskInfo
+	for _, evictee := range evictees {
+		if _, found := uids[string(evictee.UID)]; found {
+			tasks = append(tasks, evictee)
+		}
+	}
+	return tasks
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "fairshare"
+
+	// HalfLifeKey is the argument of the half-life of the historical usage.
+	HalfLifeKey = "fairshare.halfLife"
+	// PersistIntervalKey is the argument of how often the usage is persisted.
+	PersistIntervalKey = "fairshare.persistInterval"
+	// NamespaceKey is the argument of the namespace of the ConfigMap the usage is persisted in.
+	NamespaceKey = "fairshare.namespace"
+	// UserKey is the argument of the label or annotation of the podgroups identifying the user of the jobs.
+	UserKey = "fairshare.userKey"
+
+	defaultHalfLife        = 7 * 24 * time.Hour
+	defaultPersistInterval = 5 * time.Minute
+	defaultNamespace       = "volcano-system"
+	defaultUserKey         = "volcano.sh/user"
+)
+
+/*
+   actions: "enqueue, allocate, backfill"
+   tiers:
+   - plugins:
+     - name: fairshare
+       arguments:
+         fairshare.halfLife: 168h
+         fairshare.persistInterval: 5m
+         fairshare.namespace: volcano-system
+         fairshare.userKey: volcano.sh/user
+*/
+
+// timeNow is the clock of the usage accounting, it is replaced in tests
+var timeNow = time.Now
+
+// fairShare is the fair-share of an entity in a session.
+type fairShare struct {
+	Usage  float64
+	Share  float64
+	Factor float64
+}
+
+type fairSharePlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+
+	halfLife        time.Duration
+	persistInterval time.Duration
+	namespace       string
+	userKey         string
+
+	queues     map[api.QueueID]*fairShare
+	namespaces map[string]*fairShare
+	users      map[string]*fairShare
+}
+
+// New return fairshare plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	fp := &fairSharePlugin{
+		pluginArguments: arguments,
+		halfLife:        parseDuration(arguments, HalfLifeKey, defaultHalfLife),
+		persistInterval: parseDuration(arguments, PersistIntervalKey, defaultPersistInterval),
+		namespace:       defaultNamespace,
+		userKey:         defaultUserKey,
+	}
+	if value, ok := arguments[NamespaceKey].(string); ok && value != "" {
+		fp.namespace = value
+	}
+	if value, ok := arguments[UserKey].(string); ok && value != "" {
+		fp.userKey = value
+	}
+	return fp
+}
+
+func parseDuration(arguments framework.Arguments, key string, defaultValue time.Duration) time.Duration {
+	value, found := arguments[key]
+	if !found {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(fmt.Sprint(value))
+	if err != nil || duration <= 0 {
+		klog.Warningf("Invalid %s %v of fairshare plugin, use %v", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+func (fp *fairSharePlugin) Name() string {
+	return PluginName
+}
+
+// jobUser returns the user of the job by the label or annotation of its podgroup.
+func (fp *fairSharePlugin) jobUser(job *api.JobInfo) string {
+	if job.PodGroup == nil {
+		return ""
+	}
+	if user, found := job.PodGroup.Labels[fp.userKey]; found {
+		return user
+	}
+	return job.PodGroup.Annotations[fp.userKey]
+}
+
+func (fp *fairSharePlugin) OnSessionOpen(ssn *framework.Session) {
+	klog.V(5).Infof("Enter fairshare plugin ...")
+	defer klog.V(5).Infof("Leaving fairshare plugin ...")
+
+	var allocations []allocation
+	namespaces := map[string]struct{}{}
+	users := map[string]struct{}{}
+	for _, job := range ssn.Jobs {
+		user := fp.jobUser(job)
+		namespaces[job.Namespace] = struct{}{}
+		if user != "" {
+			users[user] = struct{}{}
+		}
+		if job.Allocated.IsEmpty() {
+			continue
+		}
+		allocations = append(allocations, allocation{
+			queue:     string(job.Queue),
+			namespace: job.Namespace,
+			user:      user,
+			resource:  job.Allocated,
+		})
+	}
+
+	now := timeNow()
+	store.Lock()
+	loaded := store.load(ssn.KubeClient(), fp.namespace)
+	if loaded {
+		store.update(now, fp.halfLife, allocations)
+	}
+
+	fp.buildQueueShares(ssn)
+	fp.namespaces = buildEqualShares(store.Namespaces, namespaces)
+	fp.users = buildEqualShares(store.Users, users)
+
+	if loaded && !store.persisting && now.Sub(store.lastPersist) >= fp.persistInterval && ssn.KubeClient() != nil {
+		store.lastPersist = now
+		store.persisting = true
+		go store.persist(ssn.KubeClient(), fp.namespace, store.snapshot())
+	}
+	store.Unlock()
+
+	ssn.AddQueueOrderFn(fp.Name(), func(l, r interface{}) int {
+		lv := l.(*api.QueueInfo)
+		rv := r.(*api.QueueInfo)
+
+		if lv.Queue.Spec.Priority != rv.Queue.Spec.Priority {
+			// return negative means high priority
+			return int(rv.Queue.Spec.Priority) - int(lv.Queue.Spec.Priority)
+		}
+		return compareFactors(fp.queues[lv.UID], fp.queues[rv.UID])
+	})
+
+	ssn.AddJobOrderFn(fp.Name(), func(l, r interface{}) int {
+		lv := l.(*api.JobInfo)
+		rv := r.(*api.JobInfo)
+
+		if result := compareFactors(fp.users[fp.jobUser(lv)], fp.users[fp.jobUser(rv)]); result != 0 {
+			return result
+		}
+		return compareFactors(fp.namespaces[lv.Namespace], fp.namespaces[rv.Namespace])
+	})
+}
+
+// compareFactors orders the entity of the higher factor first, the entities without fair-share are not ordered.
+func compareFactors(l, r *fairShare) int {
+	if l == nil || r == nil || l.Factor == r.Factor {
+		return 0
+	}
+	if l.Factor > r.Factor {
+		return -1
+	}
+	return 1
+}
+
+// buildQueueShares computes the fair-share of the queues, the share of a queue is its weight in the total weight.
+func (fp *fairSharePlugin) buildQueueShares(ssn *framework.Session) {
+	totalWeight := 0.0
+	for _, queue := range ssn.Queues {
+		totalWeight += float64(queue.Weight)
+	}
+	usages := normalizedUsage(store.Queues)
+	fp.queues = make(map[api.QueueID]*fairShare, len(ssn.Queues))
+	for _, queue := range ssn.Queues {
+		share := &fairShare{Usage: usages[queue.Name]}
+		if totalWeight > 0 {
+			share.Share = float64(queue.Weight) / totalWeight
+		}
+		share.Factor = fairShareFactor(share.Usage, share.Share)
+		fp.queues[queue.UID] = share
+		metrics.UpdateQueueFairShare(queue.Name, share.Usage, share.Share, share.Factor)
+		klog.V(4).Infof("Queue <%s> fair-share: usage <%.4f>, share <%.4f>, factor <%.4f>",
+			queue.Name, share.Usage, share.Share, share.Factor)
+	}
+}
+
+// buildEqualShares computes the fair-share of the namespaces or users, all the entities with usage or jobs have
+// an equal share.
+func buildEqualShares(usages map[string]resourceUsage, active map[string]struct{}) map[string]*fairShare {
+	entities := make(map[string]struct{}, len(usages)+len(active))
+	for name := range usages {
+		entities[name] = struct{}{}
+	}
+	for name := range active {
+		entities[name] = struct{}{}
+	}
+	normalized := normalizedUsage(usages)
+	shares := make(map[string]*fairShare, len(entities))
+	for name := range entities {
+		share := &fairShare{Usage: normalized[name], Share: 1 / float64(len(entities))}
+		share.Factor = fairShareFactor(share.Usage, share.Share)
+		shares[name] = share
+	}
+	return shares
+}
+
+func (fp *fairSharePlugin) OnSessionClose(ssn *framework.Session) {
+	fp.queues = nil
+	fp.namespaces = nil
+	fp.users = nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestUsageDecay(t *testing.T) {
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	s := &usageStore{usageRecord: usageRecord{
+		LastUpdate: now,
+		Queues:     map[string]resourceUsage{"q1": {corev1.ResourceCPU: 1000}, "q2": {corev1.ResourceCPU: 1.5}},
+	}}
+
+	allocated := api.NewResource(api.BuildResourceList("1", "1Gi"))
+	s.update(now.Add(time.Hour), time.Hour, []allocation{{queue: "q3", namespace: "ns1", user: "alice", resource: allocated}})
+	assert.InDelta(t, 500, s.Queues["q1"][corev1.ResourceCPU], 1e-6, "usage should be halved after a half-life")
+	assert.NotContains(t, s.Queues, "q2", "usage decayed to nothing should be forgotten")
+	// the allocation is charged for at most maxChargeInterval
+	assert.InDelta(t, 1000*maxChargeInterval.Seconds(), s.Queues["q3"][corev1.ResourceCPU], 1e-6)
+	assert.InDelta(t, 1000*maxChargeInterval.Seconds(), s.Users["alice"][corev1.ResourceCPU], 1e-6)
+	assert.InDelta(t, float64(1<<30)*maxChargeInterval.Seconds(), s.Namespaces["ns1"][corev1.ResourceMemory], 1e-6)
+	assert.Equal(t, now.Add(time.Hour), s.LastUpdate)
+}
+
+func TestFairShareFactor(t *testing.T) {
+	usages := normalizedUsage(map[string]resourceUsage{
+		"q1": {corev1.ResourceCPU: 3000, corev1.ResourceMemory: 1},
+		"q2": {corev1.ResourceCPU: 1000, corev1.ResourceMemory: 3},
+	})
+	// the effective usage is the dominant share in the usage of all the queues
+	assert.InDelta(t, 0.75, usages["q1"], 1e-9)
+	assert.InDelta(t, 0.75, usages["q2"], 1e-9)
+
+	assert.Equal(t, 1.0, fairShareFactor(0, 0.5))
+	assert.Equal(t, 0.5, fairShareFactor(0.5, 0.5))
+	assert.Equal(t, 0.25, fairShareFactor(1, 0.5))
+	assert.Equal(t, 0.0, fairShareFactor(0.1, 0))
+}
+
+func TestAllocateByHistoricalUsage(t *testing.T) {
+	options.Default()
+	defer func() { timeNow = time.Now }()
+	now := time.Date(2024, 6, 3, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	plugins := map[string]framework.PluginBuilder{PluginName: New}
+	trueValue := true
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("1", "1Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{})
+	// the pods are bound in place by the fake binder, so every case builds its own
+	pod := func(name, pg string) *corev1.Pod {
+		return util.BuildPod("ns1", name, "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), pg, map[string]string{}, nil)
+	}
+	pg1 := util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg2 := util.BuildPodGroup("pg2", "ns1", "q2", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg3 := util.BuildPodGroup("pg3", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg1.Labels = map[string]string{defaultUserKey: "alice"}
+	pg3.Labels = map[string]string{defaultUserKey: "bob"}
+	q1 := util.BuildQueue("q1", 1, nil)
+	q2 := util.BuildQueue("q2", 1, nil)
+
+	cpuSeconds := func(usage float64) resourceUsage { return resourceUsage{corev1.ResourceCPU: usage} }
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		history usageRecord
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "queue which used more than its share in the past waits",
+				Plugins:        plugins,
+				Pods:           []*corev1.Pod{pod("p1", "pg1"), pod("p2", "pg2")},
+				Nodes:          []*corev1.Node{n1},
+				PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2},
+				Queues:         []*schedulingv1beta1.Queue{q1, q2},
+				ExpectBindMap:  map[string]string{"ns1/p2": "n1"},
+				ExpectBindsNum: 1,
+			},
+			history: usageRecord{Queues: map[string]resourceUsage{"q1": cpuSeconds(9e6), "q2": cpuSeconds(1e6)}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "queue which used less than its share in the past goes first",
+				Plugins:        plugins,
+				Pods:           []*corev1.Pod{pod("p1", "pg1"), pod("p2", "pg2")},
+				Nodes:          []*corev1.Node{n1},
+				PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2},
+				Queues:         []*schedulingv1beta1.Queue{q1, q2},
+				ExpectBindMap:  map[string]string{"ns1/p1": "n1"},
+				ExpectBindsNum: 1,
+			},
+			history: usageRecord{Queues: map[string]resourceUsage{"q1": cpuSeconds(1e6), "q2": cpuSeconds(9e6)}},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "job of the user with less historical usage goes first in the queue",
+				Plugins:        plugins,
+				Pods:           []*corev1.Pod{pod("p1", "pg1"), pod("p3", "pg3")},
+				Nodes:          []*corev1.Node{n1},
+				PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg3},
+				Queues:         []*schedulingv1beta1.Queue{q1},
+				ExpectBindMap:  map[string]string{"ns1/p3": "n1"},
+				ExpectBindsNum: 1,
+			},
+			history: usageRecord{Users: map[string]resourceUsage{"alice": cpuSeconds(9e6), "bob": cpuSeconds(1e6)}},
+		},
+	}
+
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:              PluginName,
+					EnabledQueueOrder: &trueValue,
+					EnabledJobOrder:   &trueValue,
+				},
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			store = &usageStore{usageRecord: test.history, loaded: true}
+			store.LastUpdate = now
+
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{allocate.New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+
+			var cm *corev1.ConfigMap
+			assert.Eventually(t, func() bool {
+				var err error
+				cm, err = ssn.KubeClient().CoreV1().ConfigMaps(defaultNamespace).Get(context.TODO(), usageConfigMapName, metav1.GetOptions{})
+				return err == nil
+			}, 5*time.Second, 10*time.Millisecond, "usage should be persisted")
+			var record usageRecord
+			assert.NoError(t, json.Unmarshal([]byte(cm.Data[usageConfigMapKey]), &record))
+			assert.Equal(t, now, record.LastUpdate)
+			assert.Equal(t, test.history.Queues, record.Queues)
+			assert.Equal(t, test.history.Users, record.Users)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// usageConfigMapName is the name of the ConfigMap the usage is persisted in, so that it survives restarts
+	// of the scheduler.
+	usageConfigMapName = "volcano-scheduler-fairshare"
+	usageConfigMapKey  = "usage"
+
+	// maxChargeInterval bounds the time the allocation of a session is charged for, the allocation is unknown
+	// while the scheduler is down.
+	maxChargeInterval = time.Minute
+	// minUsage is the usage below which an entity is forgotten.
+	minUsage = 1.0
+)
+
+// resourceUsage is the decayed resource-seconds of an entity by resource, in the units of api.Resource,
+// e.g. milli-cpu-seconds and byte-seconds.
+type resourceUsage map[v1.ResourceName]float64
+
+func (u resourceUsage) add(r *api.Resource, seconds float64) {
+	u[v1.ResourceCPU] += r.MilliCPU * seconds
+	u[v1.ResourceMemory] += r.Memory * seconds
+	for name, quantity := range r.ScalarResources {
+		if name == v1.ResourcePods {
+			continue
+		}
+		u[name] += quantity * seconds
+	}
+}
+
+// decay multiplies the usage by the factor, it returns false if nothing is left.
+func (u resourceUsage) decay(factor float64) bool {
+	left := false
+	for name, value := range u {
+		u[name] = value * factor
+		if u[name] >= minUsage {
+			left = true
+		}
+	}
+	return left
+}
+
+// usageRecord is the persisted usage of the queues, namespaces and users.
+type usageRecord struct {
+	LastUpdate time.Time                `json:"lastUpdate"`
+	Queues     map[string]resourceUsage `json:"queues,omitempty"`
+	Namespaces map[string]resourceUsage `json:"namespaces,omitempty"`
+	Users      map[string]resourceUsage `json:"users,omitempty"`
+}
+
+// allocation is the resources allocated to a job in a session, charged to its queue, namespace and user.
+type allocation struct {
+	queue     string
+	namespace string
+	user      string
+	resource  *api.Resource
+}
+
+// usageStore keeps the usage across the sessions.
+type usageStore struct {
+	sync.Mutex
+	usageRecord
+
+	// loading and loaded track the read of the persisted usage, the usage is not updated before it is loaded.
+	loading     bool
+	loaded      bool
+	lastPersist time.Time
+	// persisting is set while the usage is persisted.
+	persisting bool
+}
+
+// store is shared by the plugin instances of the sessions.
+var store = &usageStore{}
+
+func decayUsages(usages map[string]resourceUsage, factor float64) {
+	for name, usage := range usages {
+		if !usage.decay(factor) {
+			delete(usages, name)
+		}
+	}
+}
+
+func charge(usages map[string]resourceUsage, name string, r *api.Resource, seconds float64) map[string]resourceUsage {
+	if name == "" {
+		return usages
+	}
+	if usages == nil {
+		usages = make(map[string]resourceUsage)
+	}
+	if usages[name] == nil {
+		usages[name] = resourceUsage{}
+	}
+	usages[name].add(r, seconds)
+	return usages
+}
+
+// update decays the usage by the half-life for the time since the last update, then charges the allocations
+// for that time.
+func (s *usageStore) update(now time.Time, halfLife time.Duration, allocations []allocation) {
+	if s.LastUpdate.IsZero() {
+		s.LastUpdate = now
+		return
+	}
+	elapsed := now.Sub(s.LastUpdate)
+	if elapsed <= 0 {
+		return
+	}
+	s.LastUpdate = now
+
+	factor := math.Exp2(-elapsed.Seconds() / halfLife.Seconds())
+	decayUsages(s.Queues, factor)
+	decayUsages(s.Namespaces, factor)
+	decayUsages(s.Users, factor)
+
+	seconds := math.Min(elapsed.Seconds(), maxChargeInterval.Seconds())
+	for _, a := range allocations {
+		s.Queues = charge(s.Queues, a.queue, a.resource, seconds)
+		s.Namespaces = charge(s.Namespaces, a.namespace, a.resource, seconds)
+		s.Users = charge(s.Users, a.user, a.resource, seconds)
+	}
+}
+
+// load reads the persisted usage once in the background, so that the sessions do not wait for the api server.
+// It returns whether the usage is loaded, the usage starts from scratch if it is not found.
+func (s *usageStore) load(client kubernetes.Interface, namespace string) bool {
+	if s.loaded || client == nil {
+		return true
+	}
+	if s.loading {
+		return false
+	}
+	s.loading = true
+	go func() {
+		record, found := readUsage(client, namespace)
+
+		s.Lock()
+		defer s.Unlock()
+		if found {
+			s.usageRecord = record
+			klog.V(3).Infof("Loaded fair-share usage of %d queues updated at %v", len(record.Queues), record.LastUpdate)
+		}
+		s.loading = false
+		s.loaded = true
+	}()
+	return false
+}
+
+// readUsage reads the usage from the ConfigMap, it returns false if there is no valid usage.
+func readUsage(client kubernetes.Interface, namespace string) (usageRecord, bool) {
+	var record usageRecord
+	cm, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), usageConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			klog.Warningf("Failed to get fair-share usage from ConfigMap <%s/%s>: %v", namespace, usageConfigMapName, err)
+		}
+		return record, false
+	}
+	if err := json.Unmarshal([]byte(cm.Data[usageConfigMapKey]), &record); err != nil {
+		klog.Warningf("Invalid fair-share usage in ConfigMap <%s/%s>: %v", namespace, usageConfigMapName, err)
+		return record, false
+	}
+	return record, true
+}
+
+// snapshot returns a copy of the usage, to be persisted outside of the lock of the store.
+func (s *usageStore) snapshot() usageRecord {
+	return usageRecord{
+		LastUpdate: s.LastUpdate,
+		Queues:     copyUsages(s.Queues),
+		Namespaces: copyUsages(s.Namespaces),
+		Users:      copyUsages(s.Users),
+	}
+}
+
+func copyUsages(usages map[string]resourceUsage) map[string]resourceUsage {
+	if usages == nil {
+		return nil
+	}
+	result := make(map[string]resourceUsage, len(usages))
+	for name, usage := range usages {
+		result[name] = make(resourceUsage, len(usage))
+		for resource, value := range usage {
+			result[name][resource] = value
+		}
+	}
+	return result
+}
+
+// persist writes the usage to the ConfigMap in the background, so that the sessions neither wait for the api server
+// nor hold the store meanwhile.
+func (s *usageStore) persist(client kubernetes.Interface, namespace string, record usageRecord) {
+	if err := persistUsage(client, namespace, record); err != nil {
+		klog.Warningf("Failed to persist fair-share usage to ConfigMap <%s/%s>: %v", namespace, usageConfigMapName, err)
+	}
+	s.Lock()
+	s.persisting = false
+	s.Unlock()
+}
+
+// persistUsage writes the usage to the ConfigMap.
+func persistUsage(client kubernetes.Interface, namespace string, record usageRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	configMaps := client.CoreV1().ConfigMaps(namespace)
+	cm, err := configMaps.Get(context.TODO(), usageConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: usageConfigMapName, Namespace: namespace},
+			Data:       map[string]string{usageConfigMapKey: string(data)},
+		}
+		_, err = configMaps.Create(context.TODO(), cm, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[usageConfigMapKey] = string(data)
+	_, err = configMaps.Update(context.TODO(), cm, metav1.UpdateOptions{})
+	return err
+}
+
+// normalizedUsage returns the effective usage of each entity, the dominant share of the entity in the usage
+// of all the entities, so that the usages of the resources of different units are comparable.
+func normalizedUsage(usages map[string]resourceUsage) map[string]float64 {
+	total := resourceUsage{}
+	for _, usage := range usages {
+		for name, value := range usage {
+			total[name] += value
+		}
+	}
+	result := make(map[string]float64, len(usages))
+	for entity, usage := range usages {
+		for name, value := range usage {
+			if total[name] > 0 {
+				result[entity] = math.Max(result[entity], value/total[name])
+			}
+		}
+	}
+	return result
+}
+
+// fairShareFactor is the fair-share factor of Slurm, 2^(-usage/share): 1 for an entity without usage, 0.5 for an
+// entity which used exactly its share, and towards 0 the more the entity used over its share.
+func fairShareFactor(usage, share float64) float64 {
+	if share <= 0 {
+		return 0
+	}
+	return math.Exp2(-usage / share)
+}
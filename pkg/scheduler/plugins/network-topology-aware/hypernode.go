@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopologyaware
+
+import (
+	"sort"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// hyperNodesFromLabels builds the hypernodes from the labels of the nodes, the nodes with the
+// same value of the label of tier i are in the hypernode named "<label>=<value>" of tier i+1, and
+// this hypernode is a member of the hypernode of the next label of its nodes.
+func hyperNodesFromLabels(nodes []*api.NodeInfo, tierLabels []string) []*api.HyperNodeInfo {
+	hyperNodes := map[string]*api.HyperNodeInfo{}
+	for _, node := range nodes {
+		if node.Node == nil {
+			continue
+		}
+		// member is the hypernode of the node in the previous tier, if any
+		member := ""
+		for i, label := range tierLabels {
+			value, found := node.Node.Labels[label]
+			if !found {
+				continue
+			}
+			name := label + "=" + value
+			hn, found := hyperNodes[name]
+			if !found {
+				hn = api.NewHyperNodeInfo(name, i+1)
+				hyperNodes[name] = hn
+			}
+			if member == "" {
+				hn.Nodes.Insert(node.Name)
+			} else {
+				hn.Members.Insert(member)
+			}
+			member = name
+		}
+	}
+
+	result := make([]*api.HyperNodeInfo, 0, len(hyperNodes))
+	for _, hn := range hyperNodes {
+		result = append(result, hn)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopologyaware
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "network-topology-aware"
+
+	// TierLabelsKey is the comma separated list of the node labels describing the network
+	// topology, lowest tier first, e.g. "volcano.sh/leaf-switch,volcano.sh/spine-switch". The
+	// nodes with the same value of the label of tier i are in the same hypernode of tier i+1.
+	// The labels are the only source of the topology, the plugin does nothing without them.
+	TierLabelsKey = "network-topology-aware.tierLabels"
+	// WeightKey is the weight of the hypernode and node scores of the plugin
+	WeightKey = "network-topology-aware.weight"
+)
+
+// noTierLabelsWarning warns once instead of every session as the plugin is built again in every session.
+var noTierLabelsWarning sync.Once
+
+type networkTopologyAwarePlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+	tierLabels      []string
+	weight          int
+}
+
+// New function returns network topology aware plugin object.
+func New(arguments framework.Arguments) framework.Plugin {
+	plugin := &networkTopologyAwarePlugin{
+		pluginArguments: arguments,
+		weight:          1,
+	}
+	if labels, ok := arguments[TierLabelsKey].(string); ok {
+		for _, label := range strings.Split(labels, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				plugin.tierLabels = append(plugin.tierLabels, label)
+			}
+		}
+	}
+	arguments.GetInt(&plugin.weight, WeightKey)
+	return plugin
+}
+
+func (nta *networkTopologyAwarePlugin) Name() string {
+	return PluginName
+}
+
+func (nta *networkTopologyAwarePlugin) OnSessionOpen(ssn *framework.Session) {
+	if len(nta.tierLabels) == 0 {
+		noTierLabelsWarning.Do(func() {
+			klog.Warningf("No %s is set, network topology is ignored", TierLabelsKey)
+		})
+		return
+	}
+	hyperNodes := hyperNodesFromLabels(ssn.NodeList, nta.tierLabels)
+	if len(hyperNodes) == 0 {
+		klog.V(4).Infof("No hypernode is found in session %v", ssn.UID)
+		return
+	}
+
+	hni, err := api.NewHyperNodesInfo(hyperNodes)
+	if err != nil {
+		klog.Errorf("Invalid network topology, it is ignored in session %v: %v", ssn.UID, err)
+		return
+	}
+	ssn.HyperNodes = hni
+	klog.V(4).Infof("Network topology of session %v has %d hypernodes in tiers %v", ssn.UID, len(hni.HyperNodes), hni.Tiers())
+
+	ssn.AddHyperNodeOrderFn(nta.Name(), func(job *api.JobInfo, hyperNodes []*api.HyperNodeInfo) (map[string]float64, error) {
+		nodes := placedNodes(job)
+		scores := make(map[string]float64, len(hyperNodes))
+		if nodes.Len() == 0 {
+			return scores, nil
+		}
+		// the more tasks of the job are already placed in the hypernode, the higher the score
+		for _, hn := range hyperNodes {
+			scores[hn.Name] = nta.maxScore() * float64(hn.Nodes.Intersection(nodes).Len()) / float64(nodes.Len())
+		}
+		return scores, nil
+	})
+
+	ssn.AddPredicateFn(nta.Name(), func(task *api.TaskInfo, node *api.NodeInfo) error {
+		job, found := ssn.Jobs[task.Job]
+		if !found {
+			return nil
+		}
+		topology := job.NetworkTopology()
+		if topology == nil || topology.Mode != api.HardNetworkTopologyMode {
+			return nil
+		}
+		allowed := allowedHyperNode(hni, placedNodes(job), topology.HighestTierAllowed)
+		if allowed == nil || allowed.Nodes.Has(node.Name) {
+			return nil
+		}
+		return api.NewFitErrWithStatus(task, node, &api.Status{
+			Code:   api.UnschedulableAndUnresolvable,
+			Reason: fmt.Sprintf("node is out of hypernode %s of the other tasks of the job", allowed.Name),
+			Plugin: PluginName,
+		})
+	})
+
+	ssn.AddNodeOrderFn(nta.Name(), func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
+		job, found := ssn.Jobs[task.Job]
+		if !found {
+			return 0, nil
+		}
+		topology := job.NetworkTopology()
+		if topology == nil || topology.Mode != api.SoftNetworkTopologyMode {
+			return 0, nil
+		}
+		nodes := placedNodes(job)
+		if nodes.Len() == 0 {
+			return 0, nil
+		}
+		// the lower the tier of the hypernode shared with the other tasks of the job, the higher the score
+		tiers := hni.Tiers()
+		common := hni.LowestCommonHyperNode(nodes.Clone().Insert(node.Name))
+		if common == nil {
+			return 0, nil
+		}
+		for i, tier := range tiers {
+			if tier == common.Tier {
+				return nta.maxScore() * float64(len(tiers)-i) / float64(len(tiers)), nil
+			}
+		}
+		return 0, nil
+	})
+}
+
+func (nta *networkTopologyAwarePlugin) OnSessionClose(ssn *framework.Session) {}
+
+func (nta *networkTopologyAwarePlugin) maxScore() float64 {
+	return float64(nta.weight) * float64(k8sframework.MaxNodeScore)
+}
+
+// placedNodes returns the nodes of the tasks of the job which are allocated or pipelined.
+func placedNodes(job *api.JobInfo) sets.Set[string] {
+	nodes := sets.New[string]()
+	for status, tasks := range job.TaskStatusIndex {
+		if !api.AllocatedStatus(status) && status != api.Pipelined {
+			continue
+		}
+		for _, task := range tasks {
+			if task.NodeName != "" {
+				nodes.Insert(task.NodeName)
+			}
+		}
+	}
+	return nodes
+}
+
+// allowedHyperNode returns the hypernode of the highest tier allowed which contains the placed
+// nodes, 0 allows any tier. It returns nil if there is no constraint, i.e. nothing is placed yet
+// or the placed nodes already span more than the hypernodes allowed.
+func allowedHyperNode(hni *api.HyperNodesInfo, nodes sets.Set[string], highestTierAllowed int) *api.HyperNodeInfo {
+	common := hni.LowestCommonHyperNode(nodes)
+	if common == nil {
+		return nil
+	}
+	if highestTierAllowed > 0 && common.Tier > highestTierAllowed {
+		return nil
+	}
+	allowed := common
+	for allowed.Parent != "" {
+		parent := hni.HyperNodes[allowed.Parent]
+		if highestTierAllowed > 0 && parent.Tier > highestTierAllowed {
+			break
+		}
+		allowed = parent
+	}
+	return allowed
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopologyaware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestHyperNodesFromLabels(t *testing.T) {
+	var nodes []*api.NodeInfo
+	for name, labels := range map[string]map[string]string{
+		"n0": {"leaf": "l0", "spine": "s0"},
+		"n1": {"leaf": "l0", "spine": "s0"},
+		"n2": {"leaf": "l1", "spine": "s0"},
+		"n3": {"spine": "s0"},
+		"n4": nil,
+	} {
+		nodes = append(nodes, api.NewNodeInfo(util.BuildNode(name, api.BuildResourceList("1", "1Gi"), labels)))
+	}
+
+	hni, err := api.NewHyperNodesInfo(hyperNodesFromLabels(nodes, []string{"leaf", "spine"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, hni.Tiers())
+	assert.Equal(t, sets.New("n0", "n1"), hni.HyperNodes["leaf=l0"].Nodes)
+	assert.Equal(t, sets.New("n2"), hni.HyperNodes["leaf=l1"].Nodes)
+	assert.Equal(t, sets.New("leaf=l0", "leaf=l1"), hni.HyperNodes["spine=s0"].Members)
+	assert.Equal(t, sets.New("n0", "n1", "n2", "n3"), hni.HyperNodes["spine=s0"].Nodes)
+	assert.Nil(t, hni.HyperNodeOf("n4", 2))
+}
+
+func TestNetworkTopologyAware(t *testing.T) {
+	buildNode := func(name, leaf string) *v1.Node {
+		return util.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...),
+			map[string]string{"leaf": leaf, "spine": "s0"})
+	}
+	pg := util.BuildPodGroup("pg1", "c1", "c1", 2, nil, schedulingv1.PodGroupRunning)
+	pg.Annotations = map[string]string{
+		api.NetworkTopologyModeAnnotation:        "hard",
+		api.NetworkTopologyHighestTierAnnotation: "1",
+	}
+	pg2 := util.BuildPodGroup("pg2", "c1", "c1", 2, nil, schedulingv1.PodGroupRunning)
+	pg2.Annotations = map[string]string{api.NetworkTopologyModeAnnotation: "soft"}
+
+	test := uthelper.TestCommonStruct{
+		Plugins: map[string]framework.PluginBuilder{PluginName: New},
+		Pods: []*v1.Pod{
+			util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+			util.BuildPod("c1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+			util.BuildPod("c1", "p3", "n3", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+			util.BuildPod("c1", "p4", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+		},
+		Nodes: []*v1.Node{
+			buildNode("n1", "l0"),
+			buildNode("n2", "l0"),
+			buildNode("n3", "l1"),
+			buildNode("n4", "l1"),
+		},
+		PodGroups: []*schedulingv1.PodGroup{pg, pg2},
+		Queues:    []*schedulingv1.Queue{util.BuildQueue("c1", 1, nil)},
+	}
+	trueValue := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{
+		Name:                  PluginName,
+		EnabledPredicate:      &trueValue,
+		EnabledNodeOrder:      &trueValue,
+		EnabledHyperNodeOrder: &trueValue,
+		Arguments:             map[string]interface{}{TierLabelsKey: "leaf,spine"},
+	}}}}
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+
+	job := ssn.Jobs["c1/pg1"]
+	scores, err := ssn.HyperNodeOrderFn(job, ssn.HyperNodes.HyperNodesOfTier(1))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"leaf=l0": 100, "leaf=l1": 0}, scores)
+
+	var p2, p4 *api.TaskInfo
+	for _, task := range ssn.Jobs["c1/pg1"].TaskStatusIndex[api.Pending] {
+		p2 = task
+	}
+	for _, task := range ssn.Jobs["c1/pg2"].TaskStatusIndex[api.Pending] {
+		p4 = task
+	}
+
+	// the hard job can only go to the leaf of its running task
+	assert.NoError(t, ssn.PredicateFn(p2, ssn.Nodes["n2"]))
+	assert.Error(t, ssn.PredicateFn(p2, ssn.Nodes["n3"]))
+	// the soft job can go anywhere, but prefers the leaf of its running task
+	assert.NoError(t, ssn.PredicateFn(p4, ssn.Nodes["n1"]))
+	inLeaf, err := ssn.NodeOrderFn(p4, ssn.Nodes["n4"])
+	assert.NoError(t, err)
+	inSpine, err := ssn.NodeOrderFn(p4, ssn.Nodes["n1"])
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, inLeaf)
+	assert.Equal(t, 50.0, inSpine)
+}
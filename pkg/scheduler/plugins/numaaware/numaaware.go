@@ -35,6 +35,8 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
 	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/provider/cpumanager"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/provider/devicemanager"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/provider/memorymanager"
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
 )
 
@@ -63,7 +65,7 @@ func New(arguments framework.Arguments) framework.Plugin {
 		taskBindNodeMap: make(map[api.TaskID]string),
 	}
 
-	plugin.hintProviders = append(plugin.hintProviders, cpumanager.NewProvider())
+	plugin.hintProviders = append(plugin.hintProviders, cpumanager.NewProvider(), memorymanager.NewProvider(), devicemanager.NewProvider())
 	return plugin
 }
 
@@ -235,22 +237,28 @@ func getNodeNumaNumForTask(nodeInfo []*api.NodeInfo, resAssignMap map[string]api
 	nodeNumaCnts := make([]api.ScoredNode, len(nodeInfo))
 	workqueue.ParallelizeUntil(context.TODO(), 16, len(nodeInfo), func(index int) {
 		node := nodeInfo[index]
-		assignCpus := resAssignMap[node.Name][string(v1.ResourceCPU)]
 		nodeNumaCnts[index] = api.ScoredNode{
 			NodeName: node.Name,
-			Score:    int64(getNumaNodeCntForCPUID(assignCpus, node.NumaSchedulerInfo.CPUDetail)),
+			Score:    int64(getNumaNodeCntForResSets(resAssignMap[node.Name], node.NumaSchedulerInfo.CPUDetail)),
 		}
 	})
 
 	return nodeNumaCnts
 }
 
-func getNumaNodeCntForCPUID(cpus cpuset.CPUSet, cpuDetails topology.CPUDetails) int {
+// getNumaNodeCntForResSets return the number of numa nodes of the cpus, memory and devices assigned to the task.
+func getNumaNodeCntForResSets(resSets api.ResNumaSets, cpuDetails topology.CPUDetails) int {
 	mask, _ := bitmask.NewBitMask()
-	s := cpus.List()
-
-	for _, cpuID := range s {
-		mask.Add(cpuDetails[cpuID].NUMANodeID)
+	for resName, set := range resSets {
+		if resName == string(v1.ResourceCPU) {
+			for _, cpuID := range set.List() {
+				mask.Add(cpuDetails[cpuID].NUMANodeID)
+			}
+			continue
+		}
+		if _, numaID, ok := api.ParseNumaResourceName(resName); ok && set.Size() > 0 {
+			mask.Add(numaID)
+		}
 	}
 
 	return mask.Count()
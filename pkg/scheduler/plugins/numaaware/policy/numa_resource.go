@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"sort"
+
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+	"k8s.io/utils/cpuset"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// NumaResource is a resource reported per numa node in the Numatopology, e.g. the memory blocks or the devices
+// of the numa nodes. The units of the resource are identified by ids, like the cpus.
+type NumaResource struct {
+	// Names is the name of the resource on each numa node in the resource sets
+	Names map[int]string
+	// Capacity is the number of units of the resource on each numa node
+	Capacity map[int]int
+	// Free is the free units of the resource on each numa node
+	Free map[int]cpuset.CPUSet
+}
+
+// NewNumaResource returns the resource on the numa nodes of the node, it returns nil if the node doesn't report
+// the resource per numa node.
+func NewNumaResource(resName string, topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) *NumaResource {
+	names := topoInfo.NumaResources(resName)
+	if len(names) == 0 {
+		return nil
+	}
+	res := &NumaResource{
+		Names:    names,
+		Capacity: make(map[int]int, len(names)),
+		Free:     make(map[int]cpuset.CPUSet, len(names)),
+	}
+	for numaID, name := range names {
+		res.Capacity[numaID] = topoInfo.NumaResMap[name].Capacity
+		res.Free[numaID] = resNumaSets[name]
+	}
+	return res
+}
+
+// GenerateHints returns the hints of the numa node combinations with enough free units for the request. As the
+// cpu manager does, a hint is preferred if it has the fewest numa nodes whose capacity satisfies the request.
+func (res *NumaResource) GenerateHints(numaNodes []int, request int) []TopologyHint {
+	minAffinitySize := len(numaNodes)
+	hints := []TopologyHint{}
+	bitmask.IterateBitMasks(numaNodes, func(mask bitmask.BitMask) {
+		capacity, free := 0, 0
+		for _, numaID := range mask.GetBits() {
+			capacity += res.Capacity[numaID]
+			free += res.Free[numaID].Size()
+		}
+		if capacity >= request && mask.Count() < minAffinitySize {
+			minAffinitySize = mask.Count()
+		}
+		if free < request {
+			return
+		}
+		hints = append(hints, TopologyHint{
+			NUMANodeAffinity: mask,
+			Preferred:        false,
+		})
+	})
+
+	for i := range hints {
+		if hints[i].NUMANodeAffinity.Count() == minAffinitySize {
+			hints[i].Preferred = true
+		}
+	}
+	return hints
+}
+
+// Take takes the units of the request from the numa nodes of the best hit first, then from the other numa nodes,
+// the units of the lowest ids are taken on each numa node. It returns the units taken by the name of the resource
+// on the numa nodes, or false if there are not enough free units.
+func (res *NumaResource) Take(bestHit *TopologyHint, request int) (map[string]cpuset.CPUSet, bool) {
+	var order []int
+	if bestHit != nil && bestHit.NUMANodeAffinity != nil {
+		order = append(order, bestHit.NUMANodeAffinity.GetBits()...)
+	}
+	others := make([]int, 0, len(res.Free))
+	for numaID := range res.Free {
+		if bestHit == nil || bestHit.NUMANodeAffinity == nil || !bestHit.NUMANodeAffinity.IsSet(numaID) {
+			others = append(others, numaID)
+		}
+	}
+	sort.Ints(others)
+	order = append(order, others...)
+
+	taken := make(map[string]cpuset.CPUSet)
+	for _, numaID := range order {
+		if request == 0 {
+			break
+		}
+		free := res.Free[numaID].List()
+		if len(free) > request {
+			free = free[:request]
+		}
+		if len(free) == 0 {
+			continue
+		}
+		taken[res.Names[numaID]] = cpuset.New(free...)
+		request -= len(free)
+	}
+	return taken, request == 0
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devicemanager
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/cpuset"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+type deviceMng struct {
+}
+
+// NewProvider return a new provider
+func NewProvider() policy.HintProvider {
+	return &deviceMng{}
+}
+
+// Name return the device manager name
+func (mng *deviceMng) Name() string {
+	return "deviceMng"
+}
+
+// requestedDevices return the devices requested by the container, of the resources reported per numa node,
+// e.g. "nvidia.com/gpu@numa0" with allocatable "0-1" is the free gpus 0 and 1 on the numa node 0.
+func requestedDevices(container *v1.Container, topoInfo *api.NumatopoInfo) map[string]int {
+	requests := make(map[string]int)
+	for name := range topoInfo.NumaResMap {
+		resName, _, ok := api.ParseNumaResourceName(name)
+		if !ok || resName == string(v1.ResourceMemory) || resName == string(v1.ResourceCPU) {
+			continue
+		}
+		quantity, ok := container.Resources.Limits[v1.ResourceName(resName)]
+		if !ok {
+			quantity, ok = container.Resources.Requests[v1.ResourceName(resName)]
+		}
+		if ok && quantity.Value() > 0 {
+			requests[resName] = int(quantity.Value())
+		}
+	}
+	return requests
+}
+
+func (mng *deviceMng) GetTopologyHints(container *v1.Container,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string][]policy.TopologyHint {
+	requests := requestedDevices(container, topoInfo)
+	if len(requests) == 0 {
+		return nil
+	}
+
+	numaNodes := topoInfo.CPUDetail.NUMANodes().List()
+	hints := make(map[string][]policy.TopologyHint, len(requests))
+	for resName, request := range requests {
+		devices := policy.NewNumaResource(resName, topoInfo, resNumaSets)
+		klog.V(4).Infof("[devicemanager] requested %s: %d, free: %v", resName, request, devices.Free)
+		hints[resName] = devices.GenerateHints(numaNodes, request)
+	}
+	return hints
+}
+
+func (mng *deviceMng) Allocate(container *v1.Container, bestHit *policy.TopologyHint,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string]cpuset.CPUSet {
+	result := make(map[string]cpuset.CPUSet)
+	for resName, request := range requestedDevices(container, topoInfo) {
+		devices, ok := policy.NewNumaResource(resName, topoInfo, resNumaSets).Take(bestHit, request)
+		if !ok {
+			klog.V(3).Infof("[devicemanager] not enough %s for container %s, requested %d", resName, container.Name, request)
+			continue
+		}
+		for name, ids := range devices {
+			result[name] = ids
+		}
+	}
+	return result
+}
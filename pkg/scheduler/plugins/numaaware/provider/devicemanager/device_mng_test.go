@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package devicemanager
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+	"k8s.io/utils/cpuset"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+const gpu = "nvidia.com/gpu"
+
+var numaInfo = api.NumatopoInfo{
+	NumaResMap: map[string]*api.ResourceInfo{
+		"nvidia.com/gpu@numa0": {Capacity: 2},
+		"nvidia.com/gpu@numa1": {Capacity: 2},
+		"memory@numa0":         {Capacity: 4},
+	},
+	CPUDetail: topology.CPUDetails{
+		0: {NUMANodeID: 0, CoreID: 0, SocketID: 0},
+		1: {NUMANodeID: 1, CoreID: 1, SocketID: 1},
+	},
+}
+
+func newHint(preferred bool, numaIDs ...int) policy.TopologyHint {
+	mask, _ := bitmask.NewBitMask(numaIDs...)
+	return policy.TopologyHint{NUMANodeAffinity: mask, Preferred: preferred}
+}
+
+func newContainer(gpus string) v1.Container {
+	container := v1.Container{Resources: v1.ResourceRequirements{Limits: v1.ResourceList{
+		v1.ResourceMemory: resource.MustParse("1Gi"),
+	}}}
+	if gpus != "" {
+		container.Resources.Limits[gpu] = resource.MustParse(gpus)
+	}
+	return container
+}
+
+func Test_GetTopologyHints(t *testing.T) {
+	testCases := []struct {
+		name        string
+		container   v1.Container
+		resNumaSets api.ResNumaSets
+		expect      map[string][]policy.TopologyHint
+	}{
+		{
+			name:      "devices fit into either numa node",
+			container: newContainer("2"),
+			resNumaSets: api.ResNumaSets{
+				"nvidia.com/gpu@numa0": cpuset.New(0, 1),
+				"nvidia.com/gpu@numa1": cpuset.New(2, 3),
+			},
+			expect: map[string][]policy.TopologyHint{
+				gpu: {newHint(true, 0), newHint(true, 1), newHint(false, 0, 1)},
+			},
+		},
+		{
+			name:      "devices partly used on a numa node",
+			container: newContainer("2"),
+			resNumaSets: api.ResNumaSets{
+				"nvidia.com/gpu@numa0": cpuset.New(1),
+				"nvidia.com/gpu@numa1": cpuset.New(2, 3),
+			},
+			expect: map[string][]policy.TopologyHint{
+				gpu: {newHint(true, 1), newHint(false, 0, 1)},
+			},
+		},
+		{
+			name:      "no device request has no preference",
+			container: newContainer(""),
+			resNumaSets: api.ResNumaSets{
+				"nvidia.com/gpu@numa0": cpuset.New(0, 1),
+			},
+			expect: nil,
+		},
+	}
+
+	for _, testcase := range testCases {
+		provider := NewProvider()
+		hints := provider.GetTopologyHints(&testcase.container, &numaInfo, testcase.resNumaSets)
+		if !equality.Semantic.DeepEqual(hints, testcase.expect) {
+			t.Errorf("%s failed. hints = %v\n", testcase.name, hints)
+		}
+	}
+}
+
+func Test_Allocate(t *testing.T) {
+	container := newContainer("1")
+	resNumaSets := api.ResNumaSets{
+		"nvidia.com/gpu@numa0": cpuset.New(0, 1),
+		"nvidia.com/gpu@numa1": cpuset.New(2, 3),
+	}
+	bestHit := newHint(true, 1)
+
+	result := NewProvider().Allocate(&container, &bestHit, &numaInfo, resNumaSets)
+	expect := map[string]cpuset.CPUSet{"nvidia.com/gpu@numa1": cpuset.New(2)}
+	if !equality.Semantic.DeepEqual(result, expect) {
+		t.Errorf("result = %v, want %v", result, expect)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/cpuset"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+// BlockSize is the size of the memory blocks the memory of the numa nodes is reported in by the Numatopology,
+// e.g. "memory@numa0" with allocatable "0-31" is 32Gi free memory on the numa node 0.
+const BlockSize = 1 << 30
+
+type memoryMng struct {
+}
+
+// NewProvider return a new provider
+func NewProvider() policy.HintProvider {
+	return &memoryMng{}
+}
+
+// Name return the memory manager name
+func (mng *memoryMng) Name() string {
+	return "memoryMng"
+}
+
+// requestedBlocks return the number of memory blocks requested by the container, rounded up.
+func requestedBlocks(container *v1.Container) int {
+	quantity, ok := container.Resources.Requests[v1.ResourceMemory]
+	if !ok {
+		return 0
+	}
+	return int((quantity.Value() + BlockSize - 1) / BlockSize)
+}
+
+func (mng *memoryMng) GetTopologyHints(container *v1.Container,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string][]policy.TopologyHint {
+	request := requestedBlocks(container)
+	if request == 0 {
+		return nil
+	}
+
+	memory := policy.NewNumaResource(string(v1.ResourceMemory), topoInfo, resNumaSets)
+	if memory == nil {
+		klog.V(4).Infof("[memorymanager] numa memory of node %s is not reported", topoInfo.Name)
+		return nil
+	}
+
+	klog.V(4).Infof("[memorymanager] requested blocks: %d, free blocks: %v", request, memory.Free)
+	return map[string][]policy.TopologyHint{
+		string(v1.ResourceMemory): memory.GenerateHints(topoInfo.CPUDetail.NUMANodes().List(), request),
+	}
+}
+
+func (mng *memoryMng) Allocate(container *v1.Container, bestHit *policy.TopologyHint,
+	topoInfo *api.NumatopoInfo, resNumaSets api.ResNumaSets) map[string]cpuset.CPUSet {
+	request := requestedBlocks(container)
+	memory := policy.NewNumaResource(string(v1.ResourceMemory), topoInfo, resNumaSets)
+	if request == 0 || memory == nil {
+		return nil
+	}
+
+	blocks, ok := memory.Take(bestHit, request)
+	if !ok {
+		klog.V(3).Infof("[memorymanager] not enough memory blocks for container %s, requested %d", container.Name, request)
+		return nil
+	}
+	return blocks
+}
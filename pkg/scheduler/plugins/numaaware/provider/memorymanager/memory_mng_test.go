@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memorymanager
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpumanager/topology"
+	"k8s.io/kubernetes/pkg/kubelet/cm/topologymanager/bitmask"
+	"k8s.io/utils/cpuset"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/plugins/numaaware/policy"
+)
+
+var numaInfo = api.NumatopoInfo{
+	NumaResMap: map[string]*api.ResourceInfo{
+		"memory@numa0": {Capacity: 4},
+		"memory@numa1": {Capacity: 4},
+	},
+	CPUDetail: topology.CPUDetails{
+		0: {NUMANodeID: 0, CoreID: 0, SocketID: 0},
+		1: {NUMANodeID: 1, CoreID: 1, SocketID: 1},
+	},
+}
+
+func newHint(preferred bool, numaIDs ...int) policy.TopologyHint {
+	mask, _ := bitmask.NewBitMask(numaIDs...)
+	return policy.TopologyHint{NUMANodeAffinity: mask, Preferred: preferred}
+}
+
+func newContainer(memory string) v1.Container {
+	container := v1.Container{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{}}}
+	if memory != "" {
+		container.Resources.Requests[v1.ResourceMemory] = resource.MustParse(memory)
+	}
+	return container
+}
+
+func Test_GetTopologyHints(t *testing.T) {
+	testCases := []struct {
+		name        string
+		container   v1.Container
+		resNumaSets api.ResNumaSets
+		expect      []policy.TopologyHint
+	}{
+		{
+			name:      "request fits into either numa node",
+			container: newContainer("2Gi"),
+			resNumaSets: api.ResNumaSets{
+				"memory@numa0": cpuset.New(0, 1, 2, 3),
+				"memory@numa1": cpuset.New(0, 1, 2, 3),
+			},
+			expect: []policy.TopologyHint{newHint(true, 0), newHint(true, 1), newHint(false, 0, 1)},
+		},
+		{
+			name:      "request is rounded up to blocks",
+			container: newContainer("2500Mi"),
+			resNumaSets: api.ResNumaSets{
+				"memory@numa0": cpuset.New(0, 1),
+				"memory@numa1": cpuset.New(0, 1, 2),
+			},
+			expect: []policy.TopologyHint{newHint(true, 1), newHint(false, 0, 1)},
+		},
+		{
+			name:      "request bigger than a numa node prefers both",
+			container: newContainer("6Gi"),
+			resNumaSets: api.ResNumaSets{
+				"memory@numa0": cpuset.New(0, 1, 2, 3),
+				"memory@numa1": cpuset.New(0, 1, 2, 3),
+			},
+			expect: []policy.TopologyHint{newHint(true, 0, 1)},
+		},
+		{
+			name:      "no memory request has no preference",
+			container: newContainer(""),
+			resNumaSets: api.ResNumaSets{
+				"memory@numa0": cpuset.New(0, 1, 2, 3),
+			},
+			expect: nil,
+		},
+	}
+
+	for _, testcase := range testCases {
+		provider := NewProvider()
+		hints := provider.GetTopologyHints(&testcase.container, &numaInfo, testcase.resNumaSets)
+		if !equality.Semantic.DeepEqual(hints[string(v1.ResourceMemory)], testcase.expect) {
+			t.Errorf("%s failed. hints = %v\n", testcase.name, hints)
+		}
+	}
+}
+
+func Test_Allocate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		container   v1.Container
+		resNumaSets api.ResNumaSets
+		bestHit     policy.TopologyHint
+		expect      map[string]cpuset.CPUSet
+	}{
+		{
+			name:      "blocks are taken from the best hit",
+			container: newContainer("2Gi"),
+			resNumaSets: api.ResNumaSets{
+				"memory@numa0": cpuset.New(0, 1, 2, 3),
+				"memory@numa1": cpuset.New(1, 2, 3),
+			},
+			bestHit: newHint(true, 1),
+			expect:  map[string]cpuset.CPUSet{"memory@numa1": cpuset.New(1, 2)},
+		},
+		{
+			name:      "blocks are taken across numa nodes",
+			container: newContainer("6Gi"),
+			resNumaSets: api.ResNumaSets{
+				"memory@numa0": cpuset.New(0, 1, 2, 3),
+				"memory@numa1": cpuset.New(0, 1, 2, 3),
+			},
+			bestHit: newHint(true, 0, 1),
+			expect: map[string]cpuset.CPUSet{
+				"memory@numa0": cpuset.New(0, 1, 2, 3),
+				"memory@numa1": cpuset.New(0, 1),
+			},
+		},
+	}
+
+	for _, testcase := range testCases {
+		provider := NewProvider()
+		result := provider.Allocate(&testcase.container, &testcase.bestHit, &numaInfo, testcase.resNumaSets)
+		if !equality.Semantic.DeepEqual(result, testcase.expect) {
+			t.Errorf("%s failed. result = %v\n", testcase.name, result)
+		}
+	}
+}
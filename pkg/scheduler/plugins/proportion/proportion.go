@@ -31,8 +31,12 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/plugins/util"
 )
 
-// PluginName indicates name of volcano scheduler plugin.
-const PluginName = "proportion"
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "proportion"
+
+	rootQueueID = "root"
+)
 
 type proportionPlugin struct {
 	totalResource  *api.Resource
@@ -59,6 +63,11 @@ type queueAttr struct {
 	// realCapability represents the resource limit of the queue, LessEqual capability
 	realCapability *api.Resource
 	guarantee      *api.Resource
+
+	// ancestors are the ancestors of the queue from the root, and children are its child queues, they are only set
+	// when hierarchy is enabled
+	ancestors []api.QueueID
+	children  map[api.QueueID]*queueAttr
 }
 
 // New return proportion action
@@ -80,85 +89,11 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	pp.totalResource.Add(ssn.TotalResource)
 
 	klog.V(4).Infof("The total resource is <%v>", pp.totalResource)
-	for _, queue := range ssn.Queues {
-		if len(queue.Queue.Spec.Guarantee.Resource) == 0 {
-			continue
-		}
-		guarantee := api.NewResource(queue.Queue.Spec.Guarantee.Resource)
-		pp.totalGuarantee.Add(guarantee)
-	}
-	klog.V(4).Infof("The total guarantee resource is <%v>", pp.totalGuarantee)
-	// Build attributes for Queues.
-	for _, job := range ssn.Jobs {
-		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
-		if _, found := pp.queueOpts[job.Queue]; !found {
-			queue := ssn.Queues[job.Queue]
-			attr := &queueAttr{
-				queueID: queue.UID,
-				name:    queue.Name,
-				weight:  queue.Weight,
-
-				deserved:  api.EmptyResource(),
-				allocated: api.EmptyResource(),
-				request:   api.EmptyResource(),
-				elastic:   api.EmptyResource(),
-				inqueue:   api.EmptyResource(),
-				guarantee: api.EmptyResource(),
-			}
-			if len(queue.Queue.Spec.Capability) != 0 {
-				attr.capability = api.NewResource(queue.Queue.Spec.Capability)
-				if attr.capability.MilliCPU <= 0 {
-					attr.capability.MilliCPU = math.MaxFloat64
-				}
-				if attr.capability.Memory <= 0 {
-					attr.capability.Memory = math.MaxFloat64
-				}
-			}
-			if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
-				attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
-			}
-			realCapability := api.ExceededPart(pp.totalResource, pp.totalGuarantee).Add(attr.guarantee)
-			if attr.capability == nil {
-				attr.realCapability = realCapability
-			} else {
-				realCapability.MinDimensionResource(attr.capability, api.Infinity)
-				attr.realCapability = realCapability
-			}
-			pp.queueOpts[job.Queue] = attr
-			klog.V(4).Infof("Added Queue <%s> attributes.", job.Queue)
-		}
-
-		attr := pp.queueOpts[job.Queue]
-		for status, tasks := range job.TaskStatusIndex {
-			if api.AllocatedStatus(status) {
-				for _, t := range tasks {
-					attr.allocated.Add(t.Resreq)
-					attr.request.Add(t.Resreq)
-				}
-			} else if status == api.Pending {
-				for _, t := range tasks {
-					attr.request.Add(t.Resreq)
-				}
-			}
-		}
-
-		if job.PodGroup.Status.Phase == scheduling.PodGroupInqueue {
-			attr.inqueue.Add(job.DeductSchGatedResources(job.GetMinResources()))
-		}
-
-		// calculate inqueue resource for running jobs
-		// the judgement 'job.PodGroup.Status.Running >= job.PodGroup.Spec.MinMember' will work on cases such as the following condition:
-		// Considering a Spark job is completed(driver pod is completed) while the podgroup keeps running, the allocated resource will be reserved again if without the judgement.
-		if job.PodGroup.Status.Phase == scheduling.PodGroupRunning &&
-			job.PodGroup.Spec.MinResources != nil &&
-			int32(util.CalculateAllocatedTaskNum(job)) >= job.PodGroup.Spec.MinMember {
-			inqueued := util.GetInqueueResource(job, job.Allocated)
-			// deduct scheduling gated tasks from inqueue resources
-			attr.inqueue.Add(job.DeductSchGatedResources(inqueued))
-		}
-		attr.elastic.Add(job.GetElasticResources())
-		klog.V(5).Infof("Queue %s allocated <%s> request <%s> inqueue <%s> elastic <%s>",
-			attr.name, attr.allocated.String(), attr.request.String(), attr.inqueue.String(), attr.elastic.String())
+	hierarchyEnabled := pp.HierarchyEnabled(ssn)
+	if hierarchyEnabled {
+		pp.buildHierarchicalQueueAttrs(ssn)
+	} else {
+		pp.buildQueueAttrs(ssn)
 	}
 
 	// Record metrics
@@ -173,73 +108,10 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 		metrics.UpdateQueueRequest(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
 	}
 
-	remaining := pp.totalResource.Clone()
-	meet := map[api.QueueID]struct{}{}
-	for {
-		totalWeight := int32(0)
-		for _, attr := range pp.queueOpts {
-			if _, found := meet[attr.queueID]; found {
-				continue
-			}
-			totalWeight += attr.weight
-		}
-
-		// If no queues, break
-		if totalWeight == 0 {
-			klog.V(4).Infof("Exiting when total weight is 0")
-			break
-		}
-
-		oldRemaining := remaining.Clone()
-		// Calculates the deserved of each Queue.
-		// increasedDeserved is the increased value for attr.deserved of processed queues
-		// decreasedDeserved is the decreased value for attr.deserved of processed queues
-		increasedDeserved := api.EmptyResource()
-		decreasedDeserved := api.EmptyResource()
-		for _, attr := range pp.queueOpts {
-			klog.V(4).Infof("Considering Queue <%s>: weight <%d>, total weight <%d>.",
-				attr.name, attr.weight, totalWeight)
-			if _, found := meet[attr.queueID]; found {
-				continue
-			}
-
-			oldDeserved := attr.deserved.Clone()
-			attr.deserved.Add(remaining.Clone().Multi(float64(attr.weight) / float64(totalWeight)))
-
-			if attr.realCapability != nil {
-				attr.deserved.MinDimensionResource(attr.realCapability, api.Infinity)
-			}
-			attr.deserved.MinDimensionResource(attr.request, api.Zero)
-
-			attr.deserved = helpers.Max(attr.deserved, attr.guarantee)
-			pp.updateShare(attr)
-			klog.V(4).Infof("Format queue <%s> deserved resource to <%v>", attr.name, attr.deserved)
-
-			if attr.request.LessEqual(attr.deserved, api.Zero) {
-				meet[attr.queueID] = struct{}{}
-				klog.V(4).Infof("queue <%s> is meet", attr.name)
-			} else if equality.Semantic.DeepEqual(attr.deserved, oldDeserved) {
-				meet[attr.queueID] = struct{}{}
-				klog.V(4).Infof("queue <%s> is meet cause of the capability", attr.name)
-			}
-
-			klog.V(4).Infof("The attributes of queue <%s> in proportion: deserved <%v>, realCapability <%v>, allocate <%v>, request <%v>, elastic <%v>, share <%0.2f>",
-				attr.name, attr.deserved, attr.realCapability, attr.allocated, attr.request, attr.elastic, attr.share)
-
-			increased, decreased := attr.deserved.Diff(oldDeserved, api.Zero)
-			increasedDeserved.Add(increased)
-			decreasedDeserved.Add(decreased)
-
-			// Record metrics
-			metrics.UpdateQueueDeserved(attr.name, attr.deserved.MilliCPU, attr.deserved.Memory, attr.deserved.ScalarResources)
-		}
-
-		remaining = api.ExceededPart(remaining.Clone().Add(decreasedDeserved), increasedDeserved)
-		klog.V(4).Infof("Remaining resource is  <%s>", remaining)
-		if remaining.IsEmpty() || equality.Semantic.DeepEqual(remaining, oldRemaining) {
-			klog.V(4).Infof("Exiting when remaining is empty or no queue has more resource request:  <%v>", remaining)
-			break
-		}
+	if hierarchyEnabled {
+		pp.divideHierarchically(pp.queueOpts[rootQueueID])
+	} else {
+		pp.divideDeserved(pp.totalResource.Clone(), pp.queueOpts)
 	}
 
 	ssn.AddQueueOrderFn(pp.Name(), func(l, r interface{}) int {
@@ -251,11 +123,13 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 			return int(rv.Queue.Spec.Priority) - int(lv.Queue.Spec.Priority)
 		}
 
-		if pp.queueOpts[lv.UID].share == pp.queueOpts[rv.UID].share {
+		// with hierarchy enabled, the shares of the ancestors of the queues which are siblings are compared
+		lvAttr, rvAttr := pp.siblingAncestors(lv.UID, rv.UID)
+		if lvAttr.share == rvAttr.share {
 			return 0
 		}
 
-		if pp.queueOpts[lv.UID].share < pp.queueOpts[rv.UID].share {
+		if lvAttr.share < rvAttr.share {
 			return -1
 		}
 
@@ -299,16 +173,21 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	})
 
 	queueAllocatable := func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
-		attr := pp.queueOpts[queue.UID]
-
-		futureUsed := attr.allocated.Clone().Add(candidate.Resreq)
-		allocatable := futureUsed.LessEqualWithDimension(attr.deserved, candidate.Resreq)
-		if !allocatable {
-			klog.V(3).Infof("Queue <%v>: deserved <%v>, allocated <%v>; Candidate <%v>: resource request <%v>",
-				queue.Name, attr.deserved, attr.allocated, candidate.Name, candidate.Resreq)
+		if !pp.isLeafQueue(queue.UID) {
+			klog.V(3).Infof("Queue <%v> is not a leaf queue, can not allocate task <%s>.", queue.Name, candidate.Name)
+			return false
+		}
+		// the candidate must fit in the deserved of the queue and of all its ancestors
+		for _, attr := range pp.lineage(queue.UID) {
+			futureUsed := attr.allocated.Clone().Add(candidate.Resreq)
+			if !futureUsed.LessEqualWithDimension(attr.deserved, candidate.Resreq) {
+				klog.V(3).Infof("Queue <%v>: deserved <%v>, allocated <%v>; Candidate <%v>: resource request <%v>",
+					attr.name, attr.deserved, attr.allocated, candidate.Name, candidate.Resreq)
+				return false
+			}
 		}
 
-		return allocatable
+		return true
 	}
 
 	ssn.AddAllocatableFn(pp.Name(), func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
@@ -327,18 +206,15 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddJobEnqueueableFn(pp.Name(), func(obj interface{}) int {
 		job := obj.(*api.JobInfo)
 		queueID := job.Queue
-		attr := pp.queueOpts[queueID]
 		queue := ssn.Queues[queueID]
 		// If the queue is not open, do not enqueue
 		if queue.Queue.Status.State != scheduling.QueueStateOpen {
 			klog.V(4).Infof("Queue <%s> is not open state, reject job <%s/%s>.", queue.Name, job.Namespace, job.Name)
 			return util.Reject
 		}
-		// If no capability is set, always enqueue the job.
-		if attr.realCapability == nil {
-			klog.V(4).Infof("Capability of queue <%s> was not set, allow job <%s/%s> to Inqueue.",
-				queue.Name, job.Namespace, job.Name)
-			return util.Permit
+		if !pp.isLeafQueue(queueID) {
+			klog.V(4).Infof("Queue <%s> is not a leaf queue, reject job <%s/%s>.", queue.Name, job.Namespace, job.Name)
+			return util.Reject
 		}
 
 		if job.PodGroup.Spec.MinResources == nil {
@@ -347,46 +223,61 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 		}
 		minReq := job.GetMinResources()
 
-		klog.V(5).Infof("job %s min resource <%s>, queue %s capability <%s> allocated <%s> inqueue <%s> elastic <%s>",
-			job.Name, minReq.String(), queue.Name, attr.realCapability.String(), attr.allocated.String(), attr.inqueue.String(), attr.elastic.String())
-		// The queue resource quota limit has not reached
-		r := minReq.Clone().Add(attr.allocated).Add(attr.inqueue).Sub(attr.elastic)
+		// The resource quota limit of the queue and of all its ancestors has not reached
+		lineage := pp.lineage(queueID)
+		for _, attr := range lineage {
+			// If no capability is set, always enqueue the job.
+			if attr.realCapability == nil {
+				klog.V(4).Infof("Capability of queue <%s> was not set, allow job <%s/%s> to Inqueue.",
+					attr.name, job.Namespace, job.Name)
+				continue
+			}
 
-		inqueue := r.LessEqualWithDimension(attr.realCapability, minReq)
-		klog.V(5).Infof("job %s inqueue %v", job.Name, inqueue)
-		if inqueue {
-			// deduct the resources of scheduling gated tasks in a job when calculating inqueued resources
-			// so that it will not block other jobs from being inqueued.
+			klog.V(5).Infof("job %s min resource <%s>, queue %s capability <%s> allocated <%s> inqueue <%s> elastic <%s>",
+				job.Name, minReq.String(), attr.name, attr.realCapability.String(), attr.allocated.String(), attr.inqueue.String(), attr.elastic.String())
+			r := minReq.Clone().Add(attr.allocated).Add(attr.inqueue).Sub(attr.elastic)
+
+			inqueue := r.LessEqualWithDimension(attr.realCapability, minReq)
+			klog.V(5).Infof("job %s inqueue %v in queue %s", job.Name, inqueue, attr.name)
+			if !inqueue {
+				ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeNormal, string(scheduling.PodGroupUnschedulableType), "queue resource quota insufficient")
+				return util.Reject
+			}
+		}
+
+		// deduct the resources of scheduling gated tasks in a job when calculating inqueued resources
+		// so that it will not block other jobs from being inqueued.
+		for _, attr := range lineage {
 			attr.inqueue.Add(job.DeductSchGatedResources(minReq))
-			return util.Permit
 		}
-		ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeNormal, string(scheduling.PodGroupUnschedulableType), "queue resource quota insufficient")
-		return util.Reject
+		return util.Permit
 	})
 
 	// Register event handlers.
 	ssn.AddEventHandler(&framework.EventHandler{
 		AllocateFunc: func(event *framework.Event) {
 			job := ssn.Jobs[event.Task.Job]
-			attr := pp.queueOpts[job.Queue]
-			attr.allocated.Add(event.Task.Resreq)
-			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
+			for _, attr := range pp.lineage(job.Queue) {
+				attr.allocated.Add(event.Task.Resreq)
+				metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
 
-			pp.updateShare(attr)
+				pp.updateShare(attr)
+			}
 
 			klog.V(4).Infof("Proportion AllocateFunc: task <%v/%v>, resreq <%v>,  share <%v>",
-				event.Task.Namespace, event.Task.Name, event.Task.Resreq, attr.share)
+				event.Task.Namespace, event.Task.Name, event.Task.Resreq, pp.queueOpts[job.Queue].share)
 		},
 		DeallocateFunc: func(event *framework.Event) {
 			job := ssn.Jobs[event.Task.Job]
-			attr := pp.queueOpts[job.Queue]
-			attr.allocated.Sub(event.Task.Resreq)
-			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
+			for _, attr := range pp.lineage(job.Queue) {
+				attr.allocated.Sub(event.Task.Resreq)
+				metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
 
-			pp.updateShare(attr)
+				pp.updateShare(attr)
+			}
 
 			klog.V(4).Infof("Proportion EvictFunc: task <%v/%v>, resreq <%v>,  share <%v>",
-				event.Task.Namespace, event.Task.Name, event.Task.Resreq, attr.share)
+				event.Task.Namespace, event.Task.Name, event.Task.Resreq, pp.queueOpts[job.Queue].share)
 		},
 	})
 }
@@ -411,3 +302,314 @@ func (pp *proportionPlugin) updateShare(attr *queueAttr) {
 	attr.share = res
 	metrics.UpdateQueueShare(attr.name, attr.share)
 }
+
+// HierarchyEnabled returns if hierarchy is enabled
+func (pp *proportionPlugin) HierarchyEnabled(ssn *framework.Session) bool {
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if plugin.Name != PluginName {
+				continue
+			}
+			return plugin.EnabledHierarchy != nil && *plugin.EnabledHierarchy
+		}
+	}
+	return false
+}
+
+func newQueueAttr(queue *api.QueueInfo) *queueAttr {
+	attr := &queueAttr{
+		queueID: queue.UID,
+		name:    queue.Name,
+		weight:  queue.Weight,
+
+		deserved:  api.EmptyResource(),
+		allocated: api.EmptyResource(),
+		request:   api.EmptyResource(),
+		elastic:   api.EmptyResource(),
+		inqueue:   api.EmptyResource(),
+		guarantee: api.EmptyResource(),
+	}
+	if len(queue.Queue.Spec.Capability) != 0 {
+		attr.capability = api.NewResource(queue.Queue.Spec.Capability)
+		if attr.capability.MilliCPU <= 0 {
+			attr.capability.MilliCPU = math.MaxFloat64
+		}
+		if attr.capability.Memory <= 0 {
+			attr.capability.Memory = math.MaxFloat64
+		}
+	}
+	if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
+		attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+	}
+	return attr
+}
+
+// addJob adds the resources of the job to the attributes of the queue.
+func addJob(attr *queueAttr, job *api.JobInfo) {
+	for status, tasks := range job.TaskStatusIndex {
+		if api.AllocatedStatus(status) {
+			for _, t := range tasks {
+				attr.allocated.Add(t.Resreq)
+				attr.request.Add(t.Resreq)
+			}
+		} else if status == api.Pending {
+			for _, t := range tasks {
+				attr.request.Add(t.Resreq)
+			}
+		}
+	}
+
+	if job.PodGroup.Status.Phase == scheduling.PodGroupInqueue {
+		attr.inqueue.Add(job.DeductSchGatedResources(job.GetMinResources()))
+	}
+
+	// calculate inqueue resource for running jobs
+	// the judgement 'job.PodGroup.Status.Running >= job.PodGroup.Spec.MinMember' will work on cases such as the following condition:
+	// Considering a Spark job is completed(driver pod is completed) while the podgroup keeps running, the allocated resource will be reserved again if without the judgement.
+	if job.PodGroup.Status.Phase == scheduling.PodGroupRunning &&
+		job.PodGroup.Spec.MinResources != nil &&
+		int32(util.CalculateAllocatedTaskNum(job)) >= job.PodGroup.Spec.MinMember {
+		inqueued := util.GetInqueueResource(job, job.Allocated)
+		// deduct scheduling gated tasks from inqueue resources
+		attr.inqueue.Add(job.DeductSchGatedResources(inqueued))
+	}
+	attr.elastic.Add(job.GetElasticResources())
+	klog.V(5).Infof("Queue %s allocated <%s> request <%s> inqueue <%s> elastic <%s>",
+		attr.name, attr.allocated.String(), attr.request.String(), attr.inqueue.String(), attr.elastic.String())
+}
+
+// buildQueueAttrs builds the attributes of the queues of the jobs, all the queues share the total resource.
+func (pp *proportionPlugin) buildQueueAttrs(ssn *framework.Session) {
+	for _, queue := range ssn.Queues {
+		if len(queue.Queue.Spec.Guarantee.Resource) == 0 {
+			continue
+		}
+		guarantee := api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+		pp.totalGuarantee.Add(guarantee)
+	}
+	klog.V(4).Infof("The total guarantee resource is <%v>", pp.totalGuarantee)
+	// Build attributes for Queues.
+	for _, job := range ssn.Jobs {
+		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
+		if _, found := pp.queueOpts[job.Queue]; !found {
+			attr := newQueueAttr(ssn.Queues[job.Queue])
+			realCapability := api.ExceededPart(pp.totalResource, pp.totalGuarantee).Add(attr.guarantee)
+			if attr.capability == nil {
+				attr.realCapability = realCapability
+			} else {
+				realCapability.MinDimensionResource(attr.capability, api.Infinity)
+				attr.realCapability = realCapability
+			}
+			pp.queueOpts[job.Queue] = attr
+			klog.V(4).Infof("Added Queue <%s> attributes.", job.Queue)
+		}
+
+		addJob(pp.queueOpts[job.Queue], job)
+	}
+}
+
+// buildHierarchicalQueueAttrs builds the attributes of all the queues in the tree under the root queue. The
+// resources of the jobs are added to their queues, which must be leaf queues, and to all the ancestors.
+func (pp *proportionPlugin) buildHierarchicalQueueAttrs(ssn *framework.Session) {
+	rootQueue, found := ssn.Queues[rootQueueID]
+	if !found {
+		rootQueue = &api.QueueInfo{UID: rootQueueID, Name: rootQueueID, Weight: 1, Queue: &scheduling.Queue{}}
+	}
+	pp.queueOpts[rootQueueID] = newQueueAttr(rootQueue)
+	for _, queue := range ssn.Queues {
+		if queue.UID != rootQueueID {
+			pp.queueOpts[queue.UID] = newQueueAttr(queue)
+		}
+	}
+	for _, attr := range pp.queueOpts {
+		attr.children = map[api.QueueID]*queueAttr{}
+	}
+	for id, attr := range pp.queueOpts {
+		if id == rootQueueID {
+			continue
+		}
+		parent := pp.parentOf(ssn, id)
+		pp.queueOpts[parent].children[id] = attr
+	}
+	pp.setAncestors(pp.queueOpts[rootQueueID], nil)
+
+	for _, job := range ssn.Jobs {
+		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
+		if !pp.isLeafQueue(job.Queue) {
+			klog.Errorf("The Queue <%s> of Job <%s/%s> is not leaf queue", job.Queue, job.Namespace, job.Name)
+			continue
+		}
+		for _, attr := range pp.lineage(job.Queue) {
+			addJob(attr, job)
+		}
+	}
+
+	root := pp.queueOpts[rootQueueID]
+	root.deserved = pp.totalResource.Clone()
+	root.realCapability = pp.totalResource.Clone()
+	for _, child := range root.children {
+		pp.totalGuarantee.Add(child.guarantee)
+	}
+	klog.V(4).Infof("The total guarantee resource is <%v>", pp.totalGuarantee)
+}
+
+// parentOf returns the parent of the queue, the queues of missing parents or in a cycle are put under the root.
+func (pp *proportionPlugin) parentOf(ssn *framework.Session, id api.QueueID) api.QueueID {
+	parent := api.QueueID(ssn.Queues[id].Queue.Spec.Parent)
+	if parent == "" {
+		return rootQueueID
+	}
+	if _, found := pp.queueOpts[parent]; !found {
+		klog.Errorf("The parent queue <%s> of Queue <%s> is not found, put it under the root queue", parent, id)
+		return rootQueueID
+	}
+	visited := map[api.QueueID]struct{}{id: {}}
+	for ancestor := parent; ancestor != rootQueueID && ancestor != ""; {
+		if _, found := visited[ancestor]; found {
+			klog.Errorf("The parents of Queue <%s> are in a cycle, put it under the root queue", id)
+			return rootQueueID
+		}
+		visited[ancestor] = struct{}{}
+		queue, found := ssn.Queues[ancestor]
+		if !found {
+			break
+		}
+		ancestor = api.QueueID(queue.Queue.Spec.Parent)
+	}
+	return parent
+}
+
+func (pp *proportionPlugin) setAncestors(attr *queueAttr, ancestors []api.QueueID) {
+	attr.ancestors = ancestors
+	for _, child := range attr.children {
+		childAncestors := make([]api.QueueID, 0, len(ancestors)+1)
+		childAncestors = append(childAncestors, ancestors...)
+		pp.setAncestors(child, append(childAncestors, attr.queueID))
+	}
+}
+
+// divideDeserved divides the remaining resource among the queues by their weights. The queues get at most their
+// request and real capability, and at least their guarantee; what a queue doesn't take is divided again among the
+// other queues.
+func (pp *proportionPlugin) divideDeserved(remaining *api.Resource, queues map[api.QueueID]*queueAttr) {
+	meet := map[api.QueueID]struct{}{}
+	for {
+		totalWeight := int32(0)
+		for _, attr := range queues {
+			if _, found := meet[attr.queueID]; found {
+				continue
+			}
+			totalWeight += attr.weight
+		}
+
+		// If no queues, break
+		if totalWeight == 0 {
+			klog.V(4).Infof("Exiting when total weight is 0")
+			break
+		}
+
+		oldRemaining := remaining.Clone()
+		// Calculates the deserved of each Queue.
+		// increasedDeserved is the increased value for attr.deserved of processed queues
+		// decreasedDeserved is the decreased value for attr.deserved of processed queues
+		increasedDeserved := api.EmptyResource()
+		decreasedDeserved := api.EmptyResource()
+		for _, attr := range queues {
+			klog.V(4).Infof("Considering Queue <%s>: weight <%d>, total weight <%d>.",
+				attr.name, attr.weight, totalWeight)
+			if _, found := meet[attr.queueID]; found {
+				continue
+			}
+
+			oldDeserved := attr.deserved.Clone()
+			attr.deserved.Add(remaining.Clone().Multi(float64(attr.weight) / float64(totalWeight)))
+
+			if attr.realCapability != nil {
+				attr.deserved.MinDimensionResource(attr.realCapability, api.Infinity)
+			}
+			attr.deserved.MinDimensionResource(attr.request, api.Zero)
+
+			attr.deserved = helpers.Max(attr.deserved, attr.guarantee)
+			pp.updateShare(attr)
+			klog.V(4).Infof("Format queue <%s> deserved resource to <%v>", attr.name, attr.deserved)
+
+			if attr.request.LessEqual(attr.deserved, api.Zero) {
+				meet[attr.queueID] = struct{}{}
+				klog.V(4).Infof("queue <%s> is meet", attr.name)
+			} else if equality.Semantic.DeepEqual(attr.deserved, oldDeserved) {
+				meet[attr.queueID] = struct{}{}
+				klog.V(4).Infof("queue <%s> is meet cause of the capability", attr.name)
+			}
+
+			klog.V(4).Infof("The attributes of queue <%s> in proportion: deserved <%v>, realCapability <%v>, allocate <%v>, request <%v>, elastic <%v>, share <%0.2f>",
+				attr.name, attr.deserved, attr.realCapability, attr.allocated, attr.request, attr.elastic, attr.share)
+
+			increased, decreased := attr.deserved.Diff(oldDeserved, api.Zero)
+			increasedDeserved.Add(increased)
+			decreasedDeserved.Add(decreased)
+
+			// Record metrics
+			metrics.UpdateQueueDeserved(attr.name, attr.deserved.MilliCPU, attr.deserved.Memory, attr.deserved.ScalarResources)
+		}
+
+		remaining = api.ExceededPart(remaining.Clone().Add(decreasedDeserved), increasedDeserved)
+		klog.V(4).Infof("Remaining resource is  <%s>", remaining)
+		if remaining.IsEmpty() || equality.Semantic.DeepEqual(remaining, oldRemaining) {
+			klog.V(4).Infof("Exiting when remaining is empty or no queue has more resource request:  <%v>", remaining)
+			break
+		}
+	}
+}
+
+// divideHierarchically divides the deserved of the queue among its children, then the deserved of every child among
+// its own children. As the request of a queue is the sum of the requests of its subtree, the share a subtree
+// doesn't use flows up to its parent and down to the siblings.
+func (pp *proportionPlugin) divideHierarchically(attr *queueAttr) {
+	if len(attr.children) == 0 {
+		return
+	}
+	childrenGuarantee := api.EmptyResource()
+	for _, child := range attr.children {
+		childrenGuarantee.Add(child.guarantee)
+	}
+	for _, child := range attr.children {
+		realCapability := api.ExceededPart(attr.realCapability, childrenGuarantee).Add(child.guarantee)
+		if child.capability != nil {
+			realCapability.MinDimensionResource(child.capability, api.Infinity)
+		}
+		child.realCapability = realCapability
+	}
+
+	pp.divideDeserved(attr.deserved.Clone(), attr.children)
+	for _, child := range attr.children {
+		pp.divideHierarchically(child)
+	}
+}
+
+// lineage returns the attributes of the queue and of its ancestors, from the queue up to the root.
+func (pp *proportionPlugin) lineage(queueID api.QueueID) []*queueAttr {
+	attr := pp.queueOpts[queueID]
+	attrs := []*queueAttr{attr}
+	for i := len(attr.ancestors) - 1; i >= 0; i-- {
+		attrs = append(attrs, pp.queueOpts[attr.ancestors[i]])
+	}
+	return attrs
+}
+
+func (pp *proportionPlugin) isLeafQueue(queueID api.QueueID) bool {
+	return len(pp.queueOpts[queueID].children) == 0
+}
+
+// siblingAncestors returns the ancestors of the two queues, or the queues themselves, which are children of their
+// lowest common ancestor.
+func (pp *proportionPlugin) siblingAncestors(l, r api.QueueID) (*queueAttr, *queueAttr) {
+	lAttr, rAttr := pp.queueOpts[l], pp.queueOpts[r]
+	lPath := append(append([]api.QueueID{}, lAttr.ancestors...), l)
+	rPath := append(append([]api.QueueID{}, rAttr.ancestors...), r)
+	for i := 0; i < len(lPath) && i < len(rPath); i++ {
+		if lPath[i] != rPath[i] {
+			return pp.queueOpts[lPath[i]], pp.queueOpts[rPath[i]]
+		}
+	}
+	return lAttr, rAttr
+}
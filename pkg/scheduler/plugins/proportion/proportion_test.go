@@ -504,3 +504,131 @@ func TestAllocate(t *testing.T) {
 		})
 	}
 }
+
+func TestHierarchicalProportion(t *testing.T) {
+	var plugin *proportionPlugin
+	plugins := map[string]framework.PluginBuilder{PluginName: func(arguments framework.Arguments) framework.Plugin {
+		plugin = New(arguments).(*proportionPlugin)
+		return plugin
+	}}
+	trueValue := true
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "16Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
+
+	// the pods are bound in place by the fake binder, so every case builds its own
+	buildPods := func(pg string, num int) []*apiv1.Pod {
+		var pods []*apiv1.Pod
+		for i := 0; i < num; i++ {
+			pods = append(pods, util.BuildPod("ns1", pg+"-"+strconv.Itoa(i), "", apiv1.PodPending,
+				api.BuildResourceList("1", "1Gi"), pg, make(map[string]string), make(map[string]string)))
+		}
+		return pods
+	}
+	podsOf := func(groups map[string]int) []*apiv1.Pod {
+		var pods []*apiv1.Pod
+		for pg, num := range groups {
+			pods = append(pods, buildPods(pg, num)...)
+		}
+		return pods
+	}
+
+	buildQueue := func(name, parent string) *schedulingv1beta1.Queue {
+		queue := util.BuildQueue(name, 1, nil)
+		queue.Spec.Parent = parent
+		return queue
+	}
+	// root -> a -> a1, a2
+	//      -> b
+	queues := []*schedulingv1beta1.Queue{
+		buildQueue("a", ""), buildQueue("b", "root"), buildQueue("a1", "a"), buildQueue("a2", "a"),
+	}
+	podGroups := []*schedulingv1beta1.PodGroup{
+		util.BuildPodGroup("pg-a", "ns1", "a", 1, nil, schedulingv1beta1.PodGroupInqueue),
+		util.BuildPodGroup("pg-a1", "ns1", "a1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+		util.BuildPodGroup("pg-a2", "ns1", "a2", 1, nil, schedulingv1beta1.PodGroupInqueue),
+		util.BuildPodGroup("pg-b", "ns1", "b", 1, nil, schedulingv1beta1.PodGroupInqueue),
+	}
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		expectDeserved map[api.QueueID]float64
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "weight is divided among the children",
+				Plugins:   plugins,
+				Pods:      podsOf(map[string]int{"pg-a1": 4, "pg-a2": 1, "pg-b": 2}),
+				Nodes:     []*apiv1.Node{n1},
+				PodGroups: podGroups,
+				Queues:    queues,
+				ExpectBindMap: map[string]string{
+					"ns1/pg-a1-0": "n1", "ns1/pg-a2-0": "n1", "ns1/pg-b-0": "n1", "ns1/pg-b-1": "n1",
+				},
+				ExpectBindsNum: 4,
+			},
+			expectDeserved: map[api.QueueID]float64{"a": 2000, "b": 2000, "a1": 1000, "a2": 1000},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "unused share flows up to the parent and down to the siblings",
+				Plugins:   plugins,
+				Pods:      podsOf(map[string]int{"pg-a1": 4, "pg-a2": 1}),
+				Nodes:     []*apiv1.Node{n1},
+				PodGroups: podGroups,
+				Queues:    queues,
+				ExpectBindMap: map[string]string{
+					"ns1/pg-a1-0": "n1", "ns1/pg-a1-1": "n1", "ns1/pg-a1-2": "n1", "ns1/pg-a2-0": "n1",
+				},
+				ExpectBindsNum: 4,
+			},
+			expectDeserved: map[api.QueueID]float64{"a": 4000, "b": 0, "a1": 3000, "a2": 1000},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "jobs of the queues which are not leaf queues are not allocated",
+				Plugins:   plugins,
+				Pods:      podsOf(map[string]int{"pg-a": 1, "pg-a1": 4}),
+				Nodes:     []*apiv1.Node{n1},
+				PodGroups: podGroups,
+				Queues:    queues,
+				ExpectBindMap: map[string]string{
+					"ns1/pg-a1-0": "n1", "ns1/pg-a1-1": "n1", "ns1/pg-a1-2": "n1", "ns1/pg-a1-3": "n1",
+				},
+				ExpectBindsNum: 4,
+			},
+			expectDeserved: map[api.QueueID]float64{"a": 4000, "b": 0, "a1": 4000, "a2": 0},
+		},
+	}
+
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledHierarchy:   &trueValue,
+					EnabledQueueOrder:  &trueValue,
+					EnabledAllocatable: &trueValue,
+					EnabledOverused:    &trueValue,
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+
+			for queue, deserved := range test.expectDeserved {
+				if got := plugin.queueOpts[queue].deserved.MilliCPU; got != deserved {
+					t.Errorf("expected deserved cpu of queue %s %v, got %v", queue, deserved, got)
+				}
+			}
+
+			test.Run([]framework.Action{allocate.New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
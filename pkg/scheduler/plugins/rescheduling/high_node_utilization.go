@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// HighNodeUtilizationConf is the configuration of the highNodeUtilization strategy, which evicts
+// the pods of the underutilized nodes so that they are compacted onto fewer nodes.
+type HighNodeUtilizationConf struct {
+	// Thresholds are the utilization in percent under which, in all the resources, a node is
+	// underutilized
+	Thresholds map[string]float64 `mapstructure:"thresholds"`
+}
+
+// NewHighNodeUtilizationConf returns the pointer of HighNodeUtilizationConf object with default value
+func NewHighNodeUtilizationConf() *HighNodeUtilizationConf {
+	return &HighNodeUtilizationConf{
+		Thresholds: map[string]float64{"cpu": 20, "memory": 20},
+	}
+}
+
+var victimsFnForHnu = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := NewHighNodeUtilizationConf()
+	if !decodeStrategyParams("highNodeUtilization", config) {
+		return victims
+	}
+
+	// group the nodes into the underutilized ones, which are emptied, and the other schedulable ones
+	var lowNodes []*NodeUtilization
+	free := api.EmptyResource()
+	for _, usage := range getNodeUtilization() {
+		if usage.nodeInfo.Spec.Unschedulable {
+			continue
+		}
+		if isUnderutilized(usage, config) {
+			lowNodes = append(lowNodes, usage)
+			continue
+		}
+		if nodeInfo, found := Session.Nodes[usage.nodeInfo.Name]; found {
+			free.Add(nodeInfo.Idle)
+		}
+	}
+	if len(lowNodes) == 0 {
+		klog.V(4).Infof("No node is underutilized")
+		return victims
+	}
+	if free.IsEmpty() {
+		klog.V(4).Infof("No node can take the pods of the underutilized nodes")
+		return victims
+	}
+
+	// empty the least utilized nodes first, as long as the pods fit into the other nodes
+	sort.Slice(lowNodes, func(i, j int) bool {
+		return getScoreForNode(i, lowNodes) < getScoreForNode(j, lowNodes)
+	})
+	index := tasksByPod(tasks)
+	for _, node := range lowNodes {
+		sortPods(node.pods)
+		for _, pod := range node.pods {
+			task, found := index[pod.UID]
+			if !found {
+				continue
+			}
+			if !task.Resreq.LessEqual(free, api.Zero) {
+				klog.V(4).Infof("The other nodes are full, stop evicting pods from underutilized nodes")
+				return victims
+			}
+			free.Sub(task.Resreq)
+			victims = append(victims, task)
+		}
+	}
+	klog.V(3).Infof("victims of highNodeUtilization: %v", victims)
+	return victims
+}
+
+// isUnderutilized checks whether the utilization of the node is under the thresholds in all the resources.
+func isUnderutilized(usage *NodeUtilization, config *HighNodeUtilizationConf) bool {
+	for rName, usagePercent := range usage.utilization {
+		if threshold, ok := config.Thresholds[string(rName)]; ok && usagePercent >= threshold {
+			return false
+		}
+	}
+	return true
+}
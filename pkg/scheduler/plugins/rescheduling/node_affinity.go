@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// NodeAffinityConf is the configuration of the removePodsViolatingNodeAffinity strategy, which
+// evicts the pods whose node no longer matches their node selector and required node affinity.
+type NodeAffinityConf struct {
+	// NodeFit only evicts the pods which match another schedulable node
+	NodeFit bool `mapstructure:"nodeFit"`
+}
+
+// NewNodeAffinityConf returns the pointer of NodeAffinityConf object with default value
+func NewNodeAffinityConf() *NodeAffinityConf {
+	return &NodeAffinityConf{NodeFit: true}
+}
+
+var victimsFnForNodeAffinity = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := NewNodeAffinityConf()
+	if !decodeStrategyParams("removePodsViolatingNodeAffinity", config) {
+		return victims
+	}
+
+	for _, task := range tasks {
+		if task.Pod == nil {
+			continue
+		}
+		node, found := Session.Nodes[task.NodeName]
+		if !found || node.Node == nil {
+			continue
+		}
+		affinity := nodeaffinity.GetRequiredNodeAffinity(task.Pod)
+		if match, err := affinity.Match(node.Node); err != nil || match {
+			continue
+		}
+		if config.NodeFit && !matchAnotherNode(affinity, task.NodeName) {
+			klog.V(4).Infof("Task <%s/%s> violates its node affinity, but no other node matches it", task.Namespace, task.Name)
+			continue
+		}
+		victims = append(victims, task)
+	}
+	klog.V(3).Infof("victims of removePodsViolatingNodeAffinity: %v", victims)
+	return victims
+}
+
+// matchAnotherNode checks whether a schedulable node other than the current one matches the affinity.
+func matchAnotherNode(affinity nodeaffinity.RequiredNodeAffinity, current string) bool {
+	for name, node := range Session.Nodes {
+		if name == current || node.Node == nil || node.Node.Spec.Unschedulable {
+			continue
+		}
+		if match, err := affinity.Match(node.Node); err == nil && match {
+			return true
+		}
+	}
+	return false
+}
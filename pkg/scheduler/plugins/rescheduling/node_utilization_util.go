@@ -18,6 +18,7 @@ package rescheduling
 
 import (
 	"sort"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -54,10 +55,16 @@ func groupNodesByUtilization(nodeUtilizationList []*NodeUtilization, lowThreshol
 	return lowNodes, highNodes
 }
 
-// getNodeUtilization returns all node resource utilization list
+// getNodeUtilization returns all node resource utilization list, the nodes without fresh metrics
+// are skipped so that they are neither evicted nor chosen as targets based on outdated usage
 func getNodeUtilization() []*NodeUtilization {
 	nodeUtilizationList := make([]*NodeUtilization, 0)
+	now := time.Now()
 	for _, nodeInfo := range Session.Nodes {
+		if nodeInfo.ResourceUsage == nil || now.Sub(nodeInfo.ResourceUsage.MetricsTime) > MetricsActiveTime {
+			klog.V(4).Infof("The metrics of node %s are missing or stale, it is skipped", nodeInfo.Name)
+			continue
+		}
 		nodeUtilization := &NodeUtilization{
 			nodeInfo: nodeInfo.Node,
 			utilization: map[v1.ResourceName]float64{
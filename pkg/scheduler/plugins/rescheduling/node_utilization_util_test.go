@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestGetNodeUtilizationSkipsStaleMetrics(t *testing.T) {
+	buildNodeInfo := func(name string, metricsTime time.Time) *api.NodeInfo {
+		nodeInfo := api.NewNodeInfo(util.BuildNode(name, api.BuildResourceList("4", "8Gi"), nil))
+		nodeInfo.ResourceUsage = &api.NodeUsage{
+			MetricsTime: metricsTime,
+			CPUUsageAvg: map[string]float64{MetricsPeriod: 80},
+			MEMUsageAvg: map[string]float64{MetricsPeriod: 70},
+		}
+		return nodeInfo
+	}
+
+	Session = &framework.Session{Nodes: map[string]*api.NodeInfo{
+		"fresh":   buildNodeInfo("fresh", time.Now().Add(-time.Minute)),
+		"stale":   buildNodeInfo("stale", time.Now().Add(-MetricsActiveTime-time.Minute)),
+		"missing": buildNodeInfo("missing", time.Time{}),
+	}}
+	defer func() { Session = nil }()
+
+	nodeUtilizationList := getNodeUtilization()
+	if len(nodeUtilizationList) != 1 || nodeUtilizationList[0].nodeInfo.Name != "fresh" {
+		t.Fatalf("expected only the node with fresh metrics, got %v", nodeUtilizationList)
+	}
+	if cpu := nodeUtilizationList[0].utilization[v1.ResourceCPU]; cpu != 80 {
+		t.Errorf("expected cpu utilization 80, got %v", cpu)
+	}
+}
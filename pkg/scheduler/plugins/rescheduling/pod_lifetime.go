@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// PodLifeTimeConf is the configuration of the podLifeTime strategy, which evicts the pods running
+// for longer than the max lifetime.
+type PodLifeTimeConf struct {
+	MaxPodLifeTimeSeconds int64 `mapstructure:"maxPodLifeTimeSeconds"`
+}
+
+// NewPodLifeTimeConf returns the pointer of PodLifeTimeConf object with default value
+func NewPodLifeTimeConf() *PodLifeTimeConf {
+	return &PodLifeTimeConf{
+		MaxPodLifeTimeSeconds: int64((7 * 24 * time.Hour).Seconds()),
+	}
+}
+
+var victimsFnForPodLifeTime = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := NewPodLifeTimeConf()
+	if !decodeStrategyParams("podLifeTime", config) {
+		return victims
+	}
+	if config.MaxPodLifeTimeSeconds <= 0 {
+		klog.Errorf("Invalid maxPodLifeTimeSeconds %d of podLifeTime", config.MaxPodLifeTimeSeconds)
+		return victims
+	}
+
+	maxLifeTime := time.Duration(config.MaxPodLifeTimeSeconds) * time.Second
+	now := time.Now()
+	for _, task := range tasks {
+		if task.Pod == nil {
+			continue
+		}
+		startTime := task.Pod.CreationTimestamp.Time
+		if task.Pod.Status.StartTime != nil {
+			startTime = task.Pod.Status.StartTime.Time
+		}
+		if now.Sub(startTime) > maxLifeTime {
+			victims = append(victims, task)
+		}
+	}
+	klog.V(3).Infof("victims of podLifeTime: %v", victims)
+	return victims
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// RemoveDuplicatesConf is the configuration of the removeDuplicates strategy, which evicts the
+// pods of the same owner and the same images running on the same node, but one.
+type RemoveDuplicatesConf struct {
+	// ExcludeOwnerKinds are the kinds of the owners whose pods are never duplicates, e.g. "Job"
+	ExcludeOwnerKinds []string `mapstructure:"excludeOwnerKinds"`
+}
+
+// NewRemoveDuplicatesConf returns the pointer of RemoveDuplicatesConf object with default value
+func NewRemoveDuplicatesConf() *RemoveDuplicatesConf {
+	return &RemoveDuplicatesConf{}
+}
+
+var victimsFnForRemoveDuplicates = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := NewRemoveDuplicatesConf()
+	if !decodeStrategyParams("removeDuplicates", config) {
+		return victims
+	}
+
+	duplicates := map[string][]*api.TaskInfo{}
+	for _, task := range tasks {
+		if task.Pod == nil || task.NodeName == "" {
+			continue
+		}
+		owner := metav1.GetControllerOf(task.Pod)
+		if owner == nil || contains(config.ExcludeOwnerKinds, owner.Kind) {
+			continue
+		}
+		key := strings.Join([]string{task.NodeName, task.Namespace, owner.Kind, owner.Name, podImages(task.Pod)}, "/")
+		duplicates[key] = append(duplicates[key], task)
+	}
+
+	// keep the pod of the highest priority and QoS of every owner on every node
+	keys := make([]string, 0, len(duplicates))
+	for key := range duplicates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		group := duplicates[key]
+		if len(group) < 2 {
+			continue
+		}
+		pods := make([]*v1.Pod, 0, len(group))
+		index := tasksByPod(group)
+		for _, task := range group {
+			pods = append(pods, task.Pod)
+		}
+		sortPods(pods)
+		for _, pod := range pods[:len(pods)-1] {
+			victims = append(victims, index[pod.UID])
+		}
+	}
+	klog.V(3).Infof("victims of removeDuplicates: %v", victims)
+	return victims
+}
+
+// podImages returns the sorted images of the containers of the pod.
+func podImages(pod *v1.Pod) string {
+	images := make([]string, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	sort.Strings(images)
+	return strings.Join(images, ",")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -24,6 +24,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 )
 
@@ -36,6 +37,9 @@ const (
 	DefaultMetricsPeriod = "5m"
 	// DefaultStrategy indicates the default strategy rescheduling plugin making use of
 	DefaultStrategy = "lowNodeUtilization"
+	// MetricsActiveTime is the longest time the metrics of a node are used after they are collected,
+	// the nodes with older metrics are ignored by the strategies based on the node utilization
+	MetricsActiveTime = 5 * time.Minute
 )
 
 var (
@@ -59,6 +63,11 @@ func init() {
 
 	// register victim functions for all strategies here
 	VictimFn["lowNodeUtilization"] = victimsFnForLnu
+	VictimFn["highNodeUtilization"] = victimsFnForHnu
+	VictimFn["podLifeTime"] = victimsFnForPodLifeTime
+	VictimFn["removeDuplicates"] = victimsFnForRemoveDuplicates
+	VictimFn["removePodsViolatingTopologySpreadConstraint"] = victimsFnForTopologySpread
+	VictimFn["removePodsViolatingNodeAffinity"] = victimsFnForNodeAffinity
 }
 
 type reschedulingPlugin struct {
@@ -100,14 +109,34 @@ func (rp *reschedulingPlugin) OnSessionOpen(ssn *framework.Session) {
 	}
 
 	// Get all strategies and register the victim functions for each strategy.
-	victimFns := make([]api.VictimTasksFn, 0)
+	strategies := make([]string, 0)
 	for _, strategy := range configs.strategies {
 		if VictimFn[strategy.Name] != nil {
 			klog.V(4).Infof("strategy: %s\n", strategy.Name)
-			victimFns = append(victimFns, VictimFn[strategy.Name])
+			strategies = append(strategies, strategy.Name)
+		} else {
+			klog.Warningf("Unknown rescheduling strategy %s", strategy.Name)
 		}
 	}
-	ssn.AddVictimTasksFns(rp.Name(), victimFns)
+
+	// The strategies share the disruption budgets of the session: a victim selected by a strategy
+	// reduces the evictions left for the next ones.
+	ssn.AddVictimTasksFns(rp.Name(), []api.VictimTasksFn{func(tasks []*api.TaskInfo) []*api.TaskInfo {
+		filter := newVictimFilter(ssn, listPodDisruptionBudgets(ssn))
+		victims := make([]*api.TaskInfo, 0)
+		for _, strategy := range strategies {
+			for _, victim := range filter.filter(VictimFn[strategy](tasks)) {
+				ssn.AddVictimReason(victim, PluginName+"/"+strategy)
+				victims = append(victims, victim)
+			}
+		}
+		if configs.dryRun {
+			// the victims are reported here, so that they are not evicted by any action
+			ssn.ReportDryRunVictims(PluginName, victims)
+			return nil
+		}
+		return victims
+	}})
 }
 
 func (rp *reschedulingPlugin) OnSessionClose(ssn *framework.Session) {
@@ -121,6 +150,8 @@ func (rp *reschedulingPlugin) OnSessionClose(ssn *framework.Session) {
 type Configs struct {
 	interval   time.Duration
 	strategies []Strategy
+	// dryRun only reports the victims of the strategies instead of evicting them
+	dryRun bool
 }
 
 // Strategy is the struct for rescheduling strategy
@@ -156,6 +187,7 @@ func (rc *Configs) parseArguments(arguments framework.Arguments) {
 		klog.V(4).Infof("Parse rescheduling interval failed. Reset the interval to 5m by default.")
 		rc.interval = DefaultInterval
 	}
+	arguments.GetBool(&rc.dryRun, conf.DryRunKey)
 	if metricsPeriodArg, ok := arguments["metricsPeriod"]; ok {
 		MetricsPeriod = metricsPeriodArg.(string)
 	}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/actions/shuffle"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestReschedulingDryRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		dryRun        bool
+		expectEvicted []string
+		expectReports int
+	}{
+		{
+			name:          "old pods are evicted",
+			expectEvicted: []string{"c1/old"},
+		},
+		{
+			name:          "old pods are only reported in dry-run mode",
+			dryRun:        true,
+			expectEvicted: []string{},
+			expectReports: 1,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			old := util.BuildPod("c1", "old", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+			old.Status.StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			young := util.BuildPod("c1", "young", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+			young.Status.StartTime = &metav1.Time{Time: time.Now()}
+
+			uttest := uthelper.TestCommonStruct{
+				Name:    test.name,
+				Plugins: map[string]framework.PluginBuilder{PluginName: New},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+				},
+				Queues: []*schedulingv1beta1.Queue{util.BuildQueue("q1", 1, nil)},
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroup("pg1", "c1", "q1", 0, nil, schedulingv1beta1.PodGroupRunning),
+				},
+				Pods:           []*v1.Pod{old, young},
+				ExpectEvictNum: len(test.expectEvicted),
+				ExpectEvicted:  test.expectEvicted,
+			}
+
+			trueValue := true
+			tiers := []conf.Tier{{Plugins: []conf.PluginOption{{
+				Name:          PluginName,
+				EnabledVictim: &trueValue,
+				Arguments: framework.Arguments{
+					conf.DryRunKey: test.dryRun,
+					"strategies": []interface{}{
+						map[string]interface{}{
+							"name":   "podLifeTime",
+							"params": map[string]interface{}{"maxPodLifeTimeSeconds": 3600},
+						},
+					},
+				},
+			}}}}
+
+			lastRescheduleTime = time.Time{}
+			recorder := framework.NewDryRunRecorder(1)
+			ssn := uttest.RegisterSession(tiers, nil)
+			defer uttest.Close()
+			ssn.SetDryRunRecorder(recorder)
+			uttest.Run([]framework.Action{shuffle.New()})
+			if err := uttest.CheckEvict(i); err != nil {
+				t.Fatal(err)
+			}
+
+			reports := recorder.Reports()
+			assert.Len(t, reports, test.expectReports)
+			if test.dryRun {
+				assert.Equal(t, []framework.DryRunVictim{{
+					Namespace: "c1", Name: "old", Job: "c1/pg1", Queue: "q1", Node: "n1",
+					Reasons: []string{"rescheduling/podLifeTime"},
+				}}, reports[0].Victims)
+			}
+		})
+	}
+}
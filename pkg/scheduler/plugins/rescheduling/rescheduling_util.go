@@ -16,7 +16,15 @@ limitations under the License.
 
 package rescheduling
 
-import "time"
+import (
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
 
 // lastRescheduleTime records the last execution time.
 var lastRescheduleTime time.Time
@@ -34,3 +42,28 @@ func timeToRun(interval time.Duration) bool {
 	}
 	return false
 }
+
+// decodeStrategyParams decodes the params of the strategy into config, which holds the default
+// values. It returns false if the params are invalid.
+func decodeStrategyParams(strategy string, config interface{}) bool {
+	params, ok := RegisteredStrategyConfigs[strategy]
+	if !ok || params == nil {
+		return true
+	}
+	if err := mapstructure.WeakDecode(params, config); err != nil {
+		klog.Errorf("parameters parse error for %s: %v", strategy, err)
+		return false
+	}
+	return true
+}
+
+// tasksByPod indexes the tasks by the uid of their pods.
+func tasksByPod(tasks []*api.TaskInfo) map[types.UID]*api.TaskInfo {
+	index := make(map[types.UID]*api.TaskInfo, len(tasks))
+	for _, task := range tasks {
+		if task.Pod != nil {
+			index[task.Pod.UID] = task
+		}
+	}
+	return index
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// testCluster builds the session of the strategies from the nodes and the running pods.
+func testCluster(nodes []*v1.Node, pods []*v1.Pod) []*api.TaskInfo {
+	nodeInfos := map[string]*api.NodeInfo{}
+	for _, node := range nodes {
+		nodeInfo := api.NewNodeInfo(node)
+		nodeInfo.ResourceUsage = &api.NodeUsage{
+			MetricsTime: time.Now(),
+			CPUUsageAvg: map[string]float64{MetricsPeriod: 0},
+			MEMUsageAvg: map[string]float64{MetricsPeriod: 0},
+		}
+		nodeInfos[node.Name] = nodeInfo
+	}
+	tasks := make([]*api.TaskInfo, 0, len(pods))
+	for _, pod := range pods {
+		task := api.NewTaskInfo(pod)
+		if nodeInfo, found := nodeInfos[pod.Spec.NodeName]; found {
+			_ = nodeInfo.AddTask(task)
+		}
+		tasks = append(tasks, task)
+	}
+	Session = &framework.Session{Nodes: nodeInfos}
+	return tasks
+}
+
+func victimNames(victims []*api.TaskInfo) []string {
+	names := make([]string, 0, len(victims))
+	for _, victim := range victims {
+		names = append(names, victim.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func setStrategyParams(strategy string, params map[string]interface{}) {
+	for k := range RegisteredStrategyConfigs {
+		delete(RegisteredStrategyConfigs, k)
+	}
+	RegisteredStrategyConfigs[strategy] = params
+}
+
+func TestHighNodeUtilization(t *testing.T) {
+	defer func() { Session = nil }()
+	tasks := testCluster(
+		[]*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			util.BuildNode("n2", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+			util.BuildNode("n3", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+		},
+		[]*v1.Pod{
+			util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+			util.BuildPod("c1", "p2", "n2", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg1", nil, nil),
+			util.BuildPod("c1", "p3", "n3", v1.PodRunning, api.BuildResourceList("3", "6Gi"), "pg1", nil, nil),
+		},
+	)
+	Session.Nodes["n1"].ResourceUsage.CPUUsageAvg[MetricsPeriod] = 10
+	Session.Nodes["n2"].ResourceUsage.CPUUsageAvg[MetricsPeriod] = 15
+	Session.Nodes["n3"].ResourceUsage.CPUUsageAvg[MetricsPeriod] = 70
+	setStrategyParams("highNodeUtilization", map[string]interface{}{
+		"thresholds": map[interface{}]interface{}{"cpu": 20, "memory": 20},
+	})
+
+	// n1 and n2 are underutilized, but only the pod of n1 fits into n3
+	assert.Equal(t, []string{"p1"}, victimNames(victimsFnForHnu(tasks)))
+}
+
+func TestPodLifeTime(t *testing.T) {
+	defer func() { Session = nil }()
+	old := util.BuildPod("c1", "old", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+	old.Status.StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	young := util.BuildPod("c1", "young", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+	young.Status.StartTime = &metav1.Time{Time: time.Now()}
+	tasks := testCluster([]*v1.Node{util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), nil)}, []*v1.Pod{old, young})
+
+	setStrategyParams("podLifeTime", map[string]interface{}{"maxPodLifeTimeSeconds": 3600})
+	assert.Equal(t, []string{"old"}, victimNames(victimsFnForPodLifeTime(tasks)))
+
+	setStrategyParams("podLifeTime", map[string]interface{}{"maxPodLifeTimeSeconds": "invalid"})
+	assert.Empty(t, victimsFnForPodLifeTime(tasks))
+}
+
+func TestRemoveDuplicates(t *testing.T) {
+	defer func() { Session = nil }()
+	buildOwnedPod := func(name, node, kind string) *v1.Pod {
+		pod := util.BuildPod("c1", name, node, v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: "owner", Controller: &controller}}
+		return pod
+	}
+	tasks := testCluster(
+		[]*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), nil),
+			util.BuildNode("n2", api.BuildResourceList("4", "8Gi"), nil),
+		},
+		[]*v1.Pod{
+			buildOwnedPod("rs-1", "n1", "ReplicaSet"),
+			buildOwnedPod("rs-2", "n1", "ReplicaSet"),
+			buildOwnedPod("rs-3", "n1", "ReplicaSet"),
+			buildOwnedPod("rs-4", "n2", "ReplicaSet"),
+			buildOwnedPod("job-1", "n1", "Job"),
+			buildOwnedPod("job-2", "n1", "Job"),
+		},
+	)
+
+	setStrategyParams("removeDuplicates", map[string]interface{}{"excludeOwnerKinds": []interface{}{"Job"}})
+	assert.Len(t, victimNames(victimsFnForRemoveDuplicates(tasks)), 2)
+	for _, victim := range victimsFnForRemoveDuplicates(tasks) {
+		assert.Equal(t, "n1", victim.NodeName)
+		assert.Contains(t, []string{"rs-1", "rs-2", "rs-3"}, victim.Name)
+	}
+}
+
+func TestTopologySpread(t *testing.T) {
+	defer func() { Session = nil }()
+	labels := map[string]string{"app": "web"}
+	buildSpreadPod := func(name, node string) *v1.Pod {
+		pod := util.BuildPod("c1", name, node, v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", labels, nil)
+		pod.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "zone",
+			WhenUnsatisfiable: v1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: labels},
+		}}
+		return pod
+	}
+	tasks := testCluster(
+		[]*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("8", "8Gi"), map[string]string{"zone": "a"}),
+			util.BuildNode("n2", api.BuildResourceList("8", "8Gi"), map[string]string{"zone": "b"}),
+			util.BuildNode("n3", api.BuildResourceList("8", "8Gi"), map[string]string{"zone": "c"}),
+		},
+		[]*v1.Pod{
+			buildSpreadPod("p1", "n1"),
+			buildSpreadPod("p2", "n1"),
+			buildSpreadPod("p3", "n1"),
+			buildSpreadPod("p4", "n1"),
+			buildSpreadPod("p5", "n2"),
+		},
+	)
+	setStrategyParams("removePodsViolatingTopologySpreadConstraint", nil)
+
+	// 4/1/0 is balanced to 2/1/1 by evicting 2 pods of zone a
+	victims := victimsFnForTopologySpread(tasks)
+	assert.Len(t, victims, 2)
+	for _, victim := range victims {
+		assert.Equal(t, "n1", victim.NodeName)
+	}
+
+	// soft constraints are ignored by default
+	for _, task := range tasks {
+		task.Pod.Spec.TopologySpreadConstraints[0].WhenUnsatisfiable = v1.ScheduleAnyway
+	}
+	assert.Empty(t, victimsFnForTopologySpread(tasks))
+	setStrategyParams("removePodsViolatingTopologySpreadConstraint", map[string]interface{}{"includeSoftConstraints": true})
+	assert.Len(t, victimsFnForTopologySpread(tasks), 2)
+}
+
+func TestNodeAffinity(t *testing.T) {
+	defer func() { Session = nil }()
+	tasks := testCluster(
+		[]*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("4", "8Gi"), map[string]string{"disk": "hdd"}),
+			util.BuildNode("n2", api.BuildResourceList("4", "8Gi"), map[string]string{"disk": "ssd"}),
+		},
+		[]*v1.Pod{
+			util.BuildPod("c1", "violating", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, map[string]string{"disk": "ssd"}),
+			util.BuildPod("c1", "nowhere", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, map[string]string{"disk": "nvme"}),
+			util.BuildPod("c1", "matching", "n2", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, map[string]string{"disk": "ssd"}),
+		},
+	)
+
+	setStrategyParams("removePodsViolatingNodeAffinity", nil)
+	assert.Equal(t, []string{"violating"}, victimNames(victimsFnForNodeAffinity(tasks)))
+
+	setStrategyParams("removePodsViolatingNodeAffinity", map[string]interface{}{"nodeFit": false})
+	assert.Equal(t, []string{"nowhere", "violating"}, victimNames(victimsFnForNodeAffinity(tasks)))
+}
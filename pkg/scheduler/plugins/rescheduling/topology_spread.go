@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// TopologySpreadConf is the configuration of the removePodsViolatingTopologySpreadConstraint
+// strategy, which evicts pods from the most crowded topology domains until the skew of the
+// topology spread constraints is satisfied.
+type TopologySpreadConf struct {
+	// IncludeSoftConstraints also balances the constraints with ScheduleAnyway
+	IncludeSoftConstraints bool `mapstructure:"includeSoftConstraints"`
+}
+
+// NewTopologySpreadConf returns the pointer of TopologySpreadConf object with default value
+func NewTopologySpreadConf() *TopologySpreadConf {
+	return &TopologySpreadConf{}
+}
+
+// topologyDomain is the pods matching a constraint in a topology domain
+type topologyDomain struct {
+	value string
+	pods  []*v1.Pod
+	// count is the number of pods after the evictions selected
+	count int
+}
+
+var victimsFnForTopologySpread = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0)
+
+	config := NewTopologySpreadConf()
+	if !decodeStrategyParams("removePodsViolatingTopologySpreadConstraint", config) {
+		return victims
+	}
+
+	index := tasksByPod(tasks)
+	selected := map[*api.TaskInfo]bool{}
+	checked := map[string]bool{}
+	for _, task := range tasks {
+		if task.Pod == nil {
+			continue
+		}
+		for _, constraint := range task.Pod.Spec.TopologySpreadConstraints {
+			if constraint.WhenUnsatisfiable != v1.DoNotSchedule && !config.IncludeSoftConstraints {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+			if err != nil {
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%s/%d", task.Namespace, constraint.TopologyKey, selector.String(), constraint.MaxSkew)
+			if checked[key] {
+				continue
+			}
+			checked[key] = true
+
+			for _, victim := range balanceDomains(task.Namespace, constraint, selector, index, selected) {
+				selected[victim] = true
+				victims = append(victims, victim)
+			}
+		}
+	}
+	klog.V(3).Infof("victims of removePodsViolatingTopologySpreadConstraint: %v", victims)
+	return victims
+}
+
+// balanceDomains returns the victims to evict from the most crowded domains of the constraint, until
+// the difference with the least crowded domain is not more than the max skew.
+func balanceDomains(namespace string, constraint v1.TopologySpreadConstraint, selector labels.Selector,
+	index map[types.UID]*api.TaskInfo, selected map[*api.TaskInfo]bool) []*api.TaskInfo {
+	domainsByValue := map[string]*topologyDomain{}
+	for _, node := range Session.Nodes {
+		if node.Node == nil {
+			continue
+		}
+		value, found := node.Node.Labels[constraint.TopologyKey]
+		if !found {
+			continue
+		}
+		domain, found := domainsByValue[value]
+		if !found {
+			domain = &topologyDomain{value: value}
+			domainsByValue[value] = domain
+		}
+		for _, pod := range node.Pods() {
+			if pod.Namespace == namespace && pod.DeletionTimestamp == nil && selector.Matches(labels.Set(pod.Labels)) {
+				domain.pods = append(domain.pods, pod)
+			}
+		}
+	}
+	if len(domainsByValue) < 2 {
+		return nil
+	}
+	domains := make([]*topologyDomain, 0, len(domainsByValue))
+	for _, domain := range domainsByValue {
+		domain.count = len(domain.pods)
+		sortPods(domain.pods)
+		domains = append(domains, domain)
+	}
+
+	var victims []*api.TaskInfo
+	for {
+		sort.Slice(domains, func(i, j int) bool {
+			if domains[i].count != domains[j].count {
+				return domains[i].count < domains[j].count
+			}
+			return domains[i].value < domains[j].value
+		})
+		smallest, largest := domains[0], domains[len(domains)-1]
+		if largest.count-smallest.count <= int(constraint.MaxSkew) {
+			return victims
+		}
+		victim := popVictim(largest, index, selected)
+		if victim == nil {
+			// nothing can be evicted from the most crowded domain
+			return victims
+		}
+		victims = append(victims, victim)
+		largest.count--
+		smallest.count++
+	}
+}
+
+// popVictim removes and returns the task of the pod of the lowest priority of the domain which can be evicted.
+func popVictim(domain *topologyDomain, index map[types.UID]*api.TaskInfo, selected map[*api.TaskInfo]bool) *api.TaskInfo {
+	for len(domain.pods) > 0 {
+		pod := domain.pods[0]
+		domain.pods = domain.pods[1:]
+		if task, found := index[pod.UID]; found && !selected[task] {
+			return task
+		}
+	}
+	return nil
+}
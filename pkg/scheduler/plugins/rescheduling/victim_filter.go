@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sync"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/features"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// pdbsDisabledOnce logs only once that the PodDisruptionBudgets are not considered.
+var pdbsDisabledOnce sync.Once
+
+// victimFilter drops the victims whose eviction would break a PodDisruptionBudget, the
+// minAvailable of their job or the guarantee of their queue. The budgets are consumed by the
+// victims accepted, so that the victims of all the strategies respect them together.
+type victimFilter struct {
+	pdbs        []*policyv1.PodDisruptionBudget
+	pdbsAllowed []int32
+
+	jobs           map[api.JobID]*api.JobInfo
+	jobsReady      map[api.JobID]int32
+	taskRolesReady map[api.JobID]map[string]int32
+
+	queues          map[api.QueueID]*api.QueueInfo
+	queuesAllocated map[api.QueueID]*api.Resource
+
+	selected map[api.TaskID]bool
+}
+
+func newVictimFilter(ssn *framework.Session, pdbs []*policyv1.PodDisruptionBudget) *victimFilter {
+	vf := &victimFilter{
+		pdbs:            pdbs,
+		pdbsAllowed:     make([]int32, len(pdbs)),
+		jobs:            ssn.Jobs,
+		jobsReady:       make(map[api.JobID]int32, len(ssn.Jobs)),
+		taskRolesReady:  make(map[api.JobID]map[string]int32, len(ssn.Jobs)),
+		queues:          ssn.Queues,
+		queuesAllocated: make(map[api.QueueID]*api.Resource, len(ssn.Queues)),
+		selected:        map[api.TaskID]bool{},
+	}
+	for i, pdb := range pdbs {
+		vf.pdbsAllowed[i] = pdb.Status.DisruptionsAllowed
+	}
+	for _, job := range ssn.Jobs {
+		vf.jobsReady[job.UID] = job.ReadyTaskNum()
+		roles := map[string]int32{}
+		for _, task := range job.Tasks {
+			if api.AllocatedStatus(task.Status) || task.Status == api.Succeeded {
+				roles[task.TaskRole]++
+			}
+		}
+		vf.taskRolesReady[job.UID] = roles
+
+		allocated, found := vf.queuesAllocated[job.Queue]
+		if !found {
+			allocated = api.EmptyResource()
+			vf.queuesAllocated[job.Queue] = allocated
+		}
+		if job.Allocated != nil {
+			allocated.Add(job.Allocated)
+		}
+	}
+	return vf
+}
+
+// filter returns the victims which can be evicted within the budgets left, the victims already
+// accepted by a previous call are not returned again.
+func (vf *victimFilter) filter(victims []*api.TaskInfo) []*api.TaskInfo {
+	result := make([]*api.TaskInfo, 0, len(victims))
+	for _, victim := range victims {
+		if vf.selected[victim.UID] {
+			continue
+		}
+		if !vf.jobAllows(victim) {
+			klog.V(4).Infof("Evicting task <%s/%s> breaks the minAvailable of job %s, it is not a victim", victim.Namespace, victim.Name, victim.Job)
+			continue
+		}
+		if !vf.queueAllows(victim) {
+			klog.V(4).Infof("Evicting task <%s/%s> breaks the guarantee of its queue, it is not a victim", victim.Namespace, victim.Name)
+			continue
+		}
+		matched, allowed := vf.pdbsOf(victim)
+		if !allowed {
+			klog.V(4).Infof("Evicting task <%s/%s> violates a PodDisruptionBudget, it is not a victim", victim.Namespace, victim.Name)
+			continue
+		}
+
+		for _, i := range matched {
+			vf.pdbsAllowed[i]--
+		}
+		vf.jobsReady[victim.Job]--
+		if roles, found := vf.taskRolesReady[victim.Job]; found {
+			roles[victim.TaskRole]--
+		}
+		if job, found := vf.jobs[victim.Job]; found {
+			vf.queuesAllocated[job.Queue].SubWithoutAssert(victim.Resreq)
+		}
+		vf.selected[victim.UID] = true
+		result = append(result, victim)
+	}
+	return result
+}
+
+// jobAllows checks that the job keeps its minAvailable, and the minAvailable of the role of the
+// task, after the eviction.
+func (vf *victimFilter) jobAllows(task *api.TaskInfo) bool {
+	job, found := vf.jobs[task.Job]
+	if !found {
+		return true
+	}
+	if job.MinAvailable > 0 && vf.jobsReady[job.UID]-1 < job.MinAvailable {
+		return false
+	}
+	if minAvailable, found := job.TaskMinAvailable[task.TaskRole]; found && minAvailable > 0 {
+		if vf.taskRolesReady[job.UID][task.TaskRole]-1 < minAvailable {
+			return false
+		}
+	}
+	return true
+}
+
+// queueAllows checks that the queue keeps its guarantee, in the dimensions requested by the task,
+// after the eviction.
+func (vf *victimFilter) queueAllows(task *api.TaskInfo) bool {
+	job, found := vf.jobs[task.Job]
+	if !found {
+		return true
+	}
+	queue, found := vf.queues[job.Queue]
+	if !found || queue.Queue == nil || len(queue.Queue.Spec.Guarantee.Resource) == 0 {
+		return true
+	}
+	guarantee := api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+	allocated := vf.queuesAllocated[job.Queue]
+	for _, name := range guarantee.ResourceNames() {
+		request := task.Resreq.Get(name)
+		if request <= 0 {
+			continue
+		}
+		if allocated.Get(name)-request < guarantee.Get(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// pdbsOf returns the indexes of the PodDisruptionBudgets matching the pod of the task, and whether
+// all of them allow one more disruption.
+func (vf *victimFilter) pdbsOf(task *api.TaskInfo) ([]int, bool) {
+	pod := task.Pod
+	if pod == nil || len(pod.Labels) == 0 {
+		return nil, true
+	}
+	var matched []int
+	for i, pdb := range vf.pdbs {
+		if pdb.Namespace != pod.Namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		// A PDB with a nil or empty selector matches nothing.
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		// the pod is already being disrupted, it does not consume the budget again
+		if _, found := pdb.Status.DisruptedPods[pod.Name]; found {
+			continue
+		}
+		if vf.pdbsAllowed[i] <= 0 {
+			return nil, false
+		}
+		matched = append(matched, i)
+	}
+	return matched, true
+}
+
+// listPodDisruptionBudgets returns all the PodDisruptionBudgets of the cluster, they are not
+// watched by the scheduler cache unless the PodDisruptionBudgetsSupport feature is enabled.
+func listPodDisruptionBudgets(ssn *framework.Session) []*policyv1.PodDisruptionBudget {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.PodDisruptionBudgetsSupport) {
+		pdbsDisabledOnce.Do(func() {
+			klog.V(3).Infof("The %s feature is disabled, victims are not checked against PodDisruptionBudgets",
+				features.PodDisruptionBudgetsSupport)
+		})
+		return nil
+	}
+	informerFactory := ssn.InformerFactory()
+	if informerFactory == nil {
+		return nil
+	}
+	pdbs, err := informerFactory.Policy().V1().PodDisruptionBudgets().Lister().List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list PodDisruptionBudgets, victims are not checked against them: %v", err)
+		return nil
+	}
+	return pdbs
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestVictimFilter(t *testing.T) {
+	buildTask := func(name, group string, labels map[string]string) *api.TaskInfo {
+		pod := util.BuildPod("c1", name, "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), group, labels, nil)
+		return api.NewTaskInfo(pod)
+	}
+	// gang: 3 tasks, minAvailable 2
+	gang := []*api.TaskInfo{buildTask("gang-1", "gang", nil), buildTask("gang-2", "gang", nil), buildTask("gang-3", "gang", nil)}
+	// guaranteed: 2 tasks in a queue guaranteed 1 cpu
+	guaranteed := []*api.TaskInfo{buildTask("guaranteed-1", "guaranteed", nil), buildTask("guaranteed-2", "guaranteed", nil)}
+	// budget: 3 tasks covered by a PDB allowing 1 disruption
+	web := map[string]string{"app": "web"}
+	budget := []*api.TaskInfo{buildTask("web-1", "web", web), buildTask("web-2", "web", web), buildTask("web-3", "web", web)}
+
+	gangJob := api.NewJobInfo("c1/gang", gang...)
+	gangJob.MinAvailable = 2
+	gangJob.Queue = "q1"
+	guaranteedJob := api.NewJobInfo("c1/guaranteed", guaranteed...)
+	guaranteedJob.Queue = "q2"
+	webJob := api.NewJobInfo("c1/web", budget...)
+	webJob.Queue = "q1"
+
+	q1 := &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q1"}}
+	q2 := &scheduling.Queue{ObjectMeta: metav1.ObjectMeta{Name: "q2"}}
+	q2.Spec.Guarantee = scheduling.Guarantee{Resource: api.BuildResourceList("1", "1Gi")}
+	ssn := &framework.Session{
+		Jobs: map[api.JobID]*api.JobInfo{gangJob.UID: gangJob, guaranteedJob.UID: guaranteedJob, webJob.UID: webJob},
+		Queues: map[api.QueueID]*api.QueueInfo{
+			"q1": api.NewQueueInfo(q1),
+			"q2": api.NewQueueInfo(q2),
+		},
+	}
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "web"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: web}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+	}
+
+	filter := newVictimFilter(ssn, []*policyv1.PodDisruptionBudget{pdb})
+	// the first strategy selects all the tasks, one task of every job can be evicted
+	victims := filter.filter(append(append(append([]*api.TaskInfo{}, gang...), guaranteed...), budget...))
+	assert.Equal(t, []string{"gang-1", "guaranteed-1", "web-1"}, victimNames(victims))
+
+	// the next strategy shares the budgets, and the victims already selected are not returned again
+	assert.Empty(t, filter.filter([]*api.TaskInfo{gang[0], gang[1], guaranteed[1], budget[2]}))
+}
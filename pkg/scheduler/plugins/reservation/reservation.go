@@ -0,0 +1,314 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "reservation"
+
+	// WaitTimeKey is the argument of how long a job waits before it is starving and may be the target job.
+	WaitTimeKey = "reservation.waitTime"
+	// LockTimeoutKey is the argument of how long the nodes are locked for the target job at most.
+	LockTimeoutKey = "reservation.lockTimeout"
+
+	// PodGroupReserved is the condition of the podgroup of the target job.
+	PodGroupReserved scheduling.PodGroupConditionType = "Reserved"
+
+	// NodesLockedReason is the reason of the condition while the nodes are locked for the target job.
+	NodesLockedReason = "NodesLocked"
+	// ReservationSucceededReason is the reason of the condition when the target job got ready on the locked nodes.
+	ReservationSucceededReason = "Succeeded"
+	// ReservationTimeoutReason is the reason of the condition when the lock timed out.
+	ReservationTimeoutReason = "Timeout"
+
+	defaultWaitTime    = 10 * time.Minute
+	defaultLockTimeout = 30 * time.Minute
+)
+
+/*
+   actions: "enqueue, allocate, backfill"
+   tiers:
+   - plugins:
+     - name: priority
+     - name: gang
+     - name: reservation
+       arguments:
+         reservation.waitTime: 10m
+         reservation.lockTimeout: 30m
+*/
+
+// timeNow is the clock of the locks, it is replaced in tests
+var timeNow = time.Now
+
+// lock is the nodes locked for the target job.
+type lock struct {
+	target   api.JobID
+	nodes    map[string]struct{}
+	lockedAt time.Time
+}
+
+// lockState keeps the lock across the sessions.
+type lockState struct {
+	sync.Mutex
+	lock *lock
+	// released is when the last lock of the jobs timed out, they are not the target again until they wait again
+	released map[api.JobID]time.Time
+}
+
+var state = &lockState{released: map[api.JobID]time.Time{}}
+
+type reservationPlugin struct {
+	// Arguments given for the plugin
+	pluginArguments framework.Arguments
+
+	waitTime    time.Duration
+	lockTimeout time.Duration
+}
+
+// New return reservation plugin
+func New(arguments framework.Arguments) framework.Plugin {
+	return &reservationPlugin{
+		pluginArguments: arguments,
+		waitTime:        parseDuration(arguments, WaitTimeKey, defaultWaitTime),
+		lockTimeout:     parseDuration(arguments, LockTimeoutKey, defaultLockTimeout),
+	}
+}
+
+func parseDuration(arguments framework.Arguments, key string, defaultValue time.Duration) time.Duration {
+	value, found := arguments[key]
+	if !found {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(fmt.Sprint(value))
+	if err != nil || duration < 0 {
+		klog.Warningf("Invalid %s %v of reservation plugin, use %v", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+func (rp *reservationPlugin) Name() string {
+	return PluginName
+}
+
+func (rp *reservationPlugin) OnSessionOpen(ssn *framework.Session) {
+	klog.V(5).Infof("Enter reservation plugin ...")
+	defer klog.V(5).Infof("Leaving reservation plugin ...")
+
+	// the job of the highest priority which waits for the longest time is the target job
+	ssn.AddTargetJobFn(rp.Name(), func(jobs []*api.JobInfo) *api.JobInfo {
+		if len(jobs) == 0 {
+			return nil
+		}
+		sort.Slice(jobs, func(i, j int) bool {
+			if jobs[i].Priority != jobs[j].Priority {
+				return jobs[i].Priority > jobs[j].Priority
+			}
+			return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+		})
+		return jobs[0]
+	})
+
+	now := timeNow()
+	state.Lock()
+	defer state.Unlock()
+	rp.checkLock(ssn, now)
+	if state.lock == nil {
+		rp.lockNodes(ssn, now)
+	}
+	current := state.lock
+	if current == nil {
+		return
+	}
+
+	ssn.AddPredicateFn(rp.Name(), func(task *api.TaskInfo, node *api.NodeInfo) error {
+		if _, locked := current.nodes[node.Name]; !locked || task.Job == current.target {
+			return nil
+		}
+		return api.NewFitErrWithStatus(task, node, &api.Status{
+			Code:   api.Unschedulable,
+			Reason: fmt.Sprintf("node is reserved for job %s", current.target),
+		})
+	})
+
+	ssn.AddJobOrderFn(rp.Name(), func(l, r interface{}) int {
+		lv := l.(*api.JobInfo)
+		rv := r.(*api.JobInfo)
+		switch {
+		case lv.UID == current.target && rv.UID != current.target:
+			return -1
+		case rv.UID == current.target && lv.UID != current.target:
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// checkLock releases the lock if the target job got ready, is gone or the lock timed out.
+func (rp *reservationPlugin) checkLock(ssn *framework.Session, now time.Time) {
+	for uid, releasedAt := range state.released {
+		if _, found := ssn.Jobs[uid]; !found || now.Sub(releasedAt) >= rp.waitTime {
+			delete(state.released, uid)
+		}
+	}
+
+	current := state.lock
+	if current == nil {
+		return
+	}
+	job, found := ssn.Jobs[current.target]
+	switch {
+	case !found || job.PodGroup == nil:
+		klog.V(3).Infof("Target job <%s> is gone, release the locked nodes", current.target)
+		state.lock = nil
+		return
+	case job.IsReady():
+		klog.V(3).Infof("Target job <%s/%s> is ready, release the locked nodes", job.Namespace, job.Name)
+		rp.updateCondition(ssn, job, v1.ConditionFalse, ReservationSucceededReason,
+			fmt.Sprintf("job got ready after %v", now.Sub(current.lockedAt).Round(time.Second)))
+		state.lock = nil
+	case now.Sub(current.lockedAt) >= rp.lockTimeout:
+		klog.V(3).Infof("Locked nodes of target job <%s/%s> timed out after %v", job.Namespace, job.Name, rp.lockTimeout)
+		rp.updateCondition(ssn, job, v1.ConditionFalse, ReservationTimeoutReason,
+			fmt.Sprintf("nodes didn't drain for the job in %v", rp.lockTimeout))
+		state.released[current.target] = now
+		state.lock = nil
+	default:
+		for name := range current.nodes {
+			if _, found := ssn.Nodes[name]; !found {
+				delete(current.nodes, name)
+			}
+		}
+	}
+}
+
+// starvingJobs returns the jobs which are not ready and waited longer than the wait time.
+func (rp *reservationPlugin) starvingJobs(ssn *framework.Session, now time.Time) []*api.JobInfo {
+	var jobs []*api.JobInfo
+	for uid, job := range ssn.Jobs {
+		if job.PodGroup == nil || job.IsReady() || len(job.TaskStatusIndex[api.Pending]) == 0 {
+			continue
+		}
+		if _, found := ssn.Queues[job.Queue]; !found {
+			continue
+		}
+		if _, found := state.released[uid]; found {
+			continue
+		}
+		if now.Sub(job.CreationTimestamp.Time) < rp.waitTime {
+			continue
+		}
+		if vr := ssn.JobValid(job); vr != nil && !vr.Pass {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// lockNodes selects the target job among the starving jobs and locks the nodes it fits on, those with the most idle
+// resources first, until their allocatable resources are enough for the pending tasks of the job.
+func (rp *reservationPlugin) lockNodes(ssn *framework.Session, now time.Time) {
+	target := ssn.TargetJob(rp.starvingJobs(ssn, now))
+	if target == nil {
+		return
+	}
+
+	request := api.EmptyResource()
+	var sample *api.TaskInfo
+	for _, task := range target.TaskStatusIndex[api.Pending] {
+		request.Add(task.Resreq)
+		if sample == nil {
+			sample = task
+		}
+	}
+
+	// the nodes are checked by the predicates of the plugins opened before this one
+	if err := ssn.PrePredicateFn(sample); err != nil {
+		klog.V(3).Infof("PrePredicate for target job <%s/%s> failed: %v", target.Namespace, target.Name, err)
+		return
+	}
+	nodes := make([]*api.NodeInfo, 0, len(ssn.NodeList))
+	for _, node := range ssn.NodeList {
+		if !sample.Resreq.LessEqual(node.Allocatable, api.Zero) {
+			continue
+		}
+		if err := ssn.PredicateFn(sample, node); err != nil {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[j].Idle.LessEqual(nodes[i].Idle, api.Zero) && !nodes[i].Idle.Equal(nodes[j].Idle, api.Zero)
+	})
+
+	locked := api.EmptyResource()
+	current := &lock{target: target.UID, nodes: map[string]struct{}{}, lockedAt: now}
+	var names []string
+	for _, node := range nodes {
+		if request.LessEqual(locked, api.Zero) {
+			break
+		}
+		current.nodes[node.Name] = struct{}{}
+		names = append(names, node.Name)
+		locked.Add(node.Allocatable)
+	}
+	if !request.LessEqual(locked, api.Zero) {
+		klog.V(3).Infof("Nodes are not enough for target job <%s/%s>, request <%v>", target.Namespace, target.Name, request)
+		return
+	}
+
+	state.lock = current
+	klog.V(3).Infof("Locked nodes %v for target job <%s/%s>", names, target.Namespace, target.Name)
+	rp.updateCondition(ssn, target, v1.ConditionTrue, NodesLockedReason,
+		fmt.Sprintf("nodes %s are locked for the job until %s", strings.Join(names, ","),
+			now.Add(rp.lockTimeout).Format(time.RFC3339)))
+}
+
+func (rp *reservationPlugin) updateCondition(ssn *framework.Session, job *api.JobInfo, status v1.ConditionStatus, reason, message string) {
+	cond := &scheduling.PodGroupCondition{
+		Type:               PodGroupReserved,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		TransitionID:       string(ssn.UID),
+		Reason:             reason,
+		Message:            message,
+	}
+	if err := ssn.UpdatePodGroupCondition(job, cond); err != nil {
+		klog.Errorf("Failed to update reservation condition of job <%s/%s>: %v", job.Namespace, job.Name, err)
+	}
+}
+
+func (rp *reservationPlugin) OnSessionClose(ssn *framework.Session) {}
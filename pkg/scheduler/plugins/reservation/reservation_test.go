@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestReservation(t *testing.T) {
+	options.Default()
+	defer func() { timeNow = time.Now }()
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+
+	plugins := map[string]framework.PluginBuilder{PluginName: New}
+	trueValue := true
+	resources := func(cpu string) v1.ResourceList {
+		return api.BuildResourceList(cpu, "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...)
+	}
+	n1 := util.BuildNode("n1", resources("4"), nil)
+	n2 := util.BuildNode("n2", resources("4"), nil)
+	n3 := util.BuildNode("n3", resources("4"), nil)
+
+	// the target job needs two whole nodes, the small job arrived just now
+	buildPods := func(targetStatus v1.PodPhase, targetNodes ...string) []*v1.Pod {
+		pods := []*v1.Pod{
+			util.BuildPod("ns1", "running-0", "n1", v1.PodRunning, api.BuildResourceList("2", "1Gi"), "pg0", nil, nil),
+			util.BuildPod("ns1", "running-1", "n2", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg0", nil, nil),
+			util.BuildPod("ns1", "running-2", "n3", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg0", nil, nil),
+			util.BuildPod("ns1", "small", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+		}
+		for i, name := range []string{"big-0", "big-1"} {
+			nodeName := ""
+			if len(targetNodes) > i {
+				nodeName = targetNodes[i]
+			}
+			pods = append(pods, util.BuildPod("ns1", name, nodeName, targetStatus, api.BuildResourceList("4", "1Gi"), "pg1", nil, nil))
+		}
+		return pods
+	}
+	pg0 := util.BuildPodGroup("pg0", "ns1", "q1", 3, nil, schedulingv1beta1.PodGroupRunning)
+	pg1 := util.BuildPodGroup("pg1", "ns1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue)
+	pg1.CreationTimestamp = metav1.Time{Time: now.Add(-time.Hour)}
+	pg2 := util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg2.CreationTimestamp = metav1.Time{Time: now}
+	q1 := util.BuildQueue("q1", 1, nil)
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		lock            *lock
+		expectLocked    []string
+		expectCondition *scheduling.PodGroupCondition
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "nodes of the most idle resources are locked for the starving job",
+				Plugins:        plugins,
+				Pods:           buildPods(v1.PodPending),
+				Nodes:          []*v1.Node{n1, n2, n3},
+				PodGroups:      []*schedulingv1beta1.PodGroup{pg0, pg1, pg2},
+				Queues:         []*schedulingv1beta1.Queue{q1},
+				ExpectBindMap:  map[string]string{"ns1/small": "n3"},
+				ExpectBindsNum: 1,
+			},
+			expectLocked:    []string{"n1", "n2"},
+			expectCondition: &scheduling.PodGroupCondition{Status: v1.ConditionTrue, Reason: NodesLockedReason},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "locked nodes are released on timeout",
+				Plugins:        plugins,
+				Pods:           buildPods(v1.PodPending),
+				Nodes:          []*v1.Node{n2},
+				PodGroups:      []*schedulingv1beta1.PodGroup{pg0, pg1, pg2},
+				Queues:         []*schedulingv1beta1.Queue{q1},
+				ExpectBindMap:  map[string]string{"ns1/small": "n2"},
+				ExpectBindsNum: 1,
+			},
+			lock:            &lock{target: "ns1/pg1", nodes: map[string]struct{}{"n2": {}}, lockedAt: now.Add(-time.Hour)},
+			expectCondition: &scheduling.PodGroupCondition{Status: v1.ConditionFalse, Reason: ReservationTimeoutReason},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:           "locked nodes are released when the target job is ready",
+				Plugins:        plugins,
+				Pods:           buildPods(v1.PodRunning, "n4", "n5"),
+				Nodes:          []*v1.Node{n2},
+				PodGroups:      []*schedulingv1beta1.PodGroup{pg0, pg1, pg2},
+				Queues:         []*schedulingv1beta1.Queue{q1},
+				ExpectBindMap:  map[string]string{"ns1/small": "n2"},
+				ExpectBindsNum: 1,
+			},
+			lock:            &lock{target: "ns1/pg1", nodes: map[string]struct{}{"n2": {}}, lockedAt: now.Add(-time.Minute)},
+			expectCondition: &scheduling.PodGroupCondition{Status: v1.ConditionFalse, Reason: ReservationSucceededReason},
+		},
+	}
+
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:             PluginName,
+					EnabledPredicate: &trueValue,
+					EnabledJobOrder:  &trueValue,
+					EnabledTargetJob: &trueValue,
+				},
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			state = &lockState{lock: test.lock, released: map[api.JobID]time.Time{}}
+
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+
+			var locked []string
+			if state.lock != nil {
+				for name := range state.lock.nodes {
+					locked = append(locked, name)
+				}
+			}
+			assert.ElementsMatch(t, test.expectLocked, locked)
+
+			var condition *scheduling.PodGroupCondition
+			for _, c := range ssn.Jobs["ns1/pg1"].PodGroup.Status.Conditions {
+				if c.Type == PodGroupReserved {
+					condition = &scheduling.PodGroupCondition{Status: c.Status, Reason: c.Reason}
+				}
+			}
+			assert.Equal(t, test.expectCondition, condition)
+
+			test.Run([]framework.Action{allocate.New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
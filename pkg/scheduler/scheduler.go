@@ -53,8 +53,13 @@ type Scheduler struct {
 	configurations []conf.Configuration
 	metricsConf    map[string]string
 	dumper         schedcache.Dumper
+	tracer         *framework.TraceRecorder
+	dryRunRecorder *framework.DryRunRecorder
 }
 
+// dryRunReports is the number of the latest dry-run reports kept by the scheduler
+const dryRunReports = 20
+
 // NewScheduler returns a Scheduler
 func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, error) {
 	var watcher filewatcher.FileWatcher
@@ -74,11 +79,30 @@ func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, e
 		cache:          cache,
 		schedulePeriod: opt.SchedulePeriod,
 		dumper:         schedcache.Dumper{Cache: cache, RootDir: opt.CacheDumpFileDir},
+		dryRunRecorder: framework.NewDryRunRecorder(dryRunReports),
+	}
+	if opt.TraceSessions > 0 {
+		scheduler.tracer = framework.NewTraceRecorder(opt.TraceSessions)
 	}
 
 	return scheduler, nil
 }
 
+// CacheDumper returns the dumper of the scheduler cache.
+func (pc *Scheduler) CacheDumper() *schedcache.Dumper {
+	return &pc.dumper
+}
+
+// Tracer returns the recorder of the scheduling traces, it is nil if tracing is disabled.
+func (pc *Scheduler) Tracer() *framework.TraceRecorder {
+	return pc.tracer
+}
+
+// DryRunRecorder returns the recorder of the victims reported by the actions and plugins in dry-run mode.
+func (pc *Scheduler) DryRunRecorder() *framework.DryRunRecorder {
+	return pc.dryRunRecorder
+}
+
 // Run initializes and starts the Scheduler. It loads the configuration,
 // initializes the cache, and begins the scheduling process.
 func (pc *Scheduler) Run(stopCh <-chan struct{}) {
@@ -115,6 +139,10 @@ func (pc *Scheduler) runOnce() {
 	}
 
 	ssn := framework.OpenSession(pc.cache, plugins, configurations)
+	if pc.tracer != nil {
+		ssn.EnableTrace(pc.tracer)
+	}
+	ssn.SetDryRunRecorder(pc.dryRunRecorder)
 	defer func() {
 		framework.CloseSession(ssn)
 		metrics.UpdateE2eDuration(metrics.Duration(scheduleStartTime))
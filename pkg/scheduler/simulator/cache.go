@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	schedulingscheme "volcano.sh/apis/pkg/apis/scheduling/scheme"
+	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+)
+
+// fakeBinder accepts every binding without talking to the api server.
+type fakeBinder struct{}
+
+// Bind does nothing, the simulator cache records placements when they are issued.
+func (fb *fakeBinder) Bind(kubeClient kubernetes.Interface, tasks []*api.TaskInfo) map[api.TaskID]string {
+	return nil
+}
+
+// fakeEvictor accepts every eviction without talking to the api server.
+type fakeEvictor struct{}
+
+// Evict does nothing, the simulator cache records evictions when they are issued.
+func (fe *fakeEvictor) Evict(pod *v1.Pod, reason string) error {
+	return nil
+}
+
+// statusUpdater writes podgroup status back to the cache, as the informer would do after
+// the status was updated in the api server.
+type statusUpdater struct {
+	cache *schedcache.SchedulerCache
+}
+
+// UpdatePodStatus returns the pod without any change.
+func (su *statusUpdater) UpdatePodStatus(pod *v1.Pod) (*v1.Pod, error) {
+	return pod, nil
+}
+
+// UpdatePodGroup updates the podgroup in the cache.
+func (su *statusUpdater) UpdatePodGroup(pg *api.PodGroup) (*api.PodGroup, error) {
+	podgroup := &vcv1beta1.PodGroup{}
+	if err := schedulingscheme.Scheme.Convert(&pg.PodGroup, podgroup, nil); err != nil {
+		return nil, err
+	}
+	su.cache.AddPodGroupV1beta1(podgroup)
+	return pg.Clone(), nil
+}
+
+// UpdateQueueStatus does nothing, queue status is recomputed in every session.
+func (su *statusUpdater) UpdateQueueStatus(queue *api.QueueInfo) error {
+	return nil
+}
+
+type evictedTask struct {
+	task   *api.TaskInfo
+	reason string
+}
+
+// simulatorCache wraps a mock SchedulerCache and records the bind and evict decisions
+// made by the actions synchronously, so that they can be reported and applied to the
+// cache before the next scheduling cycle.
+type simulatorCache struct {
+	*schedcache.SchedulerCache
+
+	mutex   sync.Mutex
+	bound   []*api.TaskInfo
+	evicted []evictedTask
+}
+
+var _ schedcache.Cache = &simulatorCache{}
+
+// AddBindTask records the placement after the task was bound in the cache.
+func (sc *simulatorCache) AddBindTask(task *api.TaskInfo) error {
+	if err := sc.SchedulerCache.AddBindTask(task); err != nil {
+		return err
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.bound = append(sc.bound, task.Clone())
+	return nil
+}
+
+// Evict records the eviction after the task was marked as releasing in the cache.
+func (sc *simulatorCache) Evict(task *api.TaskInfo, reason string) error {
+	if err := sc.SchedulerCache.Evict(task, reason); err != nil {
+		return err
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.evicted = append(sc.evicted, evictedTask{task: task.Clone(), reason: reason})
+	return nil
+}
+
+// settle drains the decisions recorded in the last cycle and applies them to the cache the
+// way the api server would: bound pods start running on their node and evicted pods are deleted.
+func (sc *simulatorCache) settle() ([]*api.TaskInfo, []evictedTask) {
+	sc.mutex.Lock()
+	bound, evicted := sc.bound, sc.evicted
+	sc.bound, sc.evicted = nil, nil
+	sc.mutex.Unlock()
+
+	for _, task := range bound {
+		pod := task.Pod.DeepCopy()
+		pod.Spec.NodeName = task.NodeName
+		pod.Status.Phase = v1.PodRunning
+		sc.UpdatePod(task.Pod, pod)
+	}
+	for _, e := range evicted {
+		klog.V(4).Infof("Simulator deletes evicted pod <%s/%s>", e.task.Namespace, e.task.Name)
+		sc.DeletePod(e.task.Pod)
+	}
+
+	return bound, evicted
+}
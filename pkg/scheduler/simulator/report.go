@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Report is the result of a simulation.
+type Report struct {
+	Cycles []CycleReport `json:"cycles"`
+	// Pending lists the tasks which are still pending after the last cycle.
+	Pending []PendingTask `json:"pending,omitempty"`
+}
+
+// CycleReport records the decisions made in one scheduling cycle.
+type CycleReport struct {
+	Cycle      int         `json:"cycle"`
+	Placements []Placement `json:"placements,omitempty"`
+	Evictions  []Eviction  `json:"evictions,omitempty"`
+}
+
+// Placement is a task bound to a node.
+type Placement struct {
+	Task  string `json:"task"`
+	Job   string `json:"job"`
+	Queue string `json:"queue,omitempty"`
+	Node  string `json:"node"`
+}
+
+// Eviction is a task evicted from a node.
+type Eviction struct {
+	Task   string `json:"task"`
+	Job    string `json:"job"`
+	Node   string `json:"node"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// PendingTask is a task which could not be placed, with the reason reported by the scheduler.
+type PendingTask struct {
+	Task    string `json:"task"`
+	Job     string `json:"job"`
+	Queue   string `json:"queue,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// Placements returns the number of placements over all cycles.
+func (r *Report) Placements() int {
+	count := 0
+	for _, c := range r.Cycles {
+		count += len(c.Placements)
+	}
+	return count
+}
+
+// Evictions returns the number of evictions over all cycles.
+func (r *Report) Evictions() int {
+	count := 0
+	for _, c := range r.Cycles {
+		count += len(c.Evictions)
+	}
+	return count
+}
+
+// WriteJSON writes the report as indented json.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes the report as human readable tables.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "CYCLE\tACTION\tTASK\tJOB\tNODE\tREASON\n")
+	for _, c := range r.Cycles {
+		for _, p := range c.Placements {
+			fmt.Fprintf(tw, "%d\tbind\t%s\t%s\t%s\t\n", c.Cycle, p.Task, p.Job, p.Node)
+		}
+		for _, e := range c.Evictions {
+			fmt.Fprintf(tw, "%d\tevict\t%s\t%s\t%s\t%s\n", c.Cycle, e.Task, e.Job, e.Node, e.Reason)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Pending) != 0 {
+		fmt.Fprintf(w, "\n")
+		tw = tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		fmt.Fprintf(tw, "PENDING TASK\tJOB\tQUEUE\tREASON\tMESSAGE\n")
+		for _, p := range r.Pending {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", p.Task, p.Job, p.Queue, p.Reason, p.Message)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d placements, %d evictions, %d pending tasks in %d cycles\n",
+		r.Placements(), r.Evictions(), len(r.Pending), len(r.Cycles))
+	return err
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// Simulator replays a cache snapshot through the real actions and plugins without touching
+// a cluster: binds and evictions are recorded and applied to an in-memory cache between cycles.
+type Simulator struct {
+	cache          *simulatorCache
+	actions        []framework.Action
+	tiers          []conf.Tier
+	configurations []conf.Configuration
+}
+
+// New creates a simulator which schedules the pods of schedulerName found in the snapshot
+// with the given actions and plugin tiers.
+func New(snapshot *schedcache.Snapshot, schedulerName string, actions []framework.Action,
+	tiers []conf.Tier, configurations []conf.Configuration) *Simulator {
+	su := &statusUpdater{}
+	// an empty FakeRecorder drops all events instead of blocking when its buffer is full
+	sc := schedcache.NewCustomMockSchedulerCache(schedulerName, &fakeBinder{}, &fakeEvictor{}, su, nil, nil, &record.FakeRecorder{})
+	su.cache = sc
+	sc.LoadSnapshot(snapshot)
+
+	return &Simulator{
+		cache:          &simulatorCache{SchedulerCache: sc},
+		actions:        actions,
+		tiers:          tiers,
+		configurations: configurations,
+	}
+}
+
+// Run runs the given number of scheduling cycles and reports the decisions made in each
+// of them and the tasks still pending at the end.
+func (s *Simulator) Run(cycles int) *Report {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	s.cache.Run(stopCh)
+
+	conf.EnabledActionMap = make(map[string]bool)
+	for _, action := range s.actions {
+		conf.EnabledActionMap[action.Name()] = true
+	}
+
+	report := &Report{}
+	for i := 1; i <= cycles; i++ {
+		jobs := s.runOnce()
+
+		bound, evicted := s.cache.settle()
+		report.Cycles = append(report.Cycles, newCycleReport(i, jobs, bound, evicted))
+		klog.V(3).Infof("Simulated cycle %d: %d placements, %d evictions", i, len(bound), len(evicted))
+
+		if i == cycles {
+			report.Pending = pendingTasks(jobs)
+		}
+	}
+
+	return report
+}
+
+// runOnce runs one scheduling cycle the way Scheduler.runOnce does and returns the jobs of
+// the session after it was closed, so that their scheduling reasons can be inspected.
+func (s *Simulator) runOnce() map[api.JobID]*api.JobInfo {
+	ssn := framework.OpenSession(s.cache, s.tiers, s.configurations)
+	jobs := ssn.Jobs
+	for _, action := range s.actions {
+		action.Execute(ssn)
+	}
+	framework.CloseSession(ssn)
+
+	return jobs
+}
+
+func newCycleReport(cycle int, jobs map[api.JobID]*api.JobInfo, bound []*api.TaskInfo, evicted []evictedTask) CycleReport {
+	report := CycleReport{Cycle: cycle}
+	for _, task := range bound {
+		placement := Placement{
+			Task: taskKey(task),
+			Job:  string(task.Job),
+			Node: task.NodeName,
+		}
+		if job, found := jobs[task.Job]; found {
+			placement.Queue = string(job.Queue)
+		}
+		report.Placements = append(report.Placements, placement)
+	}
+	for _, e := range evicted {
+		report.Evictions = append(report.Evictions, Eviction{
+			Task:   taskKey(e.task),
+			Job:    string(e.task.Job),
+			Node:   e.task.NodeName,
+			Reason: e.reason,
+		})
+	}
+	sort.Slice(report.Placements, func(i, j int) bool { return report.Placements[i].Task < report.Placements[j].Task })
+	sort.Slice(report.Evictions, func(i, j int) bool { return report.Evictions[i].Task < report.Evictions[j].Task })
+	return report
+}
+
+func pendingTasks(jobs map[api.JobID]*api.JobInfo) []PendingTask {
+	var pending []PendingTask
+	for _, job := range jobs {
+		for uid, task := range job.TaskStatusIndex[api.Pending] {
+			reason, msg, _ := job.TaskSchedulingReason(uid)
+			if msg == "" {
+				msg = unschedulableMessage(job)
+			}
+			pending = append(pending, PendingTask{
+				Task:    taskKey(task),
+				Job:     string(job.UID),
+				Queue:   string(job.Queue),
+				Reason:  reason,
+				Message: msg,
+			})
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Task < pending[j].Task })
+	return pending
+}
+
+// unschedulableMessage returns the message of the Unschedulable condition set by the plugins
+// on the podgroup, e.g. by gang when the job is not ready.
+func unschedulableMessage(job *api.JobInfo) string {
+	if job.PodGroup == nil {
+		return ""
+	}
+	for _, c := range job.PodGroup.Status.Conditions {
+		if c.Type == scheduling.PodGroupUnschedulableType && c.Status == v1.ConditionTrue {
+			return c.Message
+		}
+	}
+	if job.PodGroup.Status.Phase == scheduling.PodGroupPending {
+		return fmt.Sprintf("podgroup %s is pending and has not been enqueued", job.UID)
+	}
+	return ""
+}
+
+func taskKey(task *api.TaskInfo) string {
+	return fmt.Sprintf("%s/%s", task.Namespace, task.Name)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"os"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	schedcache "volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
+	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
+	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+func TestSimulatorRun(t *testing.T) {
+	framework.RegisterPluginBuilder(gang.PluginName, gang.New)
+	framework.RegisterPluginBuilder(predicates.PluginName, predicates.New)
+	framework.RegisterPluginBuilder(proportion.PluginName, proportion.New)
+	defer framework.CleanupPluginBuilders()
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{Name: gang.PluginName, EnabledJobReady: &trueValue, EnabledJobPipelined: &trueValue, EnabledJobStarving: &trueValue},
+				{Name: predicates.PluginName, EnabledPredicate: &trueValue},
+				{Name: proportion.PluginName, EnabledQueueOrder: &trueValue, EnabledReclaimable: &trueValue, EnabledAllocatable: &trueValue},
+			},
+		},
+	}
+
+	snapshot := &schedcache.Snapshot{
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+		},
+		Queues: []*schedulingv1beta1.Queue{
+			util.BuildQueue("q1", 1, nil),
+		},
+		PodGroups: []*schedulingv1beta1.PodGroup{
+			util.BuildPodGroup("pg1", "ns1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue),
+			util.BuildPodGroup("pg2", "ns1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("ns1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+			util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+			util.BuildPod("ns1", "p3", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+			util.BuildPod("ns1", "p4", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+		},
+	}
+
+	sim := New(snapshot, "", []framework.Action{allocate.New()}, tiers, nil)
+	report := sim.Run(2)
+
+	if len(report.Cycles) != 2 {
+		t.Fatalf("want 2 cycles, got %d", len(report.Cycles))
+	}
+	if len(report.Cycles[0].Placements) != 2 {
+		t.Fatalf("want 2 placements in the first cycle, got %v", report.Cycles[0].Placements)
+	}
+	job := report.Cycles[0].Placements[0].Job
+	for _, p := range report.Cycles[0].Placements {
+		if p.Node != "n1" || p.Job != job || p.Queue != "q1" {
+			t.Errorf("want all tasks of one job of queue q1 placed on n1, got %v", report.Cycles[0].Placements)
+		}
+	}
+	// the node is full after the first cycle, the placements are kept in the cache
+	if len(report.Cycles[1].Placements) != 0 {
+		t.Errorf("want no placements in the second cycle, got %v", report.Cycles[1].Placements)
+	}
+	if len(report.Pending) != 2 {
+		t.Fatalf("want 2 pending tasks, got %v", report.Pending)
+	}
+	for _, p := range report.Pending {
+		if p.Job == job || p.Message == "" {
+			t.Errorf("unexpected pending task %v", p)
+		}
+	}
+}
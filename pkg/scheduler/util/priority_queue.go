@@ -61,6 +61,18 @@ func (q *PriorityQueue) Empty() bool {
 	return q.queue.Len() == 0
 }
 
+// Clone returns a copy of the priority queue, the elements are shared with the original queue
+func (q *PriorityQueue) Clone() *PriorityQueue {
+	items := make([]interface{}, len(q.queue.items))
+	copy(items, q.queue.items)
+	return &PriorityQueue{
+		queue: priorityQueue{
+			items:  items,
+			lessFn: q.queue.lessFn,
+		},
+	}
+}
+
 // Len returns Len of the priority queue
 func (q *PriorityQueue) Len() int {
 	return q.queue.Len()
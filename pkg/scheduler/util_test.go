@@ -45,155 +45,162 @@ tiers:
 		{
 			Plugins: []conf.PluginOption{
 				{
-					Name:                 "priority",
-					EnabledJobOrder:      &trueValue,
-					EnabledJobReady:      &trueValue,
-					EnabledJobPipelined:  &trueValue,
-					EnabledTaskOrder:     &trueValue,
-					EnabledPreemptable:   &trueValue,
-					EnablePreemptive:     &trueValue,
-					EnabledReclaimable:   &trueValue,
-					EnabledQueueOrder:    &trueValue,
-					EnabledPredicate:     &trueValue,
-					EnabledBestNode:      &trueValue,
-					EnabledNodeOrder:     &trueValue,
-					EnabledTargetJob:     &trueValue,
-					EnabledReservedNodes: &trueValue,
-					EnabledJobEnqueued:   &trueValue,
-					EnabledVictim:        &trueValue,
-					EnabledJobStarving:   &trueValue,
-					EnabledOverused:      &trueValue,
-					EnabledAllocatable:   &trueValue,
+					Name:                  "priority",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnablePreemptive:      &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledBestNode:       &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledHyperNodeOrder: &trueValue,
+					EnabledTargetJob:      &trueValue,
+					EnabledReservedNodes:  &trueValue,
+					EnabledJobEnqueued:    &trueValue,
+					EnabledVictim:         &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledAllocatable:    &trueValue,
 				},
 				{
-					Name:                 "gang",
-					EnabledJobOrder:      &trueValue,
-					EnabledJobReady:      &trueValue,
-					EnabledJobPipelined:  &trueValue,
-					EnabledTaskOrder:     &trueValue,
-					EnabledPreemptable:   &trueValue,
-					EnablePreemptive:     &trueValue,
-					EnabledReclaimable:   &trueValue,
-					EnabledQueueOrder:    &trueValue,
-					EnabledPredicate:     &trueValue,
-					EnabledBestNode:      &trueValue,
-					EnabledNodeOrder:     &trueValue,
-					EnabledTargetJob:     &trueValue,
-					EnabledReservedNodes: &trueValue,
-					EnabledJobEnqueued:   &trueValue,
-					EnabledVictim:        &trueValue,
-					EnabledJobStarving:   &trueValue,
-					EnabledOverused:      &trueValue,
-					EnabledAllocatable:   &trueValue,
+					Name:                  "gang",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnablePreemptive:      &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledBestNode:       &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledHyperNodeOrder: &trueValue,
+					EnabledTargetJob:      &trueValue,
+					EnabledReservedNodes:  &trueValue,
+					EnabledJobEnqueued:    &trueValue,
+					EnabledVictim:         &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledAllocatable:    &trueValue,
 				},
 				{
-					Name:                 "conformance",
-					EnabledJobOrder:      &trueValue,
-					EnabledJobReady:      &trueValue,
-					EnabledJobPipelined:  &trueValue,
-					EnabledTaskOrder:     &trueValue,
-					EnabledPreemptable:   &trueValue,
-					EnablePreemptive:     &trueValue,
-					EnabledReclaimable:   &trueValue,
-					EnabledQueueOrder:    &trueValue,
-					EnabledPredicate:     &trueValue,
-					EnabledBestNode:      &trueValue,
-					EnabledNodeOrder:     &trueValue,
-					EnabledTargetJob:     &trueValue,
-					EnabledReservedNodes: &trueValue,
-					EnabledJobEnqueued:   &trueValue,
-					EnabledVictim:        &trueValue,
-					EnabledJobStarving:   &trueValue,
-					EnabledOverused:      &trueValue,
-					EnabledAllocatable:   &trueValue,
+					Name:                  "conformance",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnablePreemptive:      &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledBestNode:       &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledHyperNodeOrder: &trueValue,
+					EnabledTargetJob:      &trueValue,
+					EnabledReservedNodes:  &trueValue,
+					EnabledJobEnqueued:    &trueValue,
+					EnabledVictim:         &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledAllocatable:    &trueValue,
 				},
 			},
 		},
 		{
 			Plugins: []conf.PluginOption{
 				{
-					Name:                 "drf",
-					EnabledJobOrder:      &trueValue,
-					EnabledJobReady:      &trueValue,
-					EnabledJobPipelined:  &trueValue,
-					EnabledTaskOrder:     &trueValue,
-					EnabledPreemptable:   &trueValue,
-					EnablePreemptive:     &trueValue,
-					EnabledReclaimable:   &trueValue,
-					EnabledQueueOrder:    &trueValue,
-					EnabledPredicate:     &trueValue,
-					EnabledBestNode:      &trueValue,
-					EnabledNodeOrder:     &trueValue,
-					EnabledTargetJob:     &trueValue,
-					EnabledReservedNodes: &trueValue,
-					EnabledJobEnqueued:   &trueValue,
-					EnabledVictim:        &trueValue,
-					EnabledJobStarving:   &trueValue,
-					EnabledOverused:      &trueValue,
-					EnabledAllocatable:   &trueValue,
+					Name:                  "drf",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnablePreemptive:      &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledBestNode:       &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledHyperNodeOrder: &trueValue,
+					EnabledTargetJob:      &trueValue,
+					EnabledReservedNodes:  &trueValue,
+					EnabledJobEnqueued:    &trueValue,
+					EnabledVictim:         &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledAllocatable:    &trueValue,
 				},
 				{
-					Name:                 "predicates",
-					EnabledJobOrder:      &trueValue,
-					EnabledJobReady:      &trueValue,
-					EnabledJobPipelined:  &trueValue,
-					EnabledTaskOrder:     &trueValue,
-					EnabledPreemptable:   &trueValue,
-					EnablePreemptive:     &trueValue,
-					EnabledReclaimable:   &trueValue,
-					EnabledQueueOrder:    &trueValue,
-					EnabledPredicate:     &trueValue,
-					EnabledBestNode:      &trueValue,
-					EnabledNodeOrder:     &trueValue,
-					EnabledTargetJob:     &trueValue,
-					EnabledReservedNodes: &trueValue,
-					EnabledJobEnqueued:   &trueValue,
-					EnabledVictim:        &trueValue,
-					EnabledJobStarving:   &trueValue,
-					EnabledOverused:      &trueValue,
-					EnabledAllocatable:   &trueValue,
+					Name:                  "predicates",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnablePreemptive:      &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledBestNode:       &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledHyperNodeOrder: &trueValue,
+					EnabledTargetJob:      &trueValue,
+					EnabledReservedNodes:  &trueValue,
+					EnabledJobEnqueued:    &trueValue,
+					EnabledVictim:         &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledAllocatable:    &trueValue,
 				},
 				{
-					Name:                 "proportion",
-					EnabledJobOrder:      &trueValue,
-					EnabledJobReady:      &trueValue,
-					EnabledJobPipelined:  &trueValue,
-					EnabledTaskOrder:     &trueValue,
-					EnabledPreemptable:   &trueValue,
-					EnablePreemptive:     &trueValue,
-					EnabledReclaimable:   &trueValue,
-					EnabledQueueOrder:    &trueValue,
-					EnabledPredicate:     &trueValue,
-					EnabledBestNode:      &trueValue,
-					EnabledNodeOrder:     &trueValue,
-					EnabledTargetJob:     &trueValue,
-					EnabledReservedNodes: &trueValue,
-					EnabledJobEnqueued:   &trueValue,
-					EnabledVictim:        &trueValue,
-					EnabledJobStarving:   &trueValue,
-					EnabledOverused:      &trueValue,
-					EnabledAllocatable:   &trueValue,
+					Name:                  "proportion",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnablePreemptive:      &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledBestNode:       &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledHyperNodeOrder: &trueValue,
+					EnabledTargetJob:      &trueValue,
+					EnabledReservedNodes:  &trueValue,
+					EnabledJobEnqueued:    &trueValue,
+					EnabledVictim:         &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledAllocatable:    &trueValue,
 				},
 				{
-					Name:                 "nodeorder",
-					EnabledJobOrder:      &trueValue,
-					EnabledJobReady:      &trueValue,
-					EnabledJobPipelined:  &trueValue,
-					EnabledTaskOrder:     &trueValue,
-					EnabledPreemptable:   &trueValue,
-					EnablePreemptive:     &trueValue,
-					EnabledReclaimable:   &trueValue,
-					EnabledQueueOrder:    &trueValue,
-					EnabledPredicate:     &trueValue,
-					EnabledBestNode:      &trueValue,
-					EnabledNodeOrder:     &trueValue,
-					EnabledTargetJob:     &trueValue,
-					EnabledReservedNodes: &trueValue,
-					EnabledJobEnqueued:   &trueValue,
-					EnabledVictim:        &trueValue,
-					EnabledJobStarving:   &trueValue,
-					EnabledOverused:      &trueValue,
-					EnabledAllocatable:   &trueValue,
+					Name:                  "nodeorder",
+					EnabledJobOrder:       &trueValue,
+					EnabledJobReady:       &trueValue,
+					EnabledJobPipelined:   &trueValue,
+					EnabledTaskOrder:      &trueValue,
+					EnabledPreemptable:    &trueValue,
+					EnablePreemptive:      &trueValue,
+					EnabledReclaimable:    &trueValue,
+					EnabledQueueOrder:     &trueValue,
+					EnabledPredicate:      &trueValue,
+					EnabledBestNode:       &trueValue,
+					EnabledNodeOrder:      &trueValue,
+					EnabledHyperNodeOrder: &trueValue,
+					EnabledTargetJob:      &trueValue,
+					EnabledReservedNodes:  &trueValue,
+					EnabledJobEnqueued:    &trueValue,
+					EnabledVictim:         &trueValue,
+					EnabledJobStarving:    &trueValue,
+					EnabledOverused:       &trueValue,
+					EnabledAllocatable:    &trueValue,
 				},
 			},
 		},
@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotaschedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCronLookBack bounds how far back the last start of a schedule is searched for.
+const maxCronLookBack = 366
+
+// cronSchedule is a standard 5-field cron schedule: minute, hour, day of month, month and day of week.
+// A field is "*", a value, a range "1-5", a step "*/15" or "0-30/10", or a list of them "1,3,5".
+type cronSchedule struct {
+	minute [60]bool
+	hour   [24]bool
+	dom    [32]bool
+	month  [13]bool
+	dow    [7]bool
+	// as cron does, the day matches either the day of month or the day of week if both are restricted
+	domStar bool
+	dowStar bool
+}
+
+func parseCron(spec string) (*cronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron schedule %q must have 5 fields", spec)
+	}
+	s := &cronSchedule{
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}
+	if err := parseCronField(fields[0], 0, 59, s.minute[:]); err != nil {
+		return nil, err
+	}
+	if err := parseCronField(fields[1], 0, 23, s.hour[:]); err != nil {
+		return nil, err
+	}
+	if err := parseCronField(fields[2], 1, 31, s.dom[:]); err != nil {
+		return nil, err
+	}
+	if err := parseCronField(fields[3], 1, 12, s.month[:]); err != nil {
+		return nil, err
+	}
+	// 7 is sunday as well as 0
+	var dow [8]bool
+	if err := parseCronField(fields[4], 0, 7, dow[:]); err != nil {
+		return nil, err
+	}
+	copy(s.dow[:], dow[:7])
+	s.dow[0] = s.dow[0] || dow[7]
+	return s, nil
+}
+
+func parseCronField(field string, minValue, maxValue int, values []bool) error {
+	for _, item := range strings.Split(field, ",") {
+		rangePart, step := item, 1
+		if before, after, found := strings.Cut(item, "/"); found {
+			var err error
+			if step, err = strconv.Atoi(after); err != nil || step <= 0 {
+				return fmt.Errorf("invalid step in cron field %q", field)
+			}
+			rangePart = before
+		}
+
+		low, high := minValue, maxValue
+		if rangePart != "*" {
+			before, after, found := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(before); err != nil {
+				return fmt.Errorf("invalid value in cron field %q", field)
+			}
+			high = low
+			if found {
+				if high, err = strconv.Atoi(after); err != nil {
+					return fmt.Errorf("invalid range in cron field %q", field)
+				}
+			} else if step > 1 {
+				high = maxValue
+			}
+		}
+		if low < minValue || high > maxValue || low > high {
+			return fmt.Errorf("cron field %q is out of range [%d, %d]", field, minValue, maxValue)
+		}
+		for v := low; v <= high; v += step {
+			values[v] = true
+		}
+	}
+	return nil
+}
+
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	if !s.month[t.Month()] {
+		return false
+	}
+	domMatch, dowMatch := s.dom[t.Day()], s.dow[t.Weekday()]
+	switch {
+	case s.domStar && s.dowStar:
+		return true
+	case s.domStar:
+		return dowMatch
+	case s.dowStar:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// prev returns the last time the schedule started at or before t, in the location of t. It returns false if the
+// schedule didn't start in the last year.
+func (s *cronSchedule) prev(t time.Time) (time.Time, bool) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 0; i < maxCronLookBack; i++ {
+		if s.matchDay(day) {
+			lastHour, lastMinute := 23, 59
+			if i == 0 {
+				lastHour, lastMinute = t.Hour(), t.Minute()
+			}
+			for h := lastHour; h >= 0; h-- {
+				if !s.hour[h] {
+					continue
+				}
+				m := 59
+				if h == lastHour {
+					m = lastMinute
+				}
+				for ; m >= 0; m-- {
+					if s.minute[m] {
+						return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, t.Location()), true
+					}
+				}
+			}
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package quotaschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronPrev(t *testing.T) {
+	// 2024-06-05 is a wednesday
+	now := time.Date(2024, 6, 5, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		schedule string
+		expect   time.Time
+	}{
+		{schedule: "0 9 * * 1-5", expect: time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC)},
+		{schedule: "0 20 * * 1-5", expect: time.Date(2024, 6, 4, 20, 0, 0, 0, time.UTC)},
+		{schedule: "0 0 * * 6", expect: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{schedule: "*/20 * * * *", expect: time.Date(2024, 6, 5, 10, 20, 0, 0, time.UTC)},
+		{schedule: "30 10 5 6 *", expect: now},
+		{schedule: "0 0 1 1 *", expect: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{schedule: "0 12 * * 7", expect: time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		schedule, err := parseCron(test.schedule)
+		assert.NoError(t, err, test.schedule)
+		prev, found := schedule.prev(now)
+		assert.True(t, found, test.schedule)
+		assert.Equal(t, test.expect, prev, test.schedule)
+	}
+
+	for _, invalid := range []string{"0 9 * *", "60 * * * *", "0 9 * * 1-8", "*/0 * * * *", "a * * * *"} {
+		_, err := parseCron(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
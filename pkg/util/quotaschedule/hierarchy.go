@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotaschedule
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// Violation is a queue whose quota is broken by the quotas of its children.
+type Violation struct {
+	Queue    string
+	Children []string
+	Reason   string
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("queue <%s>: %s", v.Queue, v.Reason)
+}
+
+// CheckHierarchy returns the queues whose quota is broken by their children: a child whose capability is above the
+// capability of its parent, or children whose deserved sum above the deserved of their parent. parents gives the
+// parent of each queue, the parents without quota are not checked. The capabilities are compared in the resources
+// set on both sides, as a child without capability in a resource inherits the capability of its parent.
+func CheckHierarchy(quotas map[string]Quota, parents map[string]string) []Violation {
+	children := map[string][]string{}
+	for queue, parent := range parents {
+		if _, found := quotas[parent]; found && queue != parent {
+			children[parent] = append(children[parent], queue)
+		}
+	}
+
+	var violations []Violation
+	for parent, names := range children {
+		sort.Strings(names)
+		quota := quotas[parent]
+		deserved := v1.ResourceList{}
+		for _, child := range names {
+			childQuota := quotas[child]
+			if name, found := exceeds(childQuota.Capability, quota.Capability); found {
+				violations = append(violations, Violation{
+					Queue:    parent,
+					Children: []string{child},
+					Reason:   fmt.Sprintf("capability of %s is less than the capability of child queue <%s>", name, child),
+				})
+			}
+			for name, quantity := range childQuota.Deserved {
+				sum := deserved[name]
+				sum.Add(quantity)
+				deserved[name] = sum
+			}
+		}
+		for name, sum := range deserved {
+			parentDeserved := quota.Deserved[name]
+			if sum.Cmp(parentDeserved) > 0 {
+				violations = append(violations, Violation{
+					Queue:    parent,
+					Children: names,
+					Reason:   fmt.Sprintf("deserved %s is less than the sum of the deserved of its child queues", name),
+				})
+				break
+			}
+		}
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].Queue < violations[j].Queue })
+	return violations
+}
+
+// exceeds returns a resource set in both lists whose quantity in l is above the one in r.
+func exceeds(l, r v1.ResourceList) (v1.ResourceName, bool) {
+	for name, quantity := range l {
+		limit, found := r[name]
+		if found && quantity.Cmp(limit) > 0 {
+			return name, true
+		}
+	}
+	return "", false
+}
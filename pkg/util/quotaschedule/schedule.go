@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quotaschedule parses the quota schedules of the queues, the windows in which the deserved and capability
+// of a queue are overridden, and checks them against the hierarchy of the queues.
+package quotaschedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Annotation is the key of the quota schedule of a queue, a json list of the windows in which the deserved and
+// capability of the queue are overridden, e.g.
+// [{"name":"night","schedule":"0 20 * * 1-5","duration":"12h","timeZone":"Europe/Berlin","deserved":{"cpu":"200"}}]
+const Annotation = "volcano.sh/quota-schedule"
+
+// maxStartsPerWindow bounds the starts of a window enumerated by Boundaries.
+const maxStartsPerWindow = 1000
+
+// Window is a window of a quota schedule, it starts by a cron schedule and lasts for the duration.
+type Window struct {
+	Name       string          `json:"name,omitempty"`
+	Schedule   string          `json:"schedule"`
+	Duration   string          `json:"duration"`
+	TimeZone   string          `json:"timeZone,omitempty"`
+	Deserved   v1.ResourceList `json:"deserved,omitempty"`
+	Capability v1.ResourceList `json:"capability,omitempty"`
+
+	start    *cronSchedule
+	duration time.Duration
+	location *time.Location
+}
+
+// Quota is the deserved and capability of a queue at a time, and the window they come from.
+type Quota struct {
+	Window     string
+	Deserved   v1.ResourceList
+	Capability v1.ResourceList
+}
+
+// Parse parses the quota schedule of a queue.
+func Parse(value string) ([]*Window, error) {
+	var windows []*Window
+	if err := json.Unmarshal([]byte(value), &windows); err != nil {
+		return nil, err
+	}
+	for _, w := range windows {
+		var err error
+		if w.start, err = parseCron(w.Schedule); err != nil {
+			return nil, fmt.Errorf("window %q: %v", w.Name, err)
+		}
+		if w.duration, err = time.ParseDuration(w.Duration); err != nil || w.duration <= 0 {
+			return nil, fmt.Errorf("window %q: invalid duration %q", w.Name, w.Duration)
+		}
+		if w.location, err = time.LoadLocation(w.TimeZone); err != nil {
+			return nil, fmt.Errorf("window %q: invalid time zone %q", w.Name, w.TimeZone)
+		}
+	}
+	return windows, nil
+}
+
+// lastStart returns the last time the window started at or before t.
+func (w *Window) lastStart(t time.Time) (time.Time, bool) {
+	return w.start.prev(t.In(w.location))
+}
+
+func (w *Window) activeAt(t time.Time) bool {
+	start, found := w.lastStart(t)
+	return found && t.Sub(start) < w.duration
+}
+
+// QuotaAt returns the quota of a queue at t: the spec of the queue overridden by the first window active at t.
+func QuotaAt(deserved, capability v1.ResourceList, windows []*Window, t time.Time) Quota {
+	quota := Quota{Deserved: deserved, Capability: capability}
+	for _, w := range windows {
+		if !w.activeAt(t) {
+			continue
+		}
+		quota.Window = w.Name
+		if w.Deserved != nil {
+			quota.Deserved = w.Deserved
+		}
+		if w.Capability != nil {
+			quota.Capability = w.Capability
+		}
+		break
+	}
+	return quota
+}
+
+// LastBoundary returns the last time at or before t a window started or ended.
+func LastBoundary(windows []*Window, t time.Time) (time.Time, bool) {
+	var boundary time.Time
+	found := false
+	for _, w := range windows {
+		start, ok := w.lastStart(t)
+		if !ok {
+			continue
+		}
+		last := start
+		if end := start.Add(w.duration); !end.After(t) {
+			last = end
+		}
+		if !found || last.After(boundary) {
+			boundary, found = last, true
+		}
+	}
+	return boundary, found
+}
+
+// LastStarts returns the last time at or before t each window started, the windows never started are skipped.
+func LastStarts(windows []*Window, t time.Time) []time.Time {
+	var starts []time.Time
+	for _, w := range windows {
+		if start, found := w.lastStart(t); found {
+			starts = append(starts, start)
+		}
+	}
+	return starts
+}
+
+// Boundaries returns the times in (from, to] the windows start or end, in order, so that the quotas are constant
+// between two of them.
+func Boundaries(windows []*Window, from, to time.Time) []time.Time {
+	var boundaries []time.Time
+	for _, w := range windows {
+		t := to
+		for i := 0; i < maxStartsPerWindow; i++ {
+			start, found := w.lastStart(t)
+			if !found || !start.Add(w.duration).After(from) {
+				break
+			}
+			for _, boundary := range []time.Time{start, start.Add(w.duration)} {
+				if boundary.After(from) && !boundary.After(to) {
+					boundaries = append(boundaries, boundary)
+				}
+			}
+			t = start.Add(-time.Minute)
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i].Before(boundaries[j]) })
+	return boundaries
+}
+
+// Interpolate moves the quantities of from towards to by the fraction, the resources not in both are switched to
+// the new quantity at once.
+func Interpolate(from, to v1.ResourceList, fraction float64) v1.ResourceList {
+	if to == nil || from == nil {
+		return to
+	}
+	result := make(v1.ResourceList, len(to))
+	for name, quantity := range to {
+		old, found := from[name]
+		if !found {
+			result[name] = quantity
+			continue
+		}
+		milli := old.MilliValue() + int64(float64(quantity.MilliValue()-old.MilliValue())*fraction)
+		result[name] = *resource.NewMilliQuantity(milli, quantity.Format)
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package quotaschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func buildResourceList(cpu string) v1.ResourceList {
+	return v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)}
+}
+
+func TestBoundaries(t *testing.T) {
+	windows, err := Parse(`[{"name": "night", "schedule": "0 20 * * 1-5", "duration": "12h"}]`)
+	assert.NoError(t, err)
+
+	// 2024-06-07 is a friday
+	from := time.Date(2024, 6, 7, 12, 0, 0, 0, time.UTC)
+	boundaries := Boundaries(windows, from, from.Add(7*24*time.Hour))
+	expect := []time.Time{
+		time.Date(2024, 6, 7, 20, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 8, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 10, 20, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 11, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 11, 20, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 12, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 12, 20, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 13, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 13, 20, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 14, 8, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, expect, boundaries)
+}
+
+func TestParse(t *testing.T) {
+	for _, invalid := range []string{
+		`{"schedule": "0 20 * * *"}`,
+		`[{"schedule": "0 20 * *", "duration": "1h"}]`,
+		`[{"schedule": "0 20 * * *", "duration": "forever"}]`,
+		`[{"schedule": "0 20 * * *", "duration": "-1h"}]`,
+		`[{"schedule": "0 20 * * *", "duration": "1h", "timeZone": "Mars/Olympus"}]`,
+	} {
+		_, err := Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCheckHierarchy(t *testing.T) {
+	parents := map[string]string{"a": "root", "b": "root", "a1": "a"}
+	tests := []struct {
+		name   string
+		quotas map[string]Quota
+		expect []Violation
+	}{
+		{
+			name: "valid hierarchy",
+			quotas: map[string]Quota{
+				"root": {Deserved: buildResourceList("10"), Capability: buildResourceList("10")},
+				"a":    {Deserved: buildResourceList("6"), Capability: buildResourceList("8")},
+				"b":    {Deserved: buildResourceList("4")},
+				"a1":   {Deserved: buildResourceList("6"), Capability: buildResourceList("8")},
+			},
+		},
+		{
+			name: "deserved of the children above the parent",
+			quotas: map[string]Quota{
+				"root": {Deserved: buildResourceList("10"), Capability: buildResourceList("10")},
+				"a":    {Deserved: buildResourceList("8")},
+				"b":    {Deserved: buildResourceList("4")},
+			},
+			expect: []Violation{{Queue: "root", Children: []string{"a", "b"}, Reason: "deserved cpu is less than the sum of the deserved of its child queues"}},
+		},
+		{
+			name: "capability of a child above the parent",
+			quotas: map[string]Quota{
+				"a":  {Deserved: buildResourceList("6"), Capability: buildResourceList("8")},
+				"a1": {Deserved: buildResourceList("6"), Capability: buildResourceList("9")},
+			},
+			expect: []Violation{{Queue: "a", Children: []string{"a1"}, Reason: "capability of cpu is less than the capability of child queue <a1>"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, CheckHierarchy(test.quotas, parents))
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	whv1 "k8s.io/api/admissionregistration/v1"
@@ -30,6 +31,7 @@ import (
 	"k8s.io/klog/v2"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/util/quotaschedule"
 	"volcano.sh/volcano/pkg/webhooks/router"
 	"volcano.sh/volcano/pkg/webhooks/schema"
 	"volcano.sh/volcano/pkg/webhooks/util"
@@ -117,6 +119,7 @@ func validateQueue(queue *schedulingv1beta1.Queue) error {
 	errs = append(errs, validateStateOfQueue(queue.Status.State, resourcePath.Child("spec").Child("state"))...)
 	errs = append(errs, validateWeightOfQueue(queue.Spec.Weight, resourcePath.Child("spec").Child("weight"))...)
 	errs = append(errs, validateHierarchicalAttributes(queue, resourcePath.Child("metadata").Child("annotations"))...)
+	errs = append(errs, validateQuotaSchedule(queue, resourcePath.Child("metadata").Child("annotations").Key(quotaschedule.Annotation))...)
 
 	if len(errs) > 0 {
 		return errs.ToAggregate()
@@ -182,6 +185,94 @@ func validateHierarchicalAttributes(queue *schedulingv1beta1.Queue, fldPath *fie
 	return errs
 }
 
+// quotaScheduleCheckPeriod is how far ahead the boundaries of the quota windows are checked against the hierarchy.
+const quotaScheduleCheckPeriod = 7 * 24 * time.Hour
+
+// validateQuotaSchedule checks the quota schedule of the queue and that the quotas of its windows keep the
+// hierarchy with its parent, siblings and children. The hierarchy is checked at the last start of every window of
+// these queues and at every boundary of their windows in the next week, the breaks already there with the quotas
+// in the spec are ignored.
+func validateQuotaSchedule(queue *schedulingv1beta1.Queue, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	value, found := queue.Annotations[quotaschedule.Annotation]
+	if !found {
+		return errs
+	}
+	if _, err := quotaschedule.Parse(value); err != nil {
+		return append(errs, field.Invalid(fldPath, value, fmt.Sprintf("invalid quota schedule: %v", err)))
+	}
+
+	queueList, err := config.QueueLister.List(labels.Everything())
+	if err != nil {
+		return append(errs, field.Invalid(fldPath, value, fmt.Sprintf("checking quota schedule, list queues failed: %v", err)))
+	}
+	parentOf := func(q *schedulingv1beta1.Queue) string {
+		if q.Spec.Parent == "" {
+			return "root"
+		}
+		return q.Spec.Parent
+	}
+	// the queue, its parent, siblings and children, the root queue is not checked as its quota is the cluster
+	involved := map[string]*schedulingv1beta1.Queue{queue.Name: queue}
+	for _, q := range queueList {
+		if q.Name == queue.Name || q.Name == "root" {
+			continue
+		}
+		if q.Name == parentOf(queue) || parentOf(q) == parentOf(queue) || parentOf(q) == queue.Name {
+			involved[q.Name] = q
+		}
+	}
+
+	parents := map[string]string{}
+	specQuotas := map[string]quotaschedule.Quota{}
+	schedules := map[string][]*quotaschedule.Window{}
+	now := time.Now()
+	instants := []time.Time{now}
+	for name, q := range involved {
+		parents[name] = parentOf(q)
+		specQuotas[name] = quotaschedule.Quota{Deserved: q.Spec.Deserved, Capability: q.Spec.Capability}
+		// the invalid schedules of the other queues are ignored as the scheduler uses their spec
+		windows, err := quotaschedule.Parse(q.Annotations[quotaschedule.Annotation])
+		if err != nil {
+			continue
+		}
+		schedules[name] = windows
+		instants = append(instants, quotaschedule.LastStarts(windows, now)...)
+		instants = append(instants, quotaschedule.Boundaries(windows, now, now.Add(quotaScheduleCheckPeriod))...)
+	}
+
+	broken := map[string]bool{}
+	for _, violation := range quotaschedule.CheckHierarchy(specQuotas, parents) {
+		broken[violation.Error()] = true
+	}
+	for _, instant := range instants {
+		quotas := make(map[string]quotaschedule.Quota, len(involved))
+		for name, quota := range specQuotas {
+			quotas[name] = quotaschedule.QuotaAt(quota.Deserved, quota.Capability, schedules[name], instant)
+		}
+		for _, violation := range quotaschedule.CheckHierarchy(quotas, parents) {
+			if broken[violation.Error()] || !involvesQueue(violation, queue.Name) {
+				continue
+			}
+			return append(errs, field.Invalid(fldPath, value, fmt.Sprintf("quota windows break the hierarchy at %s: %v",
+				instant.Format(time.RFC3339), violation)))
+		}
+	}
+	return errs
+}
+
+func involvesQueue(violation quotaschedule.Violation, name string) bool {
+	if violation.Queue == name {
+		return true
+	}
+	for _, child := range violation.Children {
+		if child == name {
+			return true
+		}
+	}
+	return false
+}
+
 func validateStateOfQueue(value schedulingv1beta1.QueueState, fldPath *field.Path) field.ErrorList {
 	errs := field.ErrorList{}
 
@@ -244,10 +335,16 @@ func validateHierarchicalQueue(queue *schedulingv1beta1.Queue) error {
 	if queue.Spec.Parent == "" || queue.Spec.Parent == "root" {
 		return nil
 	}
+	if queue.Spec.Parent == queue.Name {
+		return fmt.Errorf("queue %s cannot be the parent queue of itself", queue.Name)
+	}
 	parentQueue, err := config.QueueLister.Get(queue.Spec.Parent)
 	if err != nil {
 		return fmt.Errorf("failed to get parent queue of queue %s: %v", queue.Name, err)
 	}
+	if err := validateQueueAncestors(queue, parentQueue); err != nil {
+		return err
+	}
 
 	childQueueNames, err := listQueueChild(parentQueue.Name)
 	if err != nil {
@@ -266,6 +363,28 @@ func validateHierarchicalQueue(queue *schedulingv1beta1.Queue) error {
 	return nil
 }
 
+// validateQueueAncestors checks that the queue is not an ancestor of its parent queue, so that the queues keep a tree.
+func validateQueueAncestors(queue, parentQueue *schedulingv1beta1.Queue) error {
+	visited := map[string]bool{parentQueue.Name: true}
+	for ancestor := parentQueue; ancestor.Spec.Parent != "" && ancestor.Spec.Parent != "root"; {
+		if ancestor.Spec.Parent == queue.Name {
+			return fmt.Errorf("queue %s cannot be the parent queue of queue %s because it is a descendant of queue %s",
+				parentQueue.Name, queue.Name, queue.Name)
+		}
+		if visited[ancestor.Spec.Parent] {
+			return fmt.Errorf("the ancestors of queue %s are in a cycle", parentQueue.Name)
+		}
+		visited[ancestor.Spec.Parent] = true
+
+		next, err := config.QueueLister.Get(ancestor.Spec.Parent)
+		if err != nil {
+			return fmt.Errorf("failed to get ancestor queue %s of queue %s: %v", ancestor.Spec.Parent, queue.Name, err)
+		}
+		ancestor = next
+	}
+	return nil
+}
+
 func listQueueChild(parentQueueName string) ([]string, error) {
 	queueList, err := config.QueueLister.List(labels.Everything())
 	if err != nil {
@@ -33,6 +33,7 @@ import (
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
 	informers "volcano.sh/apis/pkg/client/informers/externalversions"
+	"volcano.sh/volcano/pkg/util/quotaschedule"
 	"volcano.sh/volcano/pkg/webhooks/util"
 )
 
@@ -1031,19 +1032,39 @@ func TestAdmitHierarchicalQueues(t *testing.T) {
 		t.Errorf("Marshal queue with child queue failed for %v.", err)
 	}
 
+	selfParentQueue := schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "self-parent-queue",
+		},
+		Spec: schedulingv1beta1.QueueSpec{
+			Parent: "self-parent-queue",
+			Weight: 1,
+		},
+	}
+	selfParentQueueJSON, err := json.Marshal(selfParentQueue)
+	if err != nil {
+		t.Errorf("Marshal queue with itself as parent queue failed for %v.", err)
+	}
+
+	queueWithChildUnderChild := schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "queue-with-child-queues",
+		},
+		Spec: schedulingv1beta1.QueueSpec{
+			Parent: "child-queue",
+			Weight: 1,
+		},
+	}
+	queueWithChildUnderChildJSON, err := json.Marshal(queueWithChildUnderChild)
+	if err != nil {
+		t.Errorf("Marshal queue with child queue as parent queue failed for %v.", err)
+	}
+
 	config.VolcanoClient = fakeclient.NewSimpleClientset()
 	informerFactory := informers.NewSharedInformerFactory(config.VolcanoClient, 0)
 	queueInformer := informerFactory.Scheduling().V1beta1().Queues()
 	config.QueueLister = queueInformer.Lister()
 
-	stopCh := make(chan struct{})
-	informerFactory.Start(stopCh)
-	for informerType, ok := range informerFactory.WaitForCacheSync(stopCh) {
-		if !ok {
-			panic(fmt.Errorf("failed to sync cache: %v", informerType))
-		}
-	}
-
 	queueWithJobs := schedulingv1beta1.Queue{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "queue-with-jobs",
@@ -1160,6 +1181,15 @@ func TestAdmitHierarchicalQueues(t *testing.T) {
 		t.Errorf("Create queue failed for %v.", err)
 	}
 
+	// the informer is started after the queues are created, so that they are listed when the cache syncs
+	stopCh := make(chan struct{})
+	informerFactory.Start(stopCh)
+	for informerType, ok := range informerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			panic(fmt.Errorf("failed to sync cache: %v", informerType))
+		}
+	}
+
 	testCases := []struct {
 		Name           string
 		AR             admissionv1.AdmissionReview
@@ -1255,6 +1285,73 @@ func TestAdmitHierarchicalQueues(t *testing.T) {
 				Allowed: true,
 			},
 		},
+		{
+			Name: "Queue is its own parent",
+			AR: admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "AdmissionReview",
+					APIVersion: "admission.k8s.io/v1beta1",
+				},
+				Request: &admissionv1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "scheduling.volcano.sh",
+						Version: "v1beta1",
+						Kind:    "Queue",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "scheduling.volcano.sh",
+						Version:  "v1beta1",
+						Resource: "queues",
+					},
+					Name:      "self-parent-queue",
+					Operation: "CREATE",
+					Object: runtime.RawExtension{
+						Raw: selfParentQueueJSON,
+					},
+				},
+			},
+			reviewResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "queue self-parent-queue cannot be the parent queue of itself",
+				},
+			},
+		},
+		{
+			Name: "Parent Queue is a descendant",
+			AR: admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "AdmissionReview",
+					APIVersion: "admission.k8s.io/v1beta1",
+				},
+				Request: &admissionv1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "scheduling.volcano.sh",
+						Version: "v1beta1",
+						Kind:    "Queue",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "scheduling.volcano.sh",
+						Version:  "v1beta1",
+						Resource: "queues",
+					},
+					Name:      "queue-with-child-queues",
+					Operation: "UPDATE",
+					Object: runtime.RawExtension{
+						Raw: queueWithChildUnderChildJSON,
+					},
+					OldObject: runtime.RawExtension{
+						Raw: queueWithChildJSON,
+					},
+				},
+			},
+			reviewResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "queue child-queue cannot be the parent queue of queue queue-with-child-queues because it is a descendant of queue queue-with-child-queues",
+				},
+			},
+		},
 		{
 			Name: "Delete queue with child queue",
 			AR: admissionv1.AdmissionReview{
@@ -1329,3 +1426,67 @@ func TestAdmitHierarchicalQueues(t *testing.T) {
 	}
 	close(stopCh)
 }
+
+func TestValidateQuotaSchedule(t *testing.T) {
+	config.VolcanoClient = fakeclient.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(config.VolcanoClient, 0)
+	config.QueueLister = informerFactory.Scheduling().V1beta1().Queues().Lister()
+
+	buildQueue := func(name, parent, deserved, schedule string) *schedulingv1beta1.Queue {
+		queue := &schedulingv1beta1.Queue{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: schedulingv1beta1.QueueSpec{
+				Parent:   parent,
+				Weight:   1,
+				Deserved: v1.ResourceList{v1.ResourceCPU: resource.MustParse(deserved)},
+			},
+		}
+		if schedule != "" {
+			queue.Annotations = map[string]string{quotaschedule.Annotation: schedule}
+		}
+		return queue
+	}
+	for _, queue := range []*schedulingv1beta1.Queue{buildQueue("research", "", "8", ""), buildQueue("training", "research", "4", "")} {
+		if _, err := config.VolcanoClient.SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{}); err != nil {
+			t.Errorf("Create queue failed for %v.", err)
+		}
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	tests := []struct {
+		name      string
+		queue     *schedulingv1beta1.Queue
+		expectErr bool
+	}{
+		{
+			name:  "window keeps the hierarchy",
+			queue: buildQueue("inference", "research", "4", `[{"name": "night", "schedule": "0 20 * * *", "duration": "12h", "deserved": {"cpu": "2"}}]`),
+		},
+		{
+			name:      "invalid schedule",
+			queue:     buildQueue("inference", "research", "4", `[{"name": "night", "schedule": "0 20 * * *", "duration": "forever"}]`),
+			expectErr: true,
+		},
+		{
+			name:      "window breaks the deserved of the parent",
+			queue:     buildQueue("inference", "research", "4", `[{"name": "night", "schedule": "0 20 * * *", "duration": "12h", "deserved": {"cpu": "6"}}]`),
+			expectErr: true,
+		},
+		{
+			name:      "window of the parent breaks the deserved of the children",
+			queue:     buildQueue("research", "", "8", `[{"name": "night", "schedule": "0 20 * * *", "duration": "12h", "deserved": {"cpu": "2"}}]`),
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validateQuotaSchedule(test.queue, field.NewPath("metadata").Child("annotations").Key(quotaschedule.Annotation))
+			if test.expectErr != (len(errs) > 0) {
+				t.Errorf("expect error %v, got %v", test.expectErr, errs)
+			}
+		})
+	}
+}